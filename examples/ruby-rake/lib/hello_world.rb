@@ -0,0 +1,5 @@
+# frozen_string_literal: true
+
+def say_hello(name)
+  "Hello #{name}!"
+end
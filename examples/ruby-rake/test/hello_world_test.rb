@@ -0,0 +1,10 @@
+# frozen_string_literal: true
+
+require 'test_helper'
+require 'hello_world'
+
+class HelloWorldTest < Minitest::Test
+  def test_say_hello
+    assert_equal 'Hello Sue!', say_hello('Sue')
+  end
+end
@@ -0,0 +1,10 @@
+# frozen_string_literal: true
+
+require 'minitest/autorun'
+require 'minitest/reporters'
+
+# Test results are written in JUnit format so that TCR can retrieve test statistics
+Minitest::Reporters.use! [
+  Minitest::Reporters::DefaultReporter.new,
+  Minitest::Reporters::JUnitReporter.new('test_results')
+]
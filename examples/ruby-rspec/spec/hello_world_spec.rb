@@ -0,0 +1,9 @@
+# frozen_string_literal: true
+
+require 'hello_world'
+
+RSpec.describe 'say_hello' do
+  it 'greets the provided name' do
+    expect(say_hello('Sue')).to eq('Hello Sue!')
+  end
+end
@@ -0,0 +1,3 @@
+pub fn say_hello(name: &str) -> String {
+    format!("Hello {}!", name)
+}
@@ -0,0 +1,6 @@
+use hello_world::say_hello;
+
+#[test]
+fn test_say_hello() {
+    assert_eq!("Hello Sue!", say_hello("Sue"));
+}
@@ -0,0 +1,3 @@
+object HelloWorld {
+  def sayHello(name: String): String = s"Hello $name!"
+}
@@ -0,0 +1,5 @@
+class HelloWorldTest extends munit.FunSuite {
+  test("say hello") {
+    assertEquals(HelloWorld.sayHello("Sue"), "Hello Sue!")
+  }
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/murex/tcr/language"
 	"github.com/murex/tcr/params"
 	"github.com/murex/tcr/toolchain"
+	"os"
 	"path/filepath"
 )
 
@@ -47,6 +48,7 @@ var (
 func init() {
 	checkConfigRunners = []checkPointRunner{
 		checkConfigDirectory,
+		checkConfigFile,
 		checkLanguageConfig,
 		checkToolchainConfig,
 	}
@@ -83,6 +85,21 @@ func checkConfigDirectory(p params.Params) (cp []model.CheckPoint) {
 	return cp
 }
 
+func checkConfigFile(_ params.Params) (cp []model.CheckPoint) {
+	configFilePath, _ := filepath.Abs(config.GetConfigFilePath())
+	if _, err := os.Stat(configFilePath); err != nil {
+		// TCR runs with default values when there is no configuration file
+		cp = append(cp, model.OkCheckPoint("no TCR configuration file found, using default values: ", configFilePath).WithFix(
+			"save current configuration to "+configFilePath,
+			func(p *params.Params) error {
+				return config.SaveEngineParams(*p)
+			}))
+		return cp
+	}
+	cp = append(cp, model.OkCheckPoint("TCR configuration file is ", configFilePath))
+	return cp
+}
+
 func checkLanguageConfig(_ params.Params) (cp []model.CheckPoint) {
 	return checkSubDirConfig(languageConfigDir)
 }
@@ -24,8 +24,10 @@ package checker
 
 import (
 	"github.com/murex/tcr/checker/model"
+	"github.com/murex/tcr/config"
 	"github.com/murex/tcr/params"
 	"github.com/stretchr/testify/assert"
+	"os"
 	"path/filepath"
 	"testing"
 )
@@ -70,6 +72,28 @@ func Test_check_config_directory(t *testing.T) {
 	}
 }
 
+func Test_check_config_file(t *testing.T) {
+	// Configuration file path is relative to current directory
+	previousDir, _ := os.Getwd()
+	t.Cleanup(func() { _ = os.Chdir(previousDir) })
+	_ = os.Chdir(t.TempDir())
+	configFilePath, _ := filepath.Abs(config.GetConfigFilePath())
+
+	t.Run("not found", func(t *testing.T) {
+		assert.Equal(t, []model.CheckPoint{
+			model.OkCheckPoint("no TCR configuration file found, using default values: ", configFilePath).
+				WithFix("save current configuration to "+configFilePath, nil),
+		}, withoutFixActions(checkConfigFile(*params.AParamSet())))
+	})
+	t.Run("found", func(t *testing.T) {
+		_ = os.MkdirAll(filepath.Dir(configFilePath), os.ModePerm)
+		_ = os.WriteFile(configFilePath, []byte{}, 0600)
+		assert.Equal(t, []model.CheckPoint{
+			model.OkCheckPoint("TCR configuration file is ", configFilePath),
+		}, checkConfigFile(*params.AParamSet()))
+	})
+}
+
 func Test_check_language_config(t *testing.T) {
 	currentDir, _ := filepath.Abs(".")
 	tests := []struct {
@@ -24,13 +24,22 @@ package checker
 
 import (
 	"github.com/murex/tcr/checker/model"
+	"github.com/murex/tcr/config"
 	"github.com/murex/tcr/params"
 	"github.com/murex/tcr/vcs/git"
 	"strings"
+	"time"
 )
 
 var checkGitRunners []checkPointRunner
 
+const autoPushConfigKey = "config.git.auto-push"
+
+// workingBranchName returns the name of the branch proposed when TCR is run from a root branch
+var workingBranchName = func() string {
+	return "tcr/" + time.Now().Format("20060102-150405")
+}
+
 func init() {
 	checkGitRunners = []checkPointRunner{
 		checkGitCommand,
@@ -86,7 +95,13 @@ func checkGitRepository(_ params.Params) (cp []model.CheckPoint) {
 
 	cp = append(cp, model.OkCheckPoint("git working branch is ", checkEnv.vcs.GetWorkingBranch()))
 	if checkEnv.vcs.IsOnRootBranch() {
-		cp = append(cp, model.WarningCheckPoint("running TCR from a root branch is not recommended"))
+		branch := workingBranchName()
+		rootDir := checkEnv.vcs.GetRootDir()
+		cp = append(cp, model.WarningCheckPoint("running TCR from a root branch is not recommended").WithFix(
+			"create and switch to TCR working branch "+branch,
+			func(_ *params.Params) error {
+				return git.CreateBranch(rootDir, branch)
+			}))
 	}
 	return cp
 }
@@ -115,6 +130,14 @@ func checkGitRemote(_ params.Params) (cp []model.CheckPoint) {
 func checkGitAutoPush(p params.Params) (cp []model.CheckPoint) {
 	if p.AutoPush {
 		cp = append(cp, model.OkCheckPoint("git auto-push is turned on: every commit will be pushed to origin"))
+		if checkEnv.vcs != nil && !checkEnv.vcs.IsRemoteEnabled() {
+			cp = append(cp, model.WarningCheckPoint("git auto-push is turned on but no git remote is set").WithFix(
+				"turn off git auto-push in TCR configuration",
+				func(p *params.Params) error {
+					p.AutoPush = false
+					return config.SetValue(autoPushConfigKey, "false")
+				}))
+		}
 	} else {
 		cp = append(cp, model.OkCheckPoint("git auto-push is turned off: commits will only be applied locally"))
 	}
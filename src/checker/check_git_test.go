@@ -124,6 +124,9 @@ func Test_check_git_config(t *testing.T) {
 }
 
 func Test_check_git_repository(t *testing.T) {
+	previous := workingBranchName
+	t.Cleanup(func() { workingBranchName = previous })
+	workingBranchName = func() string { return "tcr/working-branch" }
 	tests := []struct {
 		desc            string
 		sourceTreeError error
@@ -150,7 +153,8 @@ func Test_check_git_repository(t *testing.T) {
 			[]model.CheckPoint{
 				model.OkCheckPoint("git repository root is vcs-fake-root-dir"),
 				model.OkCheckPoint("git working branch is vcs-fake-working-branch"),
-				model.WarningCheckPoint("running TCR from a root branch is not recommended"),
+				model.WarningCheckPoint("running TCR from a root branch is not recommended").
+					WithFix("create and switch to TCR working branch tcr/working-branch", nil),
 			},
 		},
 	}
@@ -160,7 +164,7 @@ func Test_check_git_repository(t *testing.T) {
 			initTestCheckEnv(p)
 			checkEnv.sourceTreeErr = test.sourceTreeError
 			checkEnv.vcsErr = test.vcsError
-			assert.Equal(t, test.expected, checkGitRepository(p))
+			assert.Equal(t, test.expected, withoutFixActions(checkGitRepository(p)))
 		})
 	}
 }
@@ -210,21 +214,29 @@ func Test_check_git_auto_push(t *testing.T) {
 	tests := []struct {
 		desc     string
 		value    bool
+		vcs      vcs.Interface
 		expected []model.CheckPoint
 	}{
-		{"enabled", true, []model.CheckPoint{
+		{"enabled", true, fake.NewVCSFake(fake.Settings{RemoteEnabled: true}), []model.CheckPoint{
 			model.OkCheckPoint("git auto-push is turned on: every commit will be pushed to origin"),
 		},
 		},
-		{"disabled", false, []model.CheckPoint{
+		{"enabled with no remote", true, fake.NewVCSFake(fake.Settings{RemoteEnabled: false}), []model.CheckPoint{
+			model.OkCheckPoint("git auto-push is turned on: every commit will be pushed to origin"),
+			model.WarningCheckPoint("git auto-push is turned on but no git remote is set").
+				WithFix("turn off git auto-push in TCR configuration", nil),
+		},
+		},
+		{"disabled", false, fake.NewVCSFake(fake.Settings{RemoteEnabled: false}), []model.CheckPoint{
 			model.OkCheckPoint("git auto-push is turned off: commits will only be applied locally"),
 		},
 		},
 	}
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
+			checkEnv.vcs = test.vcs
 			p := *params.AParamSet(params.WithAutoPush(test.value))
-			assert.Equal(t, test.expected, checkGitAutoPush(p))
+			assert.Equal(t, test.expected, withoutFixActions(checkGitAutoPush(p)))
 		})
 	}
 }
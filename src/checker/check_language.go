@@ -36,9 +36,11 @@ func init() {
 		checkLanguageDetection,
 		checkLanguageSrcDirectories,
 		checkLanguageSrcPatterns,
+		checkLanguageSrcExtraFilters,
 		checkLanguageSrcFiles,
 		checkLanguageTestDirectories,
 		checkLanguageTestPatterns,
+		checkLanguageTestExtraFilters,
 		checkLanguageTestFiles,
 	}
 }
@@ -73,11 +75,11 @@ func checkLanguageDetection(p params.Params) (cp []model.CheckPoint) {
 	cp = append(cp, model.OkCheckPoint("language parameter is not set explicitly"))
 
 	if checkEnv.sourceTree == nil || !checkEnv.sourceTree.IsValid() {
-		cp = append(cp, model.ErrorCheckPoint("cannot retrieve language from base directory name"))
+		cp = append(cp, model.ErrorCheckPoint("cannot detect language from base directory"))
 		return cp
 	}
 
-	cp = append(cp, model.OkCheckPoint("using base directory name for language detection"))
+	cp = append(cp, model.OkCheckPoint("using base directory name and project files for language detection"))
 	cp = append(cp, model.OkCheckPoint("base directory is ", checkEnv.sourceTree.GetBaseDir()))
 
 	if checkEnv.langErr != nil {
@@ -85,7 +87,10 @@ func checkLanguageDetection(p params.Params) (cp []model.CheckPoint) {
 		return cp
 	}
 
-	cp = append(cp, model.OkCheckPoint("language retrieved from base directory name: ", checkEnv.lang.GetName()))
+	for _, reason := range checkEnv.detection.Reasons {
+		cp = append(cp, model.OkCheckPoint("detection: ", reason))
+	}
+	cp = append(cp, model.OkCheckPoint("detected language is ", checkEnv.lang.GetName()))
 	return cp
 }
 
@@ -109,6 +114,13 @@ func checkLanguageSrcPatterns(_ params.Params) (cp []model.CheckPoint) {
 		checkEnv.lang.GetSrcFileFilter().FilePatterns...)
 }
 
+func checkLanguageSrcExtraFilters(_ params.Params) (cp []model.CheckPoint) {
+	if checkEnv.lang == nil {
+		return cp
+	}
+	return checkFileTreeFilterExtras("source", checkEnv.lang.GetSrcFileFilter())
+}
+
 func checkLanguageSrcFiles(_ params.Params) (cp []model.CheckPoint) {
 	if checkEnv.lang == nil {
 		return cp
@@ -136,6 +148,13 @@ func checkLanguageTestPatterns(_ params.Params) (cp []model.CheckPoint) {
 		checkEnv.lang.GetTestFileFilter().FilePatterns...)
 }
 
+func checkLanguageTestExtraFilters(_ params.Params) (cp []model.CheckPoint) {
+	if checkEnv.lang == nil {
+		return cp
+	}
+	return checkFileTreeFilterExtras("test", checkEnv.lang.GetTestFileFilter())
+}
+
 func checkLanguageTestFiles(_ params.Params) (cp []model.CheckPoint) {
 	if checkEnv.lang == nil {
 		return cp
@@ -152,7 +171,8 @@ func checkLanguageFiles(desc string, fileSearcher func() ([]string, error)) (cp
 		// unreachable directories: we display a warning for each, then continue
 		for _, dir := range err.DirList() {
 			cp = append(cp, model.WarningCheckPoint(
-				"cannot access "+desc+" directory ", dir))
+				"cannot access "+desc+" directory ", dir).WithFix(
+				"create "+desc+" directory "+dir, makeDirFix(dir)))
 		}
 	default:
 		// unhandled errors
@@ -167,6 +187,29 @@ func checkLanguageFiles(desc string, fileSearcher func() ([]string, error)) (cp
 	return cp
 }
 
+// checkFileTreeFilterExtras reports optional file tree filter settings (globs, exclusions
+// and .gitignore awareness). Nothing is reported for settings that are not used
+func checkFileTreeFilterExtras(desc string, filter language.FileTreeFilter) (cp []model.CheckPoint) {
+	lists := []struct {
+		header string
+		items  []string
+	}{
+		{desc + " filename matching globs:", filter.FileGlobs},
+		{"excluded " + desc + " directories:", filter.ExcludedDirectories},
+		{"excluded " + desc + " filename patterns:", filter.ExcludedPatterns},
+		{"excluded " + desc + " filename globs:", filter.ExcludedGlobs},
+	}
+	for _, list := range lists {
+		if len(list.items) > 0 {
+			cp = append(cp, model.CheckpointsForList(list.header, "", list.items...)...)
+		}
+	}
+	if filter.UseGitignore {
+		cp = append(cp, model.OkCheckPoint(desc+" files ignored by .gitignore are excluded"))
+	}
+	return cp
+}
+
 func languageAsText() string {
 	return checkEnv.lang.GetName() + " language"
 }
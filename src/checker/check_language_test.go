@@ -79,26 +79,28 @@ func Test_check_language_detection(t *testing.T) {
 		langDetected language.LangInterface
 		langErr      error
 		sourceTree   filesystem.SourceTree
+		detection    language.Detection
 		expected     []model.CheckPoint
 	}{
 		{"language param is set",
-			"some-language", nil, nil, nil, nil},
+			"some-language", nil, nil, nil, language.Detection{}, nil},
 		{
 			"invalid source tree",
 			"", nil, nil,
-			nil,
+			nil, language.Detection{},
 			[]model.CheckPoint{
 				model.OkCheckPoint("language parameter is not set explicitly"),
-				model.ErrorCheckPoint("cannot retrieve language from base directory name"),
+				model.ErrorCheckPoint("cannot detect language from base directory"),
 			},
 		},
 		{
 			"invalid language",
 			"", nil, errors.New("wrong language"),
 			filesystem.NewFakeSourceTree("/some-path/wrong-language"),
+			language.Detection{},
 			[]model.CheckPoint{
 				model.OkCheckPoint("language parameter is not set explicitly"),
-				model.OkCheckPoint("using base directory name for language detection"),
+				model.OkCheckPoint("using base directory name and project files for language detection"),
 				model.OkCheckPoint("base directory is /some-path/wrong-language"),
 				model.ErrorCheckPoint("wrong language"),
 			},
@@ -107,11 +109,30 @@ func Test_check_language_detection(t *testing.T) {
 			"all green",
 			"", language.ALanguage(language.WithName("java")), nil,
 			filesystem.NewFakeSourceTree("/some-path/java"),
+			language.Detection{Language: "java", Reasons: []string{"base directory name matches java language"}},
 			[]model.CheckPoint{
 				model.OkCheckPoint("language parameter is not set explicitly"),
-				model.OkCheckPoint("using base directory name for language detection"),
+				model.OkCheckPoint("using base directory name and project files for language detection"),
 				model.OkCheckPoint("base directory is /some-path/java"),
-				model.OkCheckPoint("language retrieved from base directory name: java"),
+				model.OkCheckPoint("detection: base directory name matches java language"),
+				model.OkCheckPoint("detected language is java"),
+			},
+		},
+		{
+			"detected from project files",
+			"", language.ALanguage(language.WithName("go")), nil,
+			filesystem.NewFakeSourceTree("/some-path/my-project"),
+			language.Detection{Language: "go", Toolchain: "go-tools", Reasons: []string{
+				"found go.mod: go language",
+				"found go.mod: go-tools toolchain",
+			}},
+			[]model.CheckPoint{
+				model.OkCheckPoint("language parameter is not set explicitly"),
+				model.OkCheckPoint("using base directory name and project files for language detection"),
+				model.OkCheckPoint("base directory is /some-path/my-project"),
+				model.OkCheckPoint("detection: found go.mod: go language"),
+				model.OkCheckPoint("detection: found go.mod: go-tools toolchain"),
+				model.OkCheckPoint("detected language is go"),
 			},
 		},
 	}
@@ -121,6 +142,7 @@ func Test_check_language_detection(t *testing.T) {
 			checkEnv.sourceTree = test.sourceTree
 			checkEnv.lang = test.langDetected
 			checkEnv.langErr = test.langErr
+			checkEnv.detection = test.detection
 			assert.Equal(t, test.expected, checkLanguageDetection(p))
 		})
 	}
@@ -282,7 +304,7 @@ func Test_check_language_src_files(t *testing.T) {
 					return nil, &err
 				}),
 			[]model.CheckPoint{
-				model.WarningCheckPoint("cannot access source directory dir1"),
+				model.WarningCheckPoint("cannot access source directory dir1").WithFix("create source directory dir1", nil),
 				model.WarningCheckPoint("no matching source file found"),
 			},
 		},
@@ -295,7 +317,7 @@ func Test_check_language_src_files(t *testing.T) {
 					return []string{"src-file1"}, &err
 				}),
 			[]model.CheckPoint{
-				model.WarningCheckPoint("cannot access source directory dir1"),
+				model.WarningCheckPoint("cannot access source directory dir1").WithFix("create source directory dir1", nil),
 				model.OkCheckPoint("matching source files found:"),
 				model.OkCheckPoint("- src-file1"),
 			},
@@ -305,7 +327,7 @@ func Test_check_language_src_files(t *testing.T) {
 		t.Run(test.desc, func(t *testing.T) {
 			p := *params.AParamSet(params.WithLanguage(test.langName))
 			checkEnv.lang = test.lang
-			assert.Equal(t, test.expected, checkLanguageSrcFiles(p))
+			assert.Equal(t, test.expected, withoutFixActions(checkLanguageSrcFiles(p)))
 		})
 	}
 }
@@ -466,7 +488,7 @@ func Test_check_language_test_files(t *testing.T) {
 					return nil, &err
 				}),
 			[]model.CheckPoint{
-				model.WarningCheckPoint("cannot access test directory dir1"),
+				model.WarningCheckPoint("cannot access test directory dir1").WithFix("create test directory dir1", nil),
 				model.WarningCheckPoint("no matching test file found"),
 			},
 		},
@@ -479,7 +501,7 @@ func Test_check_language_test_files(t *testing.T) {
 					return []string{"test-file1"}, &err
 				}),
 			[]model.CheckPoint{
-				model.WarningCheckPoint("cannot access test directory dir1"),
+				model.WarningCheckPoint("cannot access test directory dir1").WithFix("create test directory dir1", nil),
 				model.OkCheckPoint("matching test files found:"),
 				model.OkCheckPoint("- test-file1"),
 			},
@@ -489,7 +511,62 @@ func Test_check_language_test_files(t *testing.T) {
 		t.Run(test.desc, func(t *testing.T) {
 			p := *params.AParamSet(params.WithLanguage(test.langName))
 			checkEnv.lang = test.lang
-			assert.Equal(t, test.expected, checkLanguageTestFiles(p))
+			assert.Equal(t, test.expected, withoutFixActions(checkLanguageTestFiles(p)))
+		})
+	}
+}
+
+func Test_check_language_src_extra_filters(t *testing.T) {
+	tests := []struct {
+		desc     string
+		lang     language.LangInterface
+		expected []model.CheckPoint
+	}{
+		{"no language", nil, nil},
+		{
+			"no extra filter",
+			language.ALanguage(language.WithName("xxx")),
+			nil,
+		},
+		{
+			"globs and exclusions",
+			language.ALanguage(language.WithName("xxx"),
+				language.WithSrcFiles(
+					language.AFileTreeFilter(
+						language.WithGlob("**/*.ts"),
+						language.WithExcludedDirectory("node_modules"),
+						language.WithExcludedPattern(".*\\.d\\.ts"),
+						language.WithExcludedGlob("**/*.gen.ts"),
+						language.WithGitignore(),
+					))),
+			[]model.CheckPoint{
+				model.OkCheckPoint("source filename matching globs:"),
+				model.OkCheckPoint("- **/*.ts"),
+				model.OkCheckPoint("excluded source directories:"),
+				model.OkCheckPoint("- node_modules"),
+				model.OkCheckPoint("excluded source filename patterns:"),
+				model.OkCheckPoint("- .*\\.d\\.ts"),
+				model.OkCheckPoint("excluded source filename globs:"),
+				model.OkCheckPoint("- **/*.gen.ts"),
+				model.OkCheckPoint("source files ignored by .gitignore are excluded"),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			p := *params.AParamSet()
+			checkEnv.lang = test.lang
+			assert.Equal(t, test.expected, checkLanguageSrcExtraFilters(p))
 		})
 	}
 }
+
+func Test_check_language_test_extra_filters(t *testing.T) {
+	checkEnv.lang = language.ALanguage(language.WithName("xxx"),
+		language.WithTestFiles(
+			language.AFileTreeFilter(language.WithExcludedDirectory("fixtures"))))
+	assert.Equal(t, []model.CheckPoint{
+		model.OkCheckPoint("excluded test directories:"),
+		model.OkCheckPoint("- fixtures"),
+	}, checkLanguageTestExtraFilters(*params.AParamSet()))
+}
@@ -0,0 +1,124 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package checker
+
+import (
+	"errors"
+	"github.com/murex/tcr/checker/model"
+	"github.com/murex/tcr/filesystem"
+	"github.com/murex/tcr/language"
+	"github.com/murex/tcr/params"
+	"os"
+	"path/filepath"
+)
+
+var checkModulesRunners []checkPointRunner
+
+func init() {
+	checkModulesRunners = []checkPointRunner{
+		checkModulesParameter,
+		checkModuleDefinitions,
+	}
+}
+
+func checkModules(p params.Params) (cg *model.CheckGroup) {
+	cg = model.NewCheckGroup("modules")
+	// modules are checked only for polyglot projects
+	if len(p.Modules) > 0 {
+		for _, runner := range checkModulesRunners {
+			cg.Add(runner(p)...)
+		}
+	}
+	return cg
+}
+
+func checkModulesParameter(p params.Params) (cp []model.CheckPoint) {
+	cp = append(cp, model.OkCheckPoint("polyglot project with ", len(p.Modules), " module(s)"))
+	cp = append(cp, model.OkCheckPoint("primary module is ", p.Modules[0]))
+	return cp
+}
+
+func checkModuleDefinitions(p params.Params) (cp []model.CheckPoint) {
+	sourceTree, err := filesystem.New(checkEnv.projectDir)
+	if err != nil {
+		cp = append(cp, model.ErrorCheckPoint("cannot check modules: ", err))
+		return cp
+	}
+	usedBy := make(map[string]string)
+	for _, spec := range p.Modules {
+		lang, tchnName, dir, err := resolveModule(sourceTree.GetBaseDir(), spec)
+		if err != nil {
+			cp = append(cp, model.ErrorCheckPoint("module ", spec, ": ", err))
+			continue
+		}
+		if other, found := usedBy[lang.GetName()]; found {
+			cp = append(cp, model.ErrorCheckPoint("module ", spec, ": ",
+				lang.GetName(), " language is already used by module ", other))
+			continue
+		}
+		usedBy[lang.GetName()] = spec
+		cp = append(cp, model.OkCheckPoint("module ", spec, ": ", lang.GetName(),
+			" language with ", tchnName, " toolchain in ", dir))
+	}
+	return cp
+}
+
+// resolveModule retrieves the language, toolchain name and directory of the provided module
+func resolveModule(baseDir string, spec string) (lang language.LangInterface, tchnName string, dir string, err error) {
+	m, err := params.ParseModule(spec)
+	if err != nil {
+		return nil, "", "", err
+	}
+	dir, err = filepath.Abs(filepath.Join(baseDir, m.SubDir))
+	if err != nil {
+		return nil, "", "", err
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return nil, "", "", errors.New("cannot access directory " + dir)
+	}
+	if lang, err = language.GetLanguage(m.Language, dir); err != nil {
+		return nil, "", "", err
+	}
+	tchn, err := lang.GetToolchain(m.Toolchain)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return lang, tchn.GetName(), dir, nil
+}
+
+// primaryModuleParams returns the parameters to be used for checking the primary module
+// of a polyglot project: the module's subdirectory is used as both base and work directory.
+// Returns the provided parameters when no module is configured
+func primaryModuleParams(p params.Params) params.Params {
+	if len(p.Modules) == 0 {
+		return p
+	}
+	m, err := params.ParseModule(p.Modules[0])
+	if err != nil {
+		return p
+	}
+	dir := filepath.Join(p.BaseDir, m.SubDir)
+	p.BaseDir, p.WorkDir = dir, dir
+	p.Language, p.Toolchain = m.Language, m.Toolchain
+	return p
+}
@@ -0,0 +1,140 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package checker
+
+import (
+	"github.com/murex/tcr/checker/model"
+	"github.com/murex/tcr/params"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_check_modules(t *testing.T) {
+	assertCheckGroupRunner(t,
+		checkModules,
+		&checkModulesRunners,
+		*params.AParamSet(params.WithModules("go@backend")),
+		"modules")
+}
+
+func Test_check_modules_parameter(t *testing.T) {
+	p := *params.AParamSet(params.WithModules("go@backend", "rust@frontend"))
+	expected := []model.CheckPoint{
+		model.OkCheckPoint("polyglot project with 2 module(s)"),
+		model.OkCheckPoint("primary module is go@backend"),
+	}
+	assert.Equal(t, expected, checkModulesParameter(p))
+}
+
+func Test_check_module_definitions(t *testing.T) {
+	projectDir := t.TempDir()
+	for _, subDir := range []string{"backend", "frontend"} {
+		if err := os.MkdirAll(filepath.Join(projectDir, subDir), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+	backendDir := filepath.Join(projectDir, "backend")
+	frontendDir := filepath.Join(projectDir, "frontend")
+	tests := []struct {
+		desc     string
+		modules  []string
+		expected []model.CheckPoint
+	}{
+		{
+			"valid modules",
+			[]string{"go@backend", "rust:cargo@frontend"},
+			[]model.CheckPoint{
+				model.OkCheckPoint("module go@backend: go language with go-tools toolchain in ", backendDir),
+				model.OkCheckPoint("module rust:cargo@frontend: rust language with cargo toolchain in ", frontendDir),
+			},
+		},
+		{
+			"invalid module",
+			[]string{"go"},
+			[]model.CheckPoint{
+				model.ErrorCheckPoint("module go: invalid module \"go\": expected format is [language][:toolchain]@subdirectory"),
+			},
+		},
+		{
+			"missing directory",
+			[]string{"go@missing"},
+			[]model.CheckPoint{
+				model.ErrorCheckPoint("module go@missing: cannot access directory ", filepath.Join(projectDir, "missing")),
+			},
+		},
+		{
+			"incompatible toolchain",
+			[]string{"go:cargo@backend"},
+			[]model.CheckPoint{
+				model.ErrorCheckPoint("module go:cargo@backend: cargo toolchain is not compatible with go language"),
+			},
+		},
+		{
+			"same language twice",
+			[]string{"go@backend", "go@frontend"},
+			[]model.CheckPoint{
+				model.OkCheckPoint("module go@backend: go language with go-tools toolchain in ", backendDir),
+				model.ErrorCheckPoint("module go@frontend: go language is already used by module go@backend"),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			checkEnv.projectDir = projectDir
+			p := *params.AParamSet(params.WithModules(test.modules...))
+			assert.Equal(t, test.expected, checkModuleDefinitions(p))
+		})
+	}
+}
+
+func Test_primary_module_params(t *testing.T) {
+	tests := []struct {
+		desc     string
+		p        params.Params
+		expected params.Params
+	}{
+		{
+			"no module",
+			*params.AParamSet(params.WithBaseDir("base"), params.WithLanguage("go")),
+			*params.AParamSet(params.WithBaseDir("base"), params.WithLanguage("go")),
+		},
+		{
+			"several modules",
+			*params.AParamSet(params.WithBaseDir("base"), params.WithModules("go:go-tools@backend", "rust@frontend")),
+			*params.AParamSet(
+				params.WithBaseDir(filepath.Join("base", "backend")),
+				params.WithWorkDir(filepath.Join("base", "backend")),
+				params.WithLanguage("go"),
+				params.WithToolchain("go-tools"),
+				params.WithModules("go:go-tools@backend", "rust@frontend"),
+			),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, primaryModuleParams(test.p))
+		})
+	}
+}
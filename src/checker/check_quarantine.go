@@ -0,0 +1,85 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package checker
+
+import (
+	"github.com/murex/tcr/checker/model"
+	"github.com/murex/tcr/params"
+	"github.com/murex/tcr/quarantine"
+	"github.com/murex/tcr/xunit"
+	"path/filepath"
+)
+
+var checkQuarantineRunners []checkPointRunner
+
+func init() {
+	checkQuarantineRunners = []checkPointRunner{
+		checkQuarantineFile,
+		checkQuarantinedTests,
+		checkQuarantinedTestsNowPassing,
+	}
+}
+
+func checkQuarantine(p params.Params) (cg *model.CheckGroup) {
+	cg = model.NewCheckGroup("quarantined tests")
+	for _, runner := range checkQuarantineRunners {
+		cg.Add(runner(p)...)
+	}
+	return cg
+}
+
+func checkQuarantineFile(_ params.Params) (cp []model.CheckPoint) {
+	filePath, _ := filepath.Abs(quarantine.GetConfigFilePath())
+	cp = append(cp, model.OkCheckPoint("quarantine file is ", filePath))
+	return cp
+}
+
+func checkQuarantinedTests(_ params.Params) (cp []model.CheckPoint) {
+	tests := quarantine.List()
+	if len(tests) == 0 {
+		cp = append(cp, model.OkCheckPoint("no test is quarantined"))
+		return cp
+	}
+	cp = append(cp, model.OkCheckPoint(len(tests), " quarantined test(s) failures will be ignored:"))
+	for _, test := range tests {
+		cp = append(cp, model.OkCheckPoint("- ", test))
+	}
+	return cp
+}
+
+func checkQuarantinedTestsNowPassing(_ params.Params) (cp []model.CheckPoint) {
+	if checkEnv.tchn == nil || len(quarantine.List()) == 0 {
+		return cp
+	}
+	parser := xunit.NewParser()
+	if err := parser.ParseDir(checkEnv.tchn.GetTestResultPath()); err != nil {
+		cp = append(cp, model.OkCheckPoint("no test results available for quarantined tests"))
+		return cp
+	}
+	passing, _ := quarantine.Split(parser.Stats.PassedTests)
+	for _, test := range passing {
+		cp = append(cp, model.WarningCheckPoint(
+			"quarantined test ", test, " is now passing (consider removing it from quarantine)"))
+	}
+	return cp
+}
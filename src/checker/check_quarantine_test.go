@@ -0,0 +1,143 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package checker
+
+import (
+	"github.com/murex/tcr/checker/model"
+	"github.com/murex/tcr/params"
+	"github.com/murex/tcr/quarantine"
+	"github.com/murex/tcr/toolchain"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const quarantineXUnitReport = `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+	<testsuite name="SomeSuite" tests="2" failures="1">
+		<testcase classname="SomeClass" name="fixedTest"/>
+		<testcase classname="SomeClass" name="brokenTest">
+			<failure message="test failure">Assertion failed</failure>
+		</testcase>
+	</testsuite>
+</testsuites>
+`
+
+func withQuarantinedTests(t *testing.T, tests ...string) {
+	t.Helper()
+	_ = quarantine.Remove(quarantine.List()...)
+	quarantine.Add(tests...)
+	t.Cleanup(func() {
+		_ = quarantine.Remove(quarantine.List()...)
+	})
+}
+
+func Test_check_quarantine(t *testing.T) {
+	assertCheckGroupRunner(t,
+		checkQuarantine,
+		&checkQuarantineRunners,
+		*params.AParamSet(),
+		"quarantined tests")
+}
+
+func Test_check_quarantined_tests(t *testing.T) {
+	tests := []struct {
+		desc        string
+		quarantined []string
+		expected    []model.CheckPoint
+	}{
+		{
+			"no quarantined test", nil,
+			[]model.CheckPoint{
+				model.OkCheckPoint("no test is quarantined"),
+			},
+		},
+		{
+			"some quarantined tests", []string{"test-b", "test-a"},
+			[]model.CheckPoint{
+				model.OkCheckPoint("2 quarantined test(s) failures will be ignored:"),
+				model.OkCheckPoint("- test-a"),
+				model.OkCheckPoint("- test-b"),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			withQuarantinedTests(t, test.quarantined...)
+			assert.Equal(t, test.expected, checkQuarantinedTests(*params.AParamSet()))
+		})
+	}
+}
+
+func Test_check_quarantined_tests_now_passing(t *testing.T) {
+	reportDir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(reportDir, "report.xml"), []byte(quarantineXUnitReport), 0600)
+
+	tests := []struct {
+		desc        string
+		tchn        toolchain.TchnInterface
+		workDir     string
+		quarantined []string
+		expected    []model.CheckPoint
+	}{
+		{
+			"no toolchain", nil, reportDir, []string{"SomeClass.fixedTest"}, nil,
+		},
+		{
+			"no quarantined test", toolchain.NewFakeToolchain(nil, toolchain.TestStats{}),
+			reportDir, nil, nil,
+		},
+		{
+			"no test report", toolchain.NewFakeToolchain(nil, toolchain.TestStats{}),
+			filepath.Join(reportDir, "missing"), []string{"SomeClass.fixedTest"},
+			[]model.CheckPoint{
+				model.OkCheckPoint("no test results available for quarantined tests"),
+			},
+		},
+		{
+			"quarantined test still failing", toolchain.NewFakeToolchain(nil, toolchain.TestStats{}),
+			reportDir, []string{"SomeClass.brokenTest"}, nil,
+		},
+		{
+			"quarantined test now passing", toolchain.NewFakeToolchain(nil, toolchain.TestStats{}),
+			reportDir, []string{"SomeClass.brokenTest", "SomeClass.fixedTest"},
+			[]model.CheckPoint{
+				model.WarningCheckPoint("quarantined test SomeClass.fixedTest is now passing " +
+					"(consider removing it from quarantine)"),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			withQuarantinedTests(t, test.quarantined...)
+			_ = os.MkdirAll(test.workDir, 0750)
+			_ = toolchain.SetWorkDir(test.workDir)
+			if test.workDir != reportDir {
+				_ = os.RemoveAll(test.workDir)
+			}
+			checkEnv.tchn = test.tchn
+			assert.Equal(t, test.expected, checkQuarantinedTestsNowPassing(*params.AParamSet()))
+		})
+	}
+}
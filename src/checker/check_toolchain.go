@@ -26,7 +26,9 @@ import (
 	"github.com/murex/tcr/checker/model"
 	"github.com/murex/tcr/params"
 	"github.com/murex/tcr/toolchain"
+	"os"
 	"runtime"
+	"strings"
 )
 
 var checkToolchainRunners []checkPointRunner
@@ -39,7 +41,13 @@ func init() {
 		checkToolchainPlatform,
 		checkToolchainBuildCommand,
 		checkToolchainTestCommand,
+		checkToolchainSlowTestCommand,
+		checkToolchainTestSelection,
+		checkToolchainSteps,
+		checkToolchainInfraErrorDetection,
+		checkToolchainCommandTemplates,
 		checkToolchainTestResultDir,
+		checkToolchainCoverageReport,
 	}
 }
 
@@ -94,13 +102,23 @@ func checkToolchainDetection(p params.Params) (cp []model.CheckPoint) {
 		return cp
 	}
 
-	cp = append(cp, model.OkCheckPoint("using language's default toolchain"))
+	if checkEnv.detection.Toolchain != "" {
+		cp = append(cp, model.OkCheckPoint("using toolchain detected from project files"))
+	} else {
+		cp = append(cp, model.OkCheckPoint("using language's default toolchain"))
+	}
 
 	if checkEnv.tchnErr != nil {
 		cp = append(cp, model.ErrorCheckPoint(checkEnv.tchnErr))
 		return cp
 	}
 
+	if checkEnv.detection.Toolchain != "" {
+		cp = append(cp, model.OkCheckPoint("detected toolchain for ",
+			checkEnv.lang.GetName(), " language is ",
+			checkEnv.detection.Toolchain))
+		return cp
+	}
 	cp = append(cp, model.OkCheckPoint("default toolchain for ",
 		checkEnv.lang.GetName(), " language is ",
 		checkEnv.lang.GetToolchains().Default))
@@ -120,14 +138,68 @@ func checkToolchainBuildCommand(_ params.Params) (cp []model.CheckPoint) {
 	if checkEnv.tchn == nil {
 		return cp
 	}
-	return checkCommandLine("build", checkEnv.tchn.BuildCommandPath(), checkEnv.tchn.BuildCommandLine())
+	cp = append(cp, checkCommandLine("build",
+		checkEnv.tchn.BuildCommandPath(), checkEnv.tchn.BuildCommandLine())...)
+	return append(cp, checkCommandSettings("build", checkEnv.tchn.GetBuildCommands())...)
 }
 
 func checkToolchainTestCommand(_ params.Params) (cp []model.CheckPoint) {
 	if checkEnv.tchn == nil {
 		return cp
 	}
-	return checkCommandLine("test", checkEnv.tchn.TestCommandPath(), checkEnv.tchn.TestCommandLine())
+	cp = append(cp, checkCommandLine("test",
+		checkEnv.tchn.TestCommandPath(), checkEnv.tchn.TestCommandLine())...)
+	return append(cp, checkCommandSettings("test", checkEnv.tchn.GetTestCommands())...)
+}
+
+func checkToolchainSlowTestCommand(_ params.Params) (cp []model.CheckPoint) {
+	if checkEnv.tchn == nil {
+		return cp
+	}
+	if len(checkEnv.tchn.GetSlowTestCommands()) == 0 {
+		cp = append(cp, model.OkCheckPoint("slow test command is not configured"))
+		return cp
+	}
+	cp = append(cp, checkCommandLine("slow test",
+		checkEnv.tchn.SlowTestCommandPath(), checkEnv.tchn.SlowTestCommandLine())...)
+	return append(cp, checkCommandSettings("slow test", checkEnv.tchn.GetSlowTestCommands())...)
+}
+
+func checkToolchainTestSelection(_ params.Params) (cp []model.CheckPoint) {
+	if checkEnv.tchn == nil {
+		return cp
+	}
+
+	settings := checkEnv.tchn.GetTestSelection()
+	if settings == nil {
+		cp = append(cp, model.OkCheckPoint("test selection is not configured (all tests run on every cycle)"))
+		return cp
+	}
+
+	cp = append(cp, model.OkCheckPoint("test selection mapping is ", settings.Mapping))
+	if settings.Mapping == toolchain.ScriptMapping {
+		cp = append(cp, model.OkCheckPoint("test selection script is ", settings.Script))
+	}
+	if settings.FullRunEvery == 0 {
+		cp = append(cp, model.OkCheckPoint("periodic full test run is disabled"))
+	} else {
+		cp = append(cp, model.OkCheckPoint("all tests run every ", settings.FullRunEvery, " cycles"))
+	}
+	cp = append(cp, checkCommandLine("test selection",
+		checkEnv.tchn.SelectedTestCommandPath(), checkEnv.tchn.SelectedTestCommandLine())...)
+	return append(cp, checkCommandSettings("test selection", settings.GetTestCommands())...)
+}
+
+func checkToolchainCommandTemplates(_ params.Params) (cp []model.CheckPoint) {
+	if checkEnv.tchn == nil {
+		return cp
+	}
+	if err := checkEnv.tchn.CheckCommandTemplates(); err != nil {
+		cp = append(cp, model.ErrorCheckPoint("invalid toolchain command template: ", err))
+		return cp
+	}
+	cp = append(cp, model.OkCheckPoint("toolchain command templates are valid"))
+	return cp
 }
 
 func checkCommandLine(name string, cmdPath string, cmdLine string) (cp []model.CheckPoint) {
@@ -143,6 +215,76 @@ func checkCommandLine(name string, cmdPath string, cmdLine string) (cp []model.C
 	return cp
 }
 
+func checkToolchainSteps(_ params.Params) (cp []model.CheckPoint) {
+	if checkEnv.tchn == nil {
+		return cp
+	}
+	cp = append(cp, checkSteps("build", checkEnv.tchn.GetBuildSteps())...)
+	cp = append(cp, checkSteps("test", checkEnv.tchn.GetTestSteps())...)
+	return cp
+}
+
+func checkSteps(phase string, steps []toolchain.Step) (cp []model.CheckPoint) {
+	if len(steps) == 0 {
+		cp = append(cp, model.OkCheckPoint("no additional ", phase, " step is configured"))
+		return cp
+	}
+	for _, step := range steps {
+		name := phase + " step " + step.Name
+		if step.FailsCycle {
+			cp = append(cp, model.OkCheckPoint(name, " fails the cycle when failing"))
+		} else {
+			cp = append(cp, model.OkCheckPoint(name, " does not fail the cycle when failing"))
+		}
+		if step.CommandPath() == "" {
+			cp = append(cp, model.ErrorCheckPoint(name, " has no command for local platform"))
+			continue
+		}
+		cp = append(cp, checkCommandLine(name, step.CommandPath(), step.CommandLine())...)
+		cp = append(cp, checkCommandSettings(name, step.GetCommands())...)
+	}
+	return cp
+}
+
+// checkCommandSettings reports the optional work directory and environment
+// variables of the command compatible with the local platform
+func checkCommandSettings(name string, commands []toolchain.Command) (cp []model.CheckPoint) {
+	cmd := toolchain.FindCompatibleCommand(commands)
+	if cmd == nil {
+		return cp
+	}
+	if cmd.WorkDir != "" {
+		if strings.Contains(cmd.WorkDir, "{{") {
+			cp = append(cp, model.OkCheckPoint(name, " command work directory is ", cmd.WorkDir))
+		} else if info, err := os.Stat(cmd.WorkDirPath()); err != nil || !info.IsDir() {
+			cp = append(cp, model.ErrorCheckPoint("cannot access ", name, " command work directory: ", cmd.WorkDirPath()))
+		} else {
+			cp = append(cp, model.OkCheckPoint(name, " command work directory is ", cmd.WorkDirPath()))
+		}
+	}
+	for _, entry := range cmd.EnvAsList() {
+		cp = append(cp, model.OkCheckPoint(name, " command environment variable: ", entry))
+	}
+	if cmd.ExitCodes.IsSet() {
+		cp = append(cp, model.OkCheckPoint(name, " command exit codes: pass=", cmd.ExitCodes.Pass,
+			" test-failure=", cmd.ExitCodes.TestFailure, " infra-error=", cmd.ExitCodes.InfraError))
+	}
+	return cp
+}
+
+func checkToolchainInfraErrorDetection(_ params.Params) (cp []model.CheckPoint) {
+	if checkEnv.tchn == nil {
+		return cp
+	}
+	if checkEnv.tchn.DetectsInfraErrors() {
+		cp = append(cp, model.OkCheckPoint(
+			"failing tests with no test report or no test run are considered as infrastructure errors"))
+	} else {
+		cp = append(cp, model.OkCheckPoint("infrastructure error detection from test reports is turned off"))
+	}
+	return cp
+}
+
 func checkToolchainTestResultDir(_ params.Params) (cp []model.CheckPoint) {
 	if checkEnv.tchn == nil {
 		return cp
@@ -156,7 +298,35 @@ func checkToolchainTestResultDir(_ params.Params) (cp []model.CheckPoint) {
 		cp = append(cp, model.OkCheckPoint("test result directory parameter is ", dir))
 	}
 
+	path := checkEnv.tchn.GetTestResultPath()
+	cp = append(cp, model.OkCheckPoint("test result directory absolute path is ", path))
+	if _, err := os.Stat(path); err != nil {
+		// The test result directory is usually created by the first test run
+		cp = append(cp, model.OkCheckPoint("test result directory does not exist yet: ", path).WithFix(
+			"create test result directory "+path, makeDirFix(path)))
+	}
+	return cp
+}
+
+func checkToolchainCoverageReport(_ params.Params) (cp []model.CheckPoint) {
+	if checkEnv.tchn == nil {
+		return cp
+	}
+
+	settings := checkEnv.tchn.GetCoverageReport()
+	if settings == nil {
+		cp = append(cp, model.OkCheckPoint("coverage report is not configured"))
+		return cp
+	}
+
+	cp = append(cp, model.OkCheckPoint("coverage report format is ", settings.Format))
 	cp = append(cp, model.OkCheckPoint(
-		"test result directory absolute path is ", checkEnv.tchn.GetTestResultPath()))
+		"coverage report absolute path is ", checkEnv.tchn.GetCoverageReportPath()))
+	if settings.Threshold == 0 {
+		cp = append(cp, model.OkCheckPoint("coverage threshold is not set (coverage is recorded but not enforced)"))
+	} else {
+		cp = append(cp, model.OkCheckPoint(
+			"coverage threshold on changed source files is ", settings.Threshold, "%"))
+	}
 	return cp
 }
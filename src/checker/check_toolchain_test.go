@@ -25,10 +25,12 @@ package checker
 import (
 	"errors"
 	"github.com/murex/tcr/checker/model"
+	"github.com/murex/tcr/coverage"
 	"github.com/murex/tcr/language"
 	"github.com/murex/tcr/params"
 	"github.com/murex/tcr/toolchain"
 	"github.com/stretchr/testify/assert"
+	"os/exec"
 	"path/filepath"
 	"runtime"
 	"testing"
@@ -121,14 +123,15 @@ func Test_check_toolchain_detection(t *testing.T) {
 		tchnErr      error
 		lang         language.LangInterface
 		langErr      error
+		detection    language.Detection
 		expected     []model.CheckPoint
 	}{
 		{"toolchain param is set",
-			"some-toolchain", nil, nil, nil, nil, nil},
+			"some-toolchain", nil, nil, nil, nil, language.Detection{}, nil},
 		{
 			"unknown language",
 			"", nil, nil,
-			nil, errors.New("unknown language"),
+			nil, errors.New("unknown language"), language.Detection{},
 			[]model.CheckPoint{
 				model.OkCheckPoint("toolchain parameter is not set explicitly"),
 				model.WarningCheckPoint("language is unknown"),
@@ -142,7 +145,7 @@ func Test_check_toolchain_detection(t *testing.T) {
 				language.WithName("java"),
 				language.WithDefaultToolchain("gradle"),
 				language.WithCompatibleToolchain("gradle"),
-			), nil,
+			), nil, language.Detection{},
 			[]model.CheckPoint{
 				model.OkCheckPoint("toolchain parameter is not set explicitly"),
 				model.OkCheckPoint("using language's default toolchain"),
@@ -156,13 +159,29 @@ func Test_check_toolchain_detection(t *testing.T) {
 				language.WithName("java"),
 				language.WithDefaultToolchain("gradle"),
 				language.WithCompatibleToolchain("gradle"),
-			), nil,
+			), nil, language.Detection{},
 			[]model.CheckPoint{
 				model.OkCheckPoint("toolchain parameter is not set explicitly"),
 				model.OkCheckPoint("using language's default toolchain"),
 				model.OkCheckPoint("default toolchain for java language is gradle"),
 			},
 		},
+		{
+			"toolchain detected from project files",
+			"", toolchain.AToolchain(toolchain.WithName("maven")), nil,
+			language.ALanguage(
+				language.WithName("java"),
+				language.WithDefaultToolchain("gradle"),
+				language.WithCompatibleToolchain("gradle"),
+				language.WithCompatibleToolchain("maven"),
+			), nil,
+			language.Detection{Language: "java", Toolchain: "maven"},
+			[]model.CheckPoint{
+				model.OkCheckPoint("toolchain parameter is not set explicitly"),
+				model.OkCheckPoint("using toolchain detected from project files"),
+				model.OkCheckPoint("detected toolchain for java language is maven"),
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
@@ -171,6 +190,7 @@ func Test_check_toolchain_detection(t *testing.T) {
 			checkEnv.tchnErr = test.tchnErr
 			checkEnv.lang = test.lang
 			checkEnv.langErr = test.langErr
+			checkEnv.detection = test.detection
 			assert.Equal(t, test.expected, checkToolchainDetection(p))
 		})
 	}
@@ -277,6 +297,261 @@ func Test_check_toolchain_test_command(t *testing.T) {
 	}
 }
 
+func Test_check_toolchain_slow_test_command(t *testing.T) {
+	tests := []struct {
+		desc     string
+		tchn     toolchain.TchnInterface
+		expected []model.CheckPoint
+	}{
+		{"with no toolchain", nil, nil},
+		{
+			"with no slow test command",
+			toolchain.NewFakeToolchain(nil, toolchain.TestStats{}),
+			[]model.CheckPoint{
+				model.OkCheckPoint("slow test command is not configured"),
+			},
+		},
+		{
+			"with wrong slow test command path",
+			toolchain.NewFakeToolchain(nil, toolchain.TestStats{}).WithSlowTests().
+				WithCheckCommandAccess(func() (string, error) { return "", errors.New("command not found") }),
+			[]model.CheckPoint{
+				model.OkCheckPoint("slow test command line: some-command-path "),
+				model.ErrorCheckPoint("cannot access slow test command: some-command-path"),
+			},
+		},
+		{
+			"with valid slow test command path",
+			toolchain.NewFakeToolchain(nil, toolchain.TestStats{}).WithSlowTests().
+				WithCheckCommandAccess(func() (string, error) { return "/some/path", nil }),
+			[]model.CheckPoint{
+				model.OkCheckPoint("slow test command line: some-command-path "),
+				model.OkCheckPoint("slow test command path: /some/path"),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			checkEnv.tchn = test.tchn
+			assert.Equal(t, test.expected, checkToolchainSlowTestCommand(*params.AParamSet()))
+		})
+	}
+}
+
+func Test_check_toolchain_test_selection(t *testing.T) {
+	tests := []struct {
+		desc     string
+		tchn     toolchain.TchnInterface
+		expected []model.CheckPoint
+	}{
+		{"with no toolchain", nil, nil},
+		{
+			"with no test selection",
+			toolchain.NewFakeToolchain(nil, toolchain.TestStats{}),
+			[]model.CheckPoint{
+				model.OkCheckPoint("test selection is not configured (all tests run on every cycle)"),
+			},
+		},
+		{
+			"with naming mapping and no periodic full run",
+			toolchain.NewFakeToolchain(nil, toolchain.TestStats{}).
+				WithTestSelection(toolchain.NewTestSelection(
+					toolchain.NamingMapping, "", 0, []toolchain.Command{*toolchain.ACommand()})).
+				WithCheckCommandAccess(func() (string, error) { return "/some/path", nil }),
+			[]model.CheckPoint{
+				model.OkCheckPoint("test selection mapping is naming"),
+				model.OkCheckPoint("periodic full test run is disabled"),
+				model.OkCheckPoint("test selection command line: some-command-path "),
+				model.OkCheckPoint("test selection command path: /some/path"),
+			},
+		},
+		{
+			"with script mapping and periodic full run",
+			toolchain.NewFakeToolchain(nil, toolchain.TestStats{}).
+				WithTestSelection(toolchain.NewTestSelection(
+					toolchain.ScriptMapping, "./select.sh", 5, []toolchain.Command{*toolchain.ACommand()})).
+				WithCheckCommandAccess(func() (string, error) { return "", errors.New("command not found") }),
+			[]model.CheckPoint{
+				model.OkCheckPoint("test selection mapping is script"),
+				model.OkCheckPoint("test selection script is ./select.sh"),
+				model.OkCheckPoint("all tests run every 5 cycles"),
+				model.OkCheckPoint("test selection command line: some-command-path "),
+				model.ErrorCheckPoint("cannot access test selection command: some-command-path"),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			checkEnv.tchn = test.tchn
+			assert.Equal(t, test.expected, checkToolchainTestSelection(*params.AParamSet()))
+		})
+	}
+}
+
+func Test_check_toolchain_steps(t *testing.T) {
+	tests := []struct {
+		desc     string
+		tchn     toolchain.TchnInterface
+		expected []model.CheckPoint
+	}{
+		{"with no toolchain", nil, nil},
+		{
+			"with no step",
+			toolchain.AToolchain(),
+			[]model.CheckPoint{
+				model.OkCheckPoint("no additional build step is configured"),
+				model.OkCheckPoint("no additional test step is configured"),
+			},
+		},
+		{
+			"with build and test steps",
+			toolchain.AToolchain(
+				toolchain.WithBuildStep(toolchain.NewStep("lint", false,
+					[]toolchain.Command{*toolchain.ACommand(toolchain.WithPath("go"))})),
+				toolchain.WithTestStep(toolchain.NewStep("contract", true, []toolchain.Command{})),
+			),
+			[]model.CheckPoint{
+				model.OkCheckPoint("build step lint does not fail the cycle when failing"),
+				model.OkCheckPoint("build step lint command line: go "),
+				model.OkCheckPoint("build step lint command path: ", goPath()),
+				model.OkCheckPoint("test step contract fails the cycle when failing"),
+				model.ErrorCheckPoint("test step contract has no command for local platform"),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			checkEnv.tchn = test.tchn
+			assert.Equal(t, test.expected, checkToolchainSteps(*params.AParamSet()))
+		})
+	}
+}
+
+func goPath() string {
+	path, _ := exec.LookPath("go")
+	return path
+}
+
+func Test_check_toolchain_infra_error_detection(t *testing.T) {
+	tests := []struct {
+		desc     string
+		tchn     toolchain.TchnInterface
+		expected []model.CheckPoint
+	}{
+		{"with no toolchain", nil, nil},
+		{
+			"with detection off",
+			toolchain.AToolchain(),
+			[]model.CheckPoint{
+				model.OkCheckPoint("infrastructure error detection from test reports is turned off"),
+			},
+		},
+		{
+			"with detection on",
+			toolchain.AToolchain(toolchain.WithInfraErrorDetection()),
+			[]model.CheckPoint{
+				model.OkCheckPoint(
+					"failing tests with no test report or no test run are considered as infrastructure errors"),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			checkEnv.tchn = test.tchn
+			assert.Equal(t, test.expected, checkToolchainInfraErrorDetection(*params.AParamSet()))
+		})
+	}
+}
+
+func Test_check_command_settings(t *testing.T) {
+	workdir, _ := filepath.Abs(".")
+	tests := []struct {
+		desc     string
+		commands []toolchain.Command
+		expected []model.CheckPoint
+	}{
+		{"with no compatible command", nil, nil},
+		{"with no work dir and no env", []toolchain.Command{*toolchain.ACommand()}, nil},
+		{
+			"with existing work dir",
+			[]toolchain.Command{*toolchain.ACommand(toolchain.WithWorkDir("model"))},
+			[]model.CheckPoint{
+				model.OkCheckPoint("build command work directory is ", filepath.Join(workdir, "model")),
+			},
+		},
+		{
+			"with missing work dir",
+			[]toolchain.Command{*toolchain.ACommand(toolchain.WithWorkDir("missing"))},
+			[]model.CheckPoint{
+				model.ErrorCheckPoint("cannot access build command work directory: ", filepath.Join(workdir, "missing")),
+			},
+		},
+		{
+			"with templated work dir",
+			[]toolchain.Command{*toolchain.ACommand(toolchain.WithWorkDir("{{.BaseDir}}/sub"))},
+			[]model.CheckPoint{
+				model.OkCheckPoint("build command work directory is {{.BaseDir}}/sub"),
+			},
+		},
+		{
+			"with env",
+			[]toolchain.Command{*toolchain.ACommand(
+				toolchain.WithEnv("VAR_B", "b"), toolchain.WithEnv("VAR_A", "a"))},
+			[]model.CheckPoint{
+				model.OkCheckPoint("build command environment variable: VAR_A=a"),
+				model.OkCheckPoint("build command environment variable: VAR_B=b"),
+			},
+		},
+		{
+			"with exit codes",
+			[]toolchain.Command{*toolchain.ACommand(toolchain.WithExitCodes(
+				toolchain.ExitCodeMapping{TestFailure: []int{1}, InfraError: []int{2, 3}}))},
+			[]model.CheckPoint{
+				model.OkCheckPoint("build command exit codes: pass=[] test-failure=[1] infra-error=[2 3]"),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			_ = toolchain.SetWorkDir(workdir)
+			assert.Equal(t, test.expected, checkCommandSettings("build", test.commands))
+		})
+	}
+}
+
+func Test_check_toolchain_command_templates(t *testing.T) {
+	tests := []struct {
+		desc     string
+		tchn     toolchain.TchnInterface
+		expected []model.CheckPoint
+	}{
+		{"with no toolchain", nil, nil},
+		{
+			"with valid templates",
+			toolchain.AToolchain(toolchain.WithBuildCommand(
+				toolchain.ACommand(toolchain.WithArgs([]string{"{{.WorkDir}}"})))),
+			[]model.CheckPoint{
+				model.OkCheckPoint("toolchain command templates are valid"),
+			},
+		},
+		{
+			"with invalid template",
+			toolchain.AToolchain(toolchain.WithNoBuildCommand(), toolchain.WithBuildCommand(
+				toolchain.ACommand(toolchain.WithArgs([]string{"{{.Unknown"})))),
+			[]model.CheckPoint{
+				model.ErrorCheckPoint("invalid toolchain command template: some-command-path {{.Unknown: ",
+					"template: command:1: unclosed action"),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			checkEnv.tchn = test.tchn
+			assert.Equal(t, test.expected, checkToolchainCommandTemplates(*params.AParamSet()))
+		})
+	}
+}
+
 func Test_check_toolchain_test_result_dir(t *testing.T) {
 	workdir, _ := filepath.Abs("/")
 	tests := []struct {
@@ -300,6 +575,53 @@ func Test_check_toolchain_test_result_dir(t *testing.T) {
 			[]model.CheckPoint{
 				model.OkCheckPoint("test result directory parameter is some/path"),
 				model.OkCheckPoint("test result directory absolute path is ", filepath.Join(workdir, "some/path")),
+				model.OkCheckPoint("test result directory does not exist yet: ", filepath.Join(workdir, "some/path")).
+					WithFix("create test result directory "+filepath.Join(workdir, "some/path"), nil),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			checkEnv.tchn = test.tchn
+			_ = toolchain.SetWorkDir(workdir)
+			assert.Equal(t, test.expected, withoutFixActions(checkToolchainTestResultDir(*params.AParamSet())))
+		})
+	}
+}
+
+func Test_check_toolchain_coverage_report(t *testing.T) {
+	workdir, _ := filepath.Abs("/")
+	tests := []struct {
+		desc     string
+		tchn     toolchain.TchnInterface
+		expected []model.CheckPoint
+	}{
+		{"with no toolchain", nil, nil},
+		{
+			"with no coverage report",
+			toolchain.AToolchain(),
+			[]model.CheckPoint{
+				model.OkCheckPoint("coverage report is not configured"),
+			},
+		},
+		{
+			"with coverage report and no threshold",
+			toolchain.AToolchain(toolchain.WithCoverageReport(
+				&toolchain.CoverageReport{Format: coverage.FormatLCOV, Path: "lcov.info"})),
+			[]model.CheckPoint{
+				model.OkCheckPoint("coverage report format is lcov"),
+				model.OkCheckPoint("coverage report absolute path is ", filepath.Join(workdir, "lcov.info")),
+				model.OkCheckPoint("coverage threshold is not set (coverage is recorded but not enforced)"),
+			},
+		},
+		{
+			"with coverage report and threshold",
+			toolchain.AToolchain(toolchain.WithCoverageReport(
+				&toolchain.CoverageReport{Format: coverage.FormatGoCoverProfile, Path: "cover.out", Threshold: 80})),
+			[]model.CheckPoint{
+				model.OkCheckPoint("coverage report format is go-coverprofile"),
+				model.OkCheckPoint("coverage report absolute path is ", filepath.Join(workdir, "cover.out")),
+				model.OkCheckPoint("coverage threshold on changed source files is 80%"),
 			},
 		},
 	}
@@ -307,7 +629,7 @@ func Test_check_toolchain_test_result_dir(t *testing.T) {
 		t.Run(test.desc, func(t *testing.T) {
 			checkEnv.tchn = test.tchn
 			_ = toolchain.SetWorkDir(workdir)
-			assert.Equal(t, test.expected, checkToolchainTestResultDir(*params.AParamSet()))
+			assert.Equal(t, test.expected, checkToolchainCoverageReport(*params.AParamSet()))
 		})
 	}
 }
@@ -0,0 +1,83 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package checker
+
+import (
+	"github.com/murex/tcr/checker/model"
+	"github.com/murex/tcr/params"
+	"github.com/murex/tcr/report"
+	"os"
+)
+
+// Fix goes through the same checks as Run, and proposes each remediation attached to
+// the reported checkpoints. Remediations are applied one by one after confirmation.
+// Parameters are updated when an applied remediation changes them.
+// Fix returns the number of remediations that were applied
+func Fix(p *params.Params, confirm func(message string, defaultAnswer bool) bool) (applied int) {
+	fixes := collectFixes(*p)
+	if len(fixes) == 0 {
+		report.PostInfo("No automatic fix available")
+		return 0
+	}
+	for _, fix := range fixes {
+		if !confirm("Fix available: "+fix.Description, false) {
+			continue
+		}
+		if err := fix.Apply(p); err != nil {
+			report.PostWarning("Failed to ", fix.Description, ": ", err)
+			continue
+		}
+		report.PostInfo("Applied fix: ", fix.Description)
+		applied++
+	}
+	return applied
+}
+
+// collectFixes runs the selected check groups without reporting their results, and returns
+// the remediations attached to their checkpoints. A remediation is returned only once
+// when several checkpoints propose it
+func collectFixes(p params.Params) (fixes []*model.Fix) {
+	checkEnv.projectDir = p.BaseDir
+	p = primaryModuleParams(p)
+	initCheckEnv(p)
+	proposed := make(map[string]bool)
+	for _, runner := range checkGroupRunners {
+		if !isSelected(runner.name, p) {
+			continue
+		}
+		for _, cp := range runner.run(p).GetCheckPoints() {
+			if fix := cp.GetFix(); fix != nil && !proposed[fix.Description] {
+				proposed[fix.Description] = true
+				fixes = append(fixes, fix)
+			}
+		}
+	}
+	return fixes
+}
+
+// makeDirFix returns a remediation creating the provided directory
+func makeDirFix(dir string) func(p *params.Params) error {
+	return func(_ *params.Params) error {
+		return os.MkdirAll(dir, os.ModePerm)
+	}
+}
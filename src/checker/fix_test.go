@@ -0,0 +1,143 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package checker
+
+import (
+	"errors"
+	"github.com/murex/tcr/checker/model"
+	"github.com/murex/tcr/params"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// withoutFixActions drops the function applying each checkpoint's remediation,
+// so that checkpoints can be compared while still checking remediation descriptions
+func withoutFixActions(cps []model.CheckPoint) []model.CheckPoint {
+	for i, cp := range cps {
+		if fix := cp.GetFix(); fix != nil {
+			cps[i] = cp.WithFix(fix.Description, nil)
+		}
+	}
+	return cps
+}
+
+func fixRunner(fixes ...*model.Fix) checkGroupRunner {
+	return func(_ params.Params) *model.CheckGroup {
+		cg := model.NewCheckGroup("fix runner")
+		for _, fix := range fixes {
+			cg.Add(model.WarningCheckPoint("issue fixed by: ", fix.Description).WithFix(fix.Description, fix.Apply))
+		}
+		return cg
+	}
+}
+
+func Test_fix_applies_confirmed_remediations_only(t *testing.T) {
+	var applied []string
+	remediation := func(name string) *model.Fix {
+		return &model.Fix{Description: name, Apply: func(_ *params.Params) error {
+			applied = append(applied, name)
+			return nil
+		}}
+	}
+	runnersBackup := checkGroupRunners
+	t.Cleanup(func() { checkGroupRunners = runnersBackup })
+	checkGroupRunners = named(
+		fixRunner(remediation("fix-1"), remediation("fix-2")),
+		fixRunner(remediation("fix-3")))
+
+	var proposed []string
+	confirm := func(message string, defaultAnswer bool) bool {
+		assert.False(t, defaultAnswer)
+		proposed = append(proposed, message)
+		return message != "Fix available: fix-2"
+	}
+	p := params.Params{}
+	assert.Equal(t, 2, Fix(&p, confirm))
+	assert.Equal(t, []string{"Fix available: fix-1", "Fix available: fix-2", "Fix available: fix-3"}, proposed)
+	assert.Equal(t, []string{"fix-1", "fix-3"}, applied)
+}
+
+func Test_fix_proposes_each_remediation_once(t *testing.T) {
+	count := 0
+	fix := &model.Fix{Description: "same fix", Apply: func(_ *params.Params) error {
+		count++
+		return nil
+	}}
+	runnersBackup := checkGroupRunners
+	t.Cleanup(func() { checkGroupRunners = runnersBackup })
+	checkGroupRunners = named(fixRunner(fix, fix), fixRunner(fix))
+
+	p := params.Params{}
+	assert.Equal(t, 1, Fix(&p, func(_ string, _ bool) bool { return true }))
+	assert.Equal(t, 1, count)
+}
+
+func Test_fix_does_not_count_failing_remediations(t *testing.T) {
+	runnersBackup := checkGroupRunners
+	t.Cleanup(func() { checkGroupRunners = runnersBackup })
+	checkGroupRunners = named(fixRunner(&model.Fix{Description: "failing fix", Apply: func(_ *params.Params) error {
+		return errors.New("some error")
+	}}))
+
+	p := params.Params{}
+	assert.Equal(t, 0, Fix(&p, func(_ string, _ bool) bool { return true }))
+}
+
+func Test_fix_with_no_remediation(t *testing.T) {
+	runnersBackup := checkGroupRunners
+	t.Cleanup(func() { checkGroupRunners = runnersBackup })
+	checkGroupRunners = named(func(_ params.Params) *model.CheckGroup {
+		cg := model.NewCheckGroup("no fix runner")
+		cg.Add(model.ErrorCheckPoint("issue with no fix"))
+		return cg
+	})
+
+	p := params.Params{}
+	assert.Equal(t, 0, Fix(&p, func(_ string, _ bool) bool {
+		t.Error("no remediation should be proposed")
+		return false
+	}))
+}
+
+func Test_fix_updates_params(t *testing.T) {
+	runnersBackup := checkGroupRunners
+	t.Cleanup(func() { checkGroupRunners = runnersBackup })
+	checkGroupRunners = named(fixRunner(&model.Fix{Description: "turn off auto-push", Apply: func(p *params.Params) error {
+		p.AutoPush = false
+		return nil
+	}}))
+
+	p := params.Params{AutoPush: true}
+	assert.Equal(t, 1, Fix(&p, func(_ string, _ bool) bool { return true }))
+	assert.False(t, p.AutoPush)
+}
+
+func Test_make_dir_fix(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "some", "dir")
+	assert.NoError(t, makeDirFix(dir)(&params.Params{}))
+	info, err := os.Stat(dir)
+	assert.NoError(t, err)
+	assert.True(t, info.IsDir())
+}
@@ -0,0 +1,182 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package checker
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"github.com/murex/tcr/checker/model"
+	"github.com/murex/tcr/report"
+	"io"
+	"os"
+)
+
+// Check results output formats
+const (
+	FormatText  = "text"
+	FormatJSON  = "json"
+	FormatJUnit = "junit"
+)
+
+// formatOutput is where check results are written when using a machine-readable output format
+var formatOutput io.Writer = os.Stdout
+
+// Formats returns the list of supported check results output formats
+func Formats() []string {
+	return []string{FormatText, FormatJSON, FormatJUnit}
+}
+
+// IsMachineFormat indicates if the provided output format is meant to be processed by a program
+// (such as a CI pipeline) rather than read by a user
+func IsMachineFormat(format string) bool {
+	return format == FormatJSON || format == FormatJUnit
+}
+
+type (
+	jsonCheckReport struct {
+		Status     string           `json:"status"`
+		ReturnCode int              `json:"return-code"`
+		Strict     bool             `json:"strict"`
+		Groups     []jsonCheckGroup `json:"groups"`
+	}
+
+	jsonCheckGroup struct {
+		Name        string           `json:"name"`
+		Topic       string           `json:"topic"`
+		Status      string           `json:"status"`
+		CheckPoints []jsonCheckPoint `json:"checkpoints"`
+	}
+
+	jsonCheckPoint struct {
+		Status      string `json:"status"`
+		Description string `json:"description"`
+	}
+)
+
+// writeJSON writes check results to w in JSON format. Check groups without any checkpoint are omitted
+func writeJSON(w io.Writer, results []checkGroupResult, strict bool) {
+	out := jsonCheckReport{Strict: strict, Groups: []jsonCheckGroup{}}
+	overall := model.CheckStatusOk
+	for _, result := range nonEmpty(results) {
+		group := jsonCheckGroup{
+			Name:        result.name,
+			Topic:       result.group.GetTopic(),
+			Status:      result.group.GetStatus().String(),
+			CheckPoints: []jsonCheckPoint{},
+		}
+		for _, cp := range result.group.GetCheckPoints() {
+			group.CheckPoints = append(group.CheckPoints, jsonCheckPoint{
+				Status:      cp.GetStatus().String(),
+				Description: cp.GetDescription(),
+			})
+		}
+		out.Groups = append(out.Groups, group)
+		overall = max(overall, result.group.GetStatus())
+	}
+	out.Status = overall.String()
+	out.ReturnCode = int(returnStatus(overall, strict))
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(out); err != nil {
+		report.PostError("failed to write check results: ", err)
+	}
+}
+
+type (
+	junitTestSuites struct {
+		XMLName    xml.Name         `xml:"testsuites"`
+		Name       string           `xml:"name,attr"`
+		Tests      int              `xml:"tests,attr"`
+		Failures   int              `xml:"failures,attr"`
+		TestSuites []junitTestSuite `xml:"testsuite"`
+	}
+
+	junitTestSuite struct {
+		Name      string          `xml:"name,attr"`
+		Tests     int             `xml:"tests,attr"`
+		Failures  int             `xml:"failures,attr"`
+		TestCases []junitTestCase `xml:"testcase"`
+	}
+
+	junitTestCase struct {
+		Name      string        `xml:"name,attr"`
+		ClassName string        `xml:"classname,attr"`
+		Failure   *junitFailure `xml:"failure,omitempty"`
+		SystemOut string        `xml:"system-out,omitempty"`
+	}
+
+	junitFailure struct {
+		Type    string `xml:"type,attr"`
+		Message string `xml:"message,attr"`
+	}
+)
+
+// writeJUnit writes check results to w in JUnit XML format. Each check group is a test suite,
+// and each checkpoint is a test case. Errors are reported as failures. Warnings are reported
+// as failures in strict mode, and as passing test cases with a warning in their output otherwise.
+// Check groups without any checkpoint are omitted
+func writeJUnit(w io.Writer, results []checkGroupResult, strict bool) {
+	out := junitTestSuites{Name: "tcr check"}
+	for _, result := range nonEmpty(results) {
+		suite := junitTestSuite{Name: result.name}
+		for _, cp := range result.group.GetCheckPoints() {
+			testCase := junitTestCase{Name: cp.GetDescription(), ClassName: "tcr.check." + result.name}
+			switch {
+			case returnStatus(cp.GetStatus(), strict) == model.CheckStatusError:
+				testCase.Failure = &junitFailure{Type: cp.GetStatus().String(), Message: cp.GetDescription()}
+				suite.Failures++
+			case cp.GetStatus() == model.CheckStatusWarning:
+				testCase.SystemOut = "warning: " + cp.GetDescription()
+			}
+			suite.TestCases = append(suite.TestCases, testCase)
+			suite.Tests++
+		}
+		out.TestSuites = append(out.TestSuites, suite)
+		out.Tests += suite.Tests
+		out.Failures += suite.Failures
+	}
+	_, _ = io.WriteString(w, xml.Header)
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(out); err != nil {
+		report.PostError("failed to write check results: ", err)
+	}
+	_, _ = io.WriteString(w, "\n")
+}
+
+func nonEmpty(results []checkGroupResult) (res []checkGroupResult) {
+	for _, result := range results {
+		if len(result.group.GetCheckPoints()) > 0 {
+			res = append(res, result)
+		}
+	}
+	return res
+}
+
+// returnStatus returns the status contributing to the return code, depending on strict mode
+func returnStatus(s model.CheckStatus, strict bool) model.CheckStatus {
+	if strict {
+		return s.Strict()
+	}
+	return s
+}
@@ -0,0 +1,134 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package checker
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"github.com/murex/tcr/checker/model"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func someCheckResults() []checkGroupResult {
+	okGroup := model.NewCheckGroup("ok topic")
+	okGroup.Ok("ok checkpoint")
+	warningGroup := model.NewCheckGroup("warning topic")
+	warningGroup.Ok("ok checkpoint")
+	warningGroup.Warning("warning checkpoint")
+	errorGroup := model.NewCheckGroup("error topic")
+	errorGroup.Error("error checkpoint")
+	return []checkGroupResult{
+		{name: "ok-group", group: okGroup},
+		{name: "empty-group", group: model.NewCheckGroup("empty topic")},
+		{name: "warning-group", group: warningGroup},
+		{name: "error-group", group: errorGroup},
+	}
+}
+
+func Test_write_check_results_in_json_format(t *testing.T) {
+	var output bytes.Buffer
+	writeJSON(&output, someCheckResults(), false)
+
+	var got jsonCheckReport
+	assert.NoError(t, json.Unmarshal(output.Bytes(), &got))
+	assert.Equal(t, jsonCheckReport{
+		Status:     "error",
+		ReturnCode: 2,
+		Strict:     false,
+		Groups: []jsonCheckGroup{
+			{Name: "ok-group", Topic: "ok topic", Status: "ok", CheckPoints: []jsonCheckPoint{
+				{Status: "ok", Description: "ok checkpoint"},
+			}},
+			{Name: "warning-group", Topic: "warning topic", Status: "warning", CheckPoints: []jsonCheckPoint{
+				{Status: "ok", Description: "ok checkpoint"},
+				{Status: "warning", Description: "warning checkpoint"},
+			}},
+			{Name: "error-group", Topic: "error topic", Status: "error", CheckPoints: []jsonCheckPoint{
+				{Status: "error", Description: "error checkpoint"},
+			}},
+		},
+	}, got)
+}
+
+func Test_write_check_results_in_json_format_return_code(t *testing.T) {
+	tests := []struct {
+		desc       string
+		strict     bool
+		expectedRC int
+	}{
+		{"default mode", false, 1},
+		{"strict mode", true, 2},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			var output bytes.Buffer
+			writeJSON(&output, someCheckResults()[:3], test.strict)
+			var got jsonCheckReport
+			assert.NoError(t, json.Unmarshal(output.Bytes(), &got))
+			assert.Equal(t, "warning", got.Status)
+			assert.Equal(t, test.expectedRC, got.ReturnCode)
+		})
+	}
+}
+
+func Test_write_check_results_in_junit_format(t *testing.T) {
+	tests := []struct {
+		desc             string
+		strict           bool
+		expectedFailures int
+	}{
+		{"default mode", false, 1},
+		{"strict mode", true, 2},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			var output bytes.Buffer
+			writeJUnit(&output, someCheckResults(), test.strict)
+			assert.Contains(t, output.String(), xml.Header)
+
+			var got junitTestSuites
+			assert.NoError(t, xml.Unmarshal(output.Bytes(), &got))
+			assert.Equal(t, 4, got.Tests)
+			assert.Equal(t, test.expectedFailures, got.Failures)
+			assert.Len(t, got.TestSuites, 3)
+
+			warningSuite := got.TestSuites[1]
+			assert.Equal(t, "warning-group", warningSuite.Name)
+			assert.Equal(t, "tcr.check.warning-group", warningSuite.TestCases[1].ClassName)
+			assert.Equal(t, "warning checkpoint", warningSuite.TestCases[1].Name)
+			if test.strict {
+				assert.Equal(t, &junitFailure{Type: "warning", Message: "warning checkpoint"},
+					warningSuite.TestCases[1].Failure)
+			} else {
+				assert.Nil(t, warningSuite.TestCases[1].Failure)
+				assert.Equal(t, "warning: warning checkpoint", warningSuite.TestCases[1].SystemOut)
+			}
+
+			errorSuite := got.TestSuites[2]
+			assert.Equal(t, &junitFailure{Type: "error", Message: "error checkpoint"},
+				errorSuite.TestCases[0].Failure)
+		})
+	}
+}
@@ -99,3 +99,8 @@ func (cg *CheckGroup) Print() {
 func (cg *CheckGroup) GetTopic() string {
 	return cg.topic
 }
+
+// GetCheckPoints returns the checkpoints contained in this CheckGroup
+func (cg *CheckGroup) GetCheckPoints() []CheckPoint {
+	return cg.checkpoints
+}
@@ -25,6 +25,7 @@ package model
 import (
 	"errors"
 	"fmt"
+	"github.com/murex/tcr/params"
 	"github.com/murex/tcr/report"
 	"io/fs"
 )
@@ -33,6 +34,14 @@ import (
 type CheckPoint struct {
 	rc          CheckStatus
 	description string
+	fix         *Fix
+}
+
+// Fix describes a safe remediation for the issue reported by a checkpoint.
+// Apply may update the provided parameters so that they reflect the remediation
+type Fix struct {
+	Description string
+	Apply       func(p *params.Params) error
 }
 
 // CheckpointsForDirAccessError is a utility function providing usual check points
@@ -88,3 +97,24 @@ func (cp CheckPoint) Print() {
 		report.PostError("\t▼ ", cp.description)
 	}
 }
+
+// GetStatus returns the checkpoint's status
+func (cp CheckPoint) GetStatus() CheckStatus {
+	return cp.rc
+}
+
+// GetDescription returns the checkpoint's description
+func (cp CheckPoint) GetDescription() string {
+	return cp.description
+}
+
+// WithFix returns a copy of the checkpoint with the provided remediation attached to it
+func (cp CheckPoint) WithFix(description string, apply func(p *params.Params) error) CheckPoint {
+	cp.fix = &Fix{Description: description, Apply: apply}
+	return cp
+}
+
+// GetFix returns the remediation attached to the checkpoint, or nil if there is none
+func (cp CheckPoint) GetFix() *Fix {
+	return cp.fix
+}
@@ -24,6 +24,7 @@ package model
 
 import (
 	"errors"
+	"github.com/murex/tcr/params"
 	"github.com/murex/tcr/report"
 	"github.com/stretchr/testify/assert"
 	"io/fs"
@@ -150,3 +151,25 @@ func Test_checkpoints_for_lists(t *testing.T) {
 		})
 	}
 }
+
+func Test_checkpoint_with_no_fix(t *testing.T) {
+	assert.Nil(t, WarningCheckPoint("some warning").GetFix())
+}
+
+func Test_checkpoint_with_fix(t *testing.T) {
+	applied := false
+	cp := WarningCheckPoint("some warning").WithFix("some fix", func(p *params.Params) error {
+		p.AutoPush = false
+		applied = true
+		return nil
+	})
+	assert.Equal(t, CheckStatusWarning, cp.GetStatus())
+	assert.Equal(t, "some warning", cp.GetDescription())
+
+	fix := cp.GetFix()
+	assert.Equal(t, "some fix", fix.Description)
+	p := params.Params{AutoPush: true}
+	assert.NoError(t, fix.Apply(&p))
+	assert.True(t, applied)
+	assert.False(t, p.AutoPush)
+}
@@ -34,10 +34,42 @@ const (
 	CheckStatusError   CheckStatus = 2 // Build status is Error
 )
 
+// String returns the name of the check status
+func (s CheckStatus) String() string {
+	switch s {
+	case CheckStatusOk:
+		return "ok"
+	case CheckStatusWarning:
+		return "warning"
+	case CheckStatusError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// Strict returns the check status to be used in strict mode, where warnings are turned into errors
+func (s CheckStatus) Strict() CheckStatus {
+	if s == CheckStatusWarning {
+		return CheckStatusError
+	}
+	return s
+}
+
 // UpdateReturnState updates the application's return state according to CheckGroup's status
 func UpdateReturnState(results *CheckGroup) {
-	if int(results.GetStatus()) > status.GetReturnCode() {
-		RecordCheckState(results.GetStatus())
+	updateReturnState(results.GetStatus())
+}
+
+// UpdateStrictReturnState updates the application's return state according to CheckGroup's status,
+// considering warnings as errors
+func UpdateStrictReturnState(results *CheckGroup) {
+	updateReturnState(results.GetStatus().Strict())
+}
+
+func updateReturnState(s CheckStatus) {
+	if int(s) > status.GetReturnCode() {
+		RecordCheckState(s)
 	}
 }
 
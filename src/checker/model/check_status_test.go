@@ -67,3 +67,41 @@ func Test_update_return_state(t *testing.T) {
 		})
 	}
 }
+
+func Test_update_strict_return_state(t *testing.T) {
+	tests := []struct {
+		desc       string
+		checkpoint CheckPoint
+		expectedRC int
+	}{
+		{"status ok", OkCheckPoint("A"), 0},
+		{"status warning", WarningCheckPoint("A"), 2},
+		{"status error", ErrorCheckPoint("A"), 2},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			cg := NewCheckGroup("check group")
+			cg.Add(test.checkpoint)
+			status.RecordState(status.Ok)
+			UpdateStrictReturnState(cg)
+			assert.Equal(t, test.expectedRC, status.GetReturnCode())
+		})
+	}
+}
+
+func Test_check_status_string(t *testing.T) {
+	tests := []struct {
+		checkStatus CheckStatus
+		expected    string
+	}{
+		{CheckStatusOk, "ok"},
+		{CheckStatusWarning, "warning"},
+		{CheckStatusError, "error"},
+		{CheckStatus(-1), "unknown"},
+	}
+	for _, test := range tests {
+		t.Run(test.expected, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.checkStatus.String())
+		})
+	}
+}
@@ -23,6 +23,7 @@ SOFTWARE.
 package checker
 
 import (
+	"fmt"
 	"github.com/murex/tcr/checker/model"
 	"github.com/murex/tcr/config"
 	"github.com/murex/tcr/filesystem"
@@ -32,13 +33,29 @@ import (
 	"github.com/murex/tcr/toolchain"
 	"github.com/murex/tcr/vcs"
 	"github.com/murex/tcr/vcs/factory"
+	"slices"
+	"strings"
 )
 
 type checkGroupRunner func(params.Params) *model.CheckGroup
 
+// namedCheckGroupRunner associates a check group runner with the name used
+// for selecting or skipping this check group
+type namedCheckGroupRunner struct {
+	name string
+	run  checkGroupRunner
+}
+
+// checkGroupResult contains the check group produced by a named check group runner
+type checkGroupResult struct {
+	name  string
+	group *model.CheckGroup
+}
+
 type checkPointRunner func(p params.Params) []model.CheckPoint
 
 var checkEnv struct {
+	projectDir    string
 	configDir     string
 	configDirErr  error
 	workDir       string
@@ -47,44 +64,112 @@ var checkEnv struct {
 	sourceTreeErr error
 	lang          language.LangInterface
 	langErr       error
+	detection     language.Detection
 	tchn          toolchain.TchnInterface
 	tchnErr       error
 	vcs           vcs.Interface
 	vcsErr        error
 }
 
-var checkGroupRunners = []checkGroupRunner{
-	checkConfigFiles,
-	checkDirectories,
-	checkLanguage,
-	checkToolchain,
-	checkVCSConfiguration,
-	checkGitEnvironment,
-	checkP4Environment,
-	checkWorkflowConfiguration,
-	checkMobConfiguration,
+var checkGroupRunners = []namedCheckGroupRunner{
+	{"config", checkConfigFiles},
+	{"directories", checkDirectories},
+	{"modules", checkModules},
+	{"language", checkLanguage},
+	{"toolchain", checkToolchain},
+	{"quarantine", checkQuarantine},
+	{"vcs", checkVCSConfiguration},
+	{"git", checkGitEnvironment},
+	{"p4", checkP4Environment},
+	{"workflow", checkWorkflowConfiguration},
+	{"mob", checkMobConfiguration},
 }
 
 // Run goes through all configuration, parameters and local environment to check
-// if TCR is ready to be used
+// if TCR is ready to be used. For polyglot projects, language and toolchain checks
+// apply to the primary module.
+// Check groups can be selected or skipped by name (cf. GroupNames). Results are either
+// reported as text, or written to standard output in JSON or JUnit format.
+// In strict mode, warnings are considered as errors when computing the return state
 func Run(p params.Params) {
+	model.RecordCheckState(model.CheckStatusOk)
+	checkEnv.projectDir = p.BaseDir
+	p = primaryModuleParams(p)
 	initCheckEnv(p)
+	var results []checkGroupResult
 	for _, runner := range checkGroupRunners {
-		results := runner(p)
-		results.Print()
-		model.UpdateReturnState(results)
+		if !isSelected(runner.name, p) {
+			continue
+		}
+		cg := runner.run(p)
+		results = append(results, checkGroupResult{name: runner.name, group: cg})
+		if !IsMachineFormat(p.CheckFormat) {
+			cg.Print()
+		}
+		if p.CheckStrict {
+			model.UpdateStrictReturnState(cg)
+		} else {
+			model.UpdateReturnState(cg)
+		}
+	}
+	switch p.CheckFormat {
+	case FormatJSON:
+		writeJSON(formatOutput, results, p.CheckStrict)
+	case FormatJUnit:
+		writeJUnit(formatOutput, results, p.CheckStrict)
+	default:
+		report.PostInfo("")
 	}
-	report.PostInfo("")
 }
 
-func initCheckEnv(p params.Params) {
-	model.RecordCheckState(model.CheckStatusOk)
+// GroupNames returns the names of all check groups, in the order they are run
+func GroupNames() []string {
+	names := make([]string, 0, len(checkGroupRunners))
+	for _, runner := range checkGroupRunners {
+		names = append(names, runner.name)
+	}
+	return names
+}
+
+// isSelected indicates if the check group with the provided name should be run,
+// based on check group selection and skipping parameters
+func isSelected(name string, p params.Params) bool {
+	if len(p.CheckSelect) > 0 && !slices.Contains(p.CheckSelect, name) {
+		return false
+	}
+	return !slices.Contains(p.CheckSkip, name)
+}
+
+// ValidateParams verifies that check output format and check group names
+// provided in parameters are valid, and that fix mode is not combined with a
+// machine-readable output format
+func ValidateParams(p params.Params) error {
+	if !slices.Contains(Formats(), p.CheckFormat) && p.CheckFormat != "" {
+		return fmt.Errorf("unknown check output format: %s (expected one of: %s)",
+			p.CheckFormat, strings.Join(Formats(), ", "))
+	}
+	if p.CheckFix && IsMachineFormat(p.CheckFormat) {
+		return fmt.Errorf("fix mode cannot be used with %s output format", p.CheckFormat)
+	}
+	for _, name := range append(append([]string{}, p.CheckSelect...), p.CheckSkip...) {
+		if !slices.Contains(GroupNames(), name) {
+			return fmt.Errorf("unknown check group: %s (expected one of: %s)",
+				name, strings.Join(GroupNames(), ", "))
+		}
+	}
+	return nil
+}
 
+func initCheckEnv(p params.Params) {
 	checkEnv.configDir = config.GetConfigDirPath()
 	checkEnv.sourceTree, checkEnv.sourceTreeErr = filesystem.New(p.BaseDir)
 
 	if checkEnv.sourceTreeErr == nil {
+		_ = toolchain.SetBaseDir(checkEnv.sourceTree.GetBaseDir())
 		checkEnv.lang, checkEnv.langErr = language.GetLanguage(p.Language, checkEnv.sourceTree.GetBaseDir())
+		if p.Language == "" {
+			checkEnv.detection = language.Detect(checkEnv.sourceTree.GetBaseDir())
+		}
 	} else {
 		checkEnv.lang, checkEnv.langErr = language.Get(p.Language)
 	}
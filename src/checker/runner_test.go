@@ -23,6 +23,7 @@ SOFTWARE.
 package checker
 
 import (
+	"fmt"
 	"github.com/murex/tcr/checker/model"
 	"github.com/murex/tcr/params"
 	"github.com/murex/tcr/status"
@@ -90,7 +91,17 @@ func assertCheckGroupRunner(t *testing.T,
 	}
 }
 
+// named gives a name to each provided check group runner ("runner-0", "runner-1", etc.)
+func named(runners ...checkGroupRunner) (res []namedCheckGroupRunner) {
+	for i, runner := range runners {
+		res = append(res, namedCheckGroupRunner{name: fmt.Sprintf("runner-%d", i), run: runner})
+	}
+	return res
+}
+
 func Test_checker_run(t *testing.T) {
+	runnersBackup := checkGroupRunners
+	t.Cleanup(func() { checkGroupRunners = runnersBackup })
 	okRunner := func(_ params.Params) *model.CheckGroup {
 		cg := model.NewCheckGroup("ok runner")
 		cg.Add(model.OkCheckPoint("always returns ok"))
@@ -122,9 +133,84 @@ func Test_checker_run(t *testing.T) {
 	}
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
-			checkGroupRunners = test.runners
+			checkGroupRunners = named(test.runners...)
 			Run(params.Params{})
 			assert.Equal(t, test.expectedRC, status.GetReturnCode())
 		})
 	}
 }
+
+func Test_checker_run_in_strict_mode(t *testing.T) {
+	warningRunner := func(_ params.Params) *model.CheckGroup {
+		cg := model.NewCheckGroup("warning runner")
+		cg.Add(model.WarningCheckPoint("always returns warning"))
+		return cg
+	}
+	runnersBackup := checkGroupRunners
+	t.Cleanup(func() { checkGroupRunners = runnersBackup })
+	checkGroupRunners = named(warningRunner)
+
+	Run(params.Params{CheckStrict: true})
+	assert.Equal(t, 2, status.GetReturnCode())
+}
+
+func Test_checker_run_with_group_selection(t *testing.T) {
+	tests := []struct {
+		desc     string
+		selected []string
+		skipped  []string
+		expected []string
+	}{
+		{"all groups", nil, nil, []string{"runner-0", "runner-1", "runner-2"}},
+		{"selected groups", []string{"runner-0", "runner-2"}, nil, []string{"runner-0", "runner-2"}},
+		{"skipped groups", nil, []string{"runner-1"}, []string{"runner-0", "runner-2"}},
+		{"selected and skipped groups", []string{"runner-0", "runner-1"}, []string{"runner-1"}, []string{"runner-0"}},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			var ran []string
+			runner := func(name string) checkGroupRunner {
+				return func(_ params.Params) *model.CheckGroup {
+					ran = append(ran, name)
+					return model.NewCheckGroup(name)
+				}
+			}
+			runnersBackup := checkGroupRunners
+			t.Cleanup(func() { checkGroupRunners = runnersBackup })
+			checkGroupRunners = named(runner("runner-0"), runner("runner-1"), runner("runner-2"))
+
+			Run(params.Params{CheckSelect: test.selected, CheckSkip: test.skipped})
+			assert.Equal(t, test.expected, ran)
+		})
+	}
+}
+
+func Test_validate_check_params(t *testing.T) {
+	tests := []struct {
+		desc        string
+		p           params.Params
+		expectError bool
+	}{
+		{"default values", params.Params{}, false},
+		{"text format", params.Params{CheckFormat: "text"}, false},
+		{"json format", params.Params{CheckFormat: "json"}, false},
+		{"junit format", params.Params{CheckFormat: "junit"}, false},
+		{"unknown format", params.Params{CheckFormat: "xml"}, true},
+		{"known groups", params.Params{CheckSelect: []string{"git"}, CheckSkip: []string{"toolchain"}}, false},
+		{"unknown selected group", params.Params{CheckSelect: []string{"unknown"}}, true},
+		{"unknown skipped group", params.Params{CheckSkip: []string{"unknown"}}, true},
+		{"fix with text format", params.Params{CheckFix: true, CheckFormat: "text"}, false},
+		{"fix with json format", params.Params{CheckFix: true, CheckFormat: "json"}, true},
+		{"fix with junit format", params.Params{CheckFix: true, CheckFormat: "junit"}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			err := ValidateParams(test.p)
+			if test.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
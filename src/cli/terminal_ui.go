@@ -35,6 +35,7 @@ import (
 	"github.com/murex/tcr/vcs/git"
 	"github.com/murex/tcr/vcs/p4"
 	"os"
+	"strings"
 )
 
 // TerminalUI is the user interface implementation when using the Command Line Interface
@@ -63,6 +64,7 @@ const (
 	timerStatusMenuHelper        = "Timer status"
 	quitDriverRoleMenuHelper     = "Quit Driver role"
 	quitNavigatorRoleMenuHelper  = "Quit Navigator role"
+	fullTestRunMenuHelper        = "Run all tests on next cycle"
 )
 
 // New creates a new instance of terminal
@@ -149,6 +151,16 @@ func (term *TerminalUI) ReportError(emphasis bool, a ...any) {
 	term.notifyOnEmphasis(emphasis, "🟥", a...)
 }
 
+// NotifyAlert reports an alert message that requires the user's attention. Alerts
+// are also sent as high-level desktop notifications
+func (term *TerminalUI) NotifyAlert(a ...any) {
+	printInRed(a...)
+	err := term.desktop.ShowNotification(desktop.HighLevel, "🚨 "+settings.ApplicationName, fmt.Sprint(a...))
+	if err != nil {
+		term.ReportWarning(false, "Failed to show desktop notification: ", err.Error())
+	}
+}
+
 func (term *TerminalUI) notifyOnEmphasis(emphasis bool, emoji string, a ...any) {
 	if emphasis {
 		err := term.desktop.ShowNotification(desktop.NormalLevel, emoji+" "+settings.ApplicationName, fmt.Sprint(a...))
@@ -245,6 +257,9 @@ func (term *TerminalUI) ShowSessionInfo() {
 	term.ReportTitle(false, "Base Directory: ", info.BaseDir)
 	term.ReportInfo(false, "Work Directory: ", info.WorkDir)
 	term.ReportInfo(false, "Language=", info.LanguageName, ", Toolchain=", info.ToolchainName)
+	for _, module := range info.Modules {
+		term.ReportInfo(false, "Module: ", module)
+	}
 	term.reportVCSInfo(info)
 	term.reportMessageSuffix(info.MessageSuffix)
 }
@@ -277,29 +292,43 @@ func (term *TerminalUI) Confirm(message string, defaultAnswer bool) bool {
 	defer Restore()
 
 	term.ReportWarning(false, message)
-	term.ReportWarning(false, "Do you want to proceed? ", yesOrNoAdvice(defaultAnswer))
+	term.ReportWarning(false, "Do you want to proceed? ", YesOrNoAdvice(defaultAnswer))
 
 	keyboardInput := make([]byte, 1)
 	for {
 		_, _ = os.Stdin.Read(keyboardInput)
-		switch keyboardInput[0] {
-		case 'y', 'Y':
-			return true
-		case 'n', 'N':
-			return false
-		case enterKey:
+		if keyboardInput[0] == enterKey {
 			return defaultAnswer
 		}
+		if answer, valid := ParseYesOrNo(string(keyboardInput), defaultAnswer); valid {
+			return answer
+		}
 	}
 }
 
-func yesOrNoAdvice(defaultAnswer bool) string {
+// YesOrNoAdvice returns the hint displayed together with a yes/no question,
+// showing which answer is selected by default
+func YesOrNoAdvice(defaultAnswer bool) string {
 	if defaultAnswer {
 		return "[Y/n]"
 	}
 	return "[y/N]"
 }
 
+// ParseYesOrNo interprets the answer to a yes/no question. An empty answer selects
+// defaultAnswer. valid is false when the answer is neither yes nor no
+func ParseYesOrNo(input string, defaultAnswer bool) (answer bool, valid bool) {
+	switch strings.ToLower(input) {
+	case "":
+		return defaultAnswer, true
+	case "y", "yes":
+		return true, true
+	case "n", "no":
+		return false, true
+	}
+	return false, false
+}
+
 // Start runs the terminal session
 func (term *TerminalUI) Start() {
 	term.initTCREngine()
@@ -390,6 +419,9 @@ func (term *TerminalUI) initRoleMenu() *menu {
 		newMenuOption('T', timerStatusMenuHelper,
 			term.timerStatusMenuEnabler(),
 			term.timerStatusMenuAction(), false),
+		newMenuOption('A', fullTestRunMenuHelper,
+			term.quitRoleMenuEnabler(role.Driver{}),
+			term.fullTestRunMenuAction(), false),
 		newMenuOption('Q', quitDriverRoleMenuHelper,
 			term.quitRoleMenuEnabler(role.Driver{}),
 			term.quitRoleMenuAction(), true),
@@ -469,6 +501,12 @@ func (term *TerminalUI) timerStatusMenuAction() menuAction {
 	}
 }
 
+func (term *TerminalUI) fullTestRunMenuAction() menuAction {
+	return func() {
+		term.tcr.RequestFullTestRun()
+	}
+}
+
 func (term *TerminalUI) quitRoleMenuEnabler(r role.Role) menuEnabler {
 	return func() bool {
 		return term.tcr.GetCurrentRole() == r
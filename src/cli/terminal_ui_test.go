@@ -97,11 +97,35 @@ func fakeStdin(t *testing.T, input []byte) *os.File {
 }
 
 func Test_confirm_question_with_default_answer_to_no(t *testing.T) {
-	assert.Equal(t, "[y/N]", yesOrNoAdvice(false))
+	assert.Equal(t, "[y/N]", YesOrNoAdvice(false))
 }
 
 func Test_confirm_question_with_default_answer_to_yes(t *testing.T) {
-	assert.Equal(t, "[Y/n]", yesOrNoAdvice(true))
+	assert.Equal(t, "[Y/n]", YesOrNoAdvice(true))
+}
+
+func Test_parse_yes_or_no(t *testing.T) {
+	testFlags := []struct {
+		input          string
+		defaultAnswer  bool
+		expectedAnswer bool
+		expectedValid  bool
+	}{
+		{"", true, true, true},
+		{"", false, false, true},
+		{"y", false, true, true},
+		{"Yes", false, true, true},
+		{"N", true, false, true},
+		{"no", true, false, true},
+		{"maybe", true, false, false},
+	}
+	for _, tt := range testFlags {
+		t.Run(tt.input, func(t *testing.T) {
+			answer, valid := ParseYesOrNo(tt.input, tt.defaultAnswer)
+			assert.Equal(t, tt.expectedAnswer, answer)
+			assert.Equal(t, tt.expectedValid, valid)
+		})
+	}
 }
 
 func terminalSetup(p params.Params) (term *TerminalUI, fakeEngine *engine.FakeTCREngine, fakeNotifier *desktop.FakeNotifier) {
@@ -264,6 +288,7 @@ func Test_list_role_menu_options(t *testing.T) {
 			currentRole: role.Driver{},
 			expected: asCyanTraceWithSeparatorLine(title) +
 				asCyanTrace("\tT "+menuArrow+" "+timerStatusMenuHelper) +
+				asCyanTrace("\tA "+menuArrow+" "+fullTestRunMenuHelper) +
 				asCyanTrace("\tQ "+menuArrow+" "+quitDriverRoleMenuHelper) +
 				asCyanTrace("\t? "+menuArrow+" "+optionsMenuHelper),
 		},
@@ -474,6 +499,17 @@ func Test_terminal_notification_box_title(t *testing.T) {
 	}
 }
 
+func Test_terminal_notify_alert(t *testing.T) {
+	term, _, fakeNotifier := terminalSetup(*params.AParamSet())
+	assert.Equal(t, asRedTrace("some alert"), capturer.CaptureStdout(func() {
+		term.NotifyAlert("some alert")
+	}))
+	assert.Equal(t, desktop.HighLevel, fakeNotifier.LastLevel)
+	assert.Equal(t, "🚨 TCR", fakeNotifier.LastTitle)
+	assert.Equal(t, "some alert", fakeNotifier.LastMessage)
+	terminalTeardown(*term)
+}
+
 func Test_show_session_info(t *testing.T) {
 	expected := asCyanTraceWithSeparatorLine("Base Directory: fake") +
 		asCyanTrace("Work Directory: fake") +
@@ -696,6 +732,10 @@ func Test_driver_menu(t *testing.T) {
 			"T key triggers reporting timer status", []byte{'t', 'T'},
 			[]engine.TCRCall{engine.TCRCallReportMobTimerStatus},
 		},
+		{
+			"A key triggers a full test run request", []byte{'a', 'A'},
+			[]engine.TCRCall{engine.TCRCallRequestFullTestRun},
+		},
 		{
 			"P key has no action", []byte{'p', 'P'},
 			engine.NoTCRCall,
@@ -765,6 +805,10 @@ func Test_navigator_menu(t *testing.T) {
 			"D key has no action", []byte{'d', 'D'},
 			engine.NoTCRCall,
 		},
+		{
+			"A key has no action", []byte{'a', 'A'},
+			engine.NoTCRCall,
+		},
 		{
 			"N key has no action", []byte{'n', 'N'},
 			engine.NoTCRCall,
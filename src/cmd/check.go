@@ -23,10 +23,16 @@ SOFTWARE.
 package cmd
 
 import (
+	"github.com/murex/tcr/checker"
+	"github.com/murex/tcr/checker/model"
 	"github.com/murex/tcr/cli"
 	"github.com/murex/tcr/engine"
 	"github.com/murex/tcr/runmode"
+	"github.com/murex/tcr/status"
+	"github.com/murex/tcr/utils"
 	"github.com/spf13/cobra"
+	"os"
+	"strings"
 )
 
 // checkCmd represents the check command
@@ -46,6 +52,7 @@ The main checkpoints are organized in sections as follows:
 - Work Directory
 - Language settings
 - Toolchain settings
+- Quarantined tests
 - VCS environment
 - Auto-push settings
 - Mob timer settings (for driver role)
@@ -58,14 +65,53 @@ The return code of TCR "check" is one of the following:
 | 0   | All checks passed without any warning or error                             |
 | 1   | One or more warnings were raised. This should not prevent TCR from running |
 | 2   | One or more errors were raised. TCR will not be able to run properly       |
+
+With --strict option, warnings are considered as errors when computing the return code.
+
+Check groups can be selected or skipped by name (cf. --select and --skip options).
+Available check group names are: config, directories, modules, language, toolchain,
+quarantine, vcs, git, p4, workflow and mob.
+
+Check results can be written to standard output in a machine-readable format
+(cf. --format option), for instance when running TCR "check" in a CI pipeline:
+
+- json: one object per check group, with the status and description of each checkpoint
+- junit: one test suite per check group, and one test case per checkpoint. Errors
+  (and warnings with --strict option) are reported as test failures
+
+With --fix option, TCR proposes a remediation for each issue that can be fixed safely
+(creating missing directories, saving current configuration, creating a TCR working branch
+when running from a root branch, turning off auto-push when no remote is set).
+Each remediation is applied only after confirmation, then checks are run again.
+This option cannot be combined with a machine-readable output format.
 `,
 	Run: func(cmd *cobra.Command, args []string) {
+		if err := checker.ValidateParams(parameters); err != nil {
+			utils.Trace(err)
+			os.Exit(int(model.CheckStatusError)) //nolint:revive
+		}
 		parameters.Mode = runmode.Check{}
+		if checker.IsMachineFormat(parameters.CheckFormat) {
+			// Check results are the only thing written to standard output,
+			// hence we bypass the terminal user interface
+			checker.Run(parameters)
+			os.Exit(status.GetReturnCode()) //nolint:revive
+		}
 		u := cli.New(parameters, engine.NewTCREngine())
 		u.Start()
 	},
 }
 
 func init() {
+	checkCmd.Flags().StringVar(&parameters.CheckFormat, "format", checker.FormatText,
+		"check results output format (one of: "+strings.Join(checker.Formats(), ", ")+")")
+	checkCmd.Flags().BoolVar(&parameters.CheckStrict, "strict", false,
+		"consider warnings as errors")
+	checkCmd.Flags().StringSliceVar(&parameters.CheckSelect, "select", nil,
+		"run only the provided check groups (comma-separated)")
+	checkCmd.Flags().StringSliceVar(&parameters.CheckSkip, "skip", nil,
+		"skip the provided check groups (comma-separated)")
+	checkCmd.Flags().BoolVar(&parameters.CheckFix, "fix", false,
+		"propose and apply remediations for the issues found, then check again")
 	rootCmd.AddCommand(checkCmd)
 }
@@ -24,7 +24,9 @@ package cmd
 
 import (
 	"github.com/murex/tcr/config"
+	"github.com/murex/tcr/utils"
 	"github.com/spf13/cobra"
+	"os"
 )
 
 // configCmd represents the config command
@@ -47,9 +49,21 @@ var showCmd = &cobra.Command{
 	Long: `
 config show subcommand displays TCR configuration.
 
+TCR configuration values are merged from the following sources, by
+decreasing order of precedence:
+- command line flags
+- environment variables (ex: TCR_CONFIG_TCR_LANGUAGE)
+- profile configuration file (<config-dir>/.tcr/profile/<profile-name>.yml),
+  when selected with --profile option
+- branch configuration file (<config-dir>/.tcr/branch/<branch-name>.yml)
+- project configuration file (<config-dir>/.tcr/config.yml)
+- user configuration file (ex: $XDG_CONFIG_HOME/tcr/config.yml)
+
+The --origin flag displays where each configuration value comes from.
+
 This subcommand does not start TCR engine.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		config.Show()
+		config.Show(showOrigin)
 	},
 }
 
@@ -79,10 +93,184 @@ This subcommand does not start TCR engine.`,
 	},
 }
 
+// getCmd represents the config get command
+var getCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Get a TCR configuration value",
+	Long: `
+config get subcommand displays the effective value of a TCR configuration key.
+
+Keys use the same dotted notation as config show subcommand, for instance:
+- config.tcr.language
+- config.mob-timer.duration
+- language.java.test-files.directories
+- toolchain.maven.build.0.arguments
+
+This subcommand does not start TCR engine.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		value, err := config.GetValue(args[0])
+		if err != nil {
+			utils.Trace(err)
+			return
+		}
+		utils.TraceKeyValue(args[0], value)
+	},
+}
+
+// setCmd represents the config set command
+var setCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Set a TCR configuration value",
+	Long: `
+config set subcommand checks and saves the value of a TCR configuration key.
+
+- config.* values are saved in the project configuration file. Boolean and
+  duration values are checked before being saved. List values are comma-separated.
+- language.* and toolchain.* values are saved in the corresponding language or
+  toolchain configuration file. Values use YAML syntax (ex: "[src, lib]" for a list),
+  and are checked against the language or toolchain configuration structure.
+  When changing a built-in language or toolchain, the configuration file extends
+  the built-in definition.
+
+This subcommand does not start TCR engine.`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := config.SetValue(args[0], args[1]); err != nil {
+			utils.Trace(err)
+		}
+	},
+}
+
+// unsetCmd represents the config unset command
+var unsetCmd = &cobra.Command{
+	Use:   "unset <key>",
+	Short: "Unset a TCR configuration value",
+	Long: `
+config unset subcommand removes a TCR configuration key from the corresponding
+configuration file, so that the value from other sources applies again.
+
+This subcommand does not start TCR engine.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := config.UnsetValue(args[0]); err != nil {
+			utils.Trace(err)
+		}
+	},
+}
+
+// validateCmd represents the config validate command
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate TCR configuration files",
+	Long: `
+config validate subcommand checks all TCR configuration files (configuration,
+language, toolchain and quarantine files) against their schema, and reports
+the file, line and a fix hint for every issue found.
+
+The following issues are detected:
+- unknown keys
+- values with a wrong type
+- invalid regular expressions
+- unknown OS and architecture names
+- language default toolchain not listed in compatible toolchains
+
+The return code is 0 when no issue is found, and 1 otherwise.
+
+This subcommand does not start TCR engine.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !config.ShowValidation() {
+			os.Exit(1) //nolint:revive
+		}
+	},
+}
+
+// profileCmd represents the config profile command
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage TCR configuration profiles",
+	Long: `
+config profile subcommand provides management of TCR configuration profiles.
+
+A configuration profile is a named set of configuration values stored in
+<config-dir>/.tcr/profile/<profile-name>.yml. When selected with --profile option,
+it overrides values coming from user, project and branch configuration files.
+
+When a profile is selected, config set and config unset subcommands update the
+profile instead of the project configuration file.
+
+This subcommand does not start TCR engine.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		_ = cmd.Usage()
+	},
+}
+
+// profileListCmd represents the config profile list command
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List TCR configuration profiles",
+	Long: `
+config profile list subcommand displays the available configuration profiles,
+along with the values they override.
+
+This subcommand does not start TCR engine.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		config.ShowProfiles()
+	},
+}
+
+// profileCreateCmd represents the config profile create command
+var profileCreateCmd = &cobra.Command{
+	Use:   "create <profile-name>",
+	Short: "Create a TCR configuration profile",
+	Long: `
+config profile create subcommand creates a configuration profile. The profile is
+initialized with the configuration values provided on the command line, for instance:
+
+  tcr config profile create kata --duration 5m --commit-failures --auto-push
+
+This subcommand does not start TCR engine.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := config.CreateProfile(args[0]); err != nil {
+			utils.Trace(err)
+		}
+	},
+}
+
+// profileDeleteCmd represents the config profile delete command
+var profileDeleteCmd = &cobra.Command{
+	Use:   "delete <profile-name>",
+	Short: "Delete a TCR configuration profile",
+	Long: `
+config profile delete subcommand deletes a configuration profile.
+
+This subcommand does not start TCR engine.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := config.DeleteProfile(args[0]); err != nil {
+			utils.Trace(err)
+		}
+	},
+}
+
+var showOrigin bool
+
 func init() {
+	showCmd.Flags().BoolVar(&showOrigin, "origin", false,
+		"display where each configuration value comes from")
 	configCmd.AddCommand(showCmd)
 	configCmd.AddCommand(resetCmd)
 	configCmd.AddCommand(saveCmd)
+	configCmd.AddCommand(getCmd)
+	configCmd.AddCommand(setCmd)
+	configCmd.AddCommand(unsetCmd)
+	configCmd.AddCommand(validateCmd)
+
+	profileCmd.AddCommand(profileListCmd)
+	profileCmd.AddCommand(profileCreateCmd)
+	profileCmd.AddCommand(profileDeleteCmd)
+	configCmd.AddCommand(profileCmd)
 
 	rootCmd.AddCommand(configCmd)
 }
@@ -0,0 +1,73 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"github.com/murex/tcr/filesystem"
+	"github.com/murex/tcr/language"
+	"github.com/murex/tcr/utils"
+	"github.com/spf13/cobra"
+)
+
+// explainCmd represents the explain command
+var explainCmd = &cobra.Command{
+	Use:   "explain <path>...",
+	Short: "Explain how files are classified by TCR",
+	Long: `
+TCR explain subcommand explains how each provided file is classified for the current
+language (cf. -l option), and how TCR behaves when this file is changed.
+
+For each file, it shows:
+
+- Whether the file is located in a directory watched by TCR
+- Which directory and pattern (or glob) of the language's source and test files
+  filters match the file, and which exclusion rule applies to it, if any
+- Whether the file is recognized as a source file, a test file, or a language file
+- Whether saving the file triggers a TCR cycle
+- Whether changes to the file are reverted when build or tests fail
+
+Relative paths are resolved from the current directory.
+
+This subcommand does not start TCR engine.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		sourceTree, err := filesystem.New(parameters.BaseDir)
+		if err != nil {
+			utils.Trace(err)
+			return
+		}
+		lang, err := language.GetLanguage(parameters.Language, sourceTree.GetBaseDir())
+		if err != nil {
+			utils.Trace(err)
+			return
+		}
+		utils.Trace("Using ", lang.GetName(), " language with base directory ", sourceTree.GetBaseDir())
+		for _, arg := range args {
+			language.Explain(lang, sourceTree.GetBaseDir(), arg).Show()
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(explainCmd)
+}
@@ -0,0 +1,74 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"github.com/murex/tcr/cli"
+	"github.com/murex/tcr/config"
+	"github.com/murex/tcr/engine"
+	"github.com/murex/tcr/runmode"
+	"github.com/murex/tcr/utils"
+	"github.com/murex/tcr/wizard"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// initCmd represents the init command
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Initialize TCR configuration for the current project",
+	Long: `
+TCR init subcommand walks you through the configuration of TCR for the current project.
+
+It inspects project files to propose a language and a toolchain, then asks about
+the VCS, git auto-push, commit-failures and mob timer settings. Values already
+provided through command line options or configuration files are proposed as defaults.
+
+The resulting settings are saved into the project configuration file (cf. -c option),
+after which TCR runs the same checks as "tcr check", and exits with the same return code.
+The return code is 1 when the configuration cannot be completed or saved.
+
+With --yes option, all proposed values are accepted without asking any question.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		prompter := wizard.NewTextPrompter(os.Stdin, os.Stdout, acceptDefaults)
+		p, err := wizard.Run(parameters, prompter)
+		if err == nil {
+			err = config.SaveEngineParams(p)
+		}
+		if err != nil {
+			utils.Trace(err)
+			os.Exit(1) //nolint:revive
+		}
+		p.Mode = runmode.Check{}
+		u := cli.New(p, engine.NewTCREngine())
+		u.Start()
+	},
+}
+
+var acceptDefaults bool
+
+func init() {
+	initCmd.Flags().BoolVarP(&acceptDefaults, "yes", "y", false,
+		"accept all proposed values without asking (non-interactive mode)")
+	rootCmd.AddCommand(initCmd)
+}
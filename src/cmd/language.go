@@ -0,0 +1,149 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"github.com/murex/tcr/filesystem"
+	"github.com/murex/tcr/language"
+	"github.com/murex/tcr/utils"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// languageCmd represents the language command
+var languageCmd = &cobra.Command{
+	Use:   "language",
+	Short: "Manage TCR languages",
+	Long: `
+TCR language subcommand provides management of built-in and custom languages.
+
+Custom languages are stored in the language directory of TCR configuration
+directory (cf. -c option).
+
+This subcommand does not start TCR engine.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		_ = cmd.Usage()
+	},
+}
+
+// languageListCmd represents the language list command
+var languageListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List available languages",
+	Long: `
+language list subcommand lists available languages, and where each of them is defined.
+
+This subcommand does not start TCR engine.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		language.ShowList()
+	},
+}
+
+// languageShowCmd represents the language show command
+var languageShowCmd = &cobra.Command{
+	Use:   "show <language-name>",
+	Short: "Show a language's configuration",
+	Long: `
+language show subcommand displays the configuration of the provided language.
+
+This subcommand does not start TCR engine.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := language.ShowConfig(args[0]); err != nil {
+			utils.Trace(err)
+		}
+	},
+}
+
+// languageAddCmd represents the language add command
+var languageAddCmd = &cobra.Command{
+	Use:   "add <language-name>",
+	Short: "Add a custom language",
+	Long: `
+language add subcommand creates a custom language configuration file.
+
+The language is defined either from scratch, or from an existing language used
+as a template (cf. --from option). Command line options override the template's
+settings, for instance:
+
+  tcr language add groovy --from java --src-patterns '(?i)^.*\.groovy$' --test-patterns '(?i)^.*Test\.groovy$'
+
+This subcommand does not start TCR engine.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := language.Add(args[0], languageAddOptions); err != nil {
+			utils.Trace(err)
+		}
+	},
+}
+
+// languageTestCmd represents the language test command
+var languageTestCmd = &cobra.Command{
+	Use:   "test <language-name>",
+	Short: "Try a language on the current project",
+	Long: `
+language test subcommand applies the provided language's source and test files
+filters to the base directory (cf. -b option), and lists the matching files.
+
+The return code is 0 when both source and test files are found, and 1 otherwise.
+
+This subcommand does not start TCR engine.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		sourceTree, err := filesystem.New(parameters.BaseDir)
+		if err == nil {
+			err = language.Try(args[0], sourceTree.GetBaseDir())
+		}
+		if err != nil {
+			utils.Trace(err)
+			os.Exit(1) //nolint:revive
+		}
+	},
+}
+
+var languageAddOptions language.AddOptions
+
+func init() {
+	addFlags := languageAddCmd.Flags()
+	addFlags.StringVar(&languageAddOptions.Template, "from", "",
+		"existing language used as a template")
+	addFlags.StringSliceVar(&languageAddOptions.Toolchains, "toolchains", nil,
+		"compatible toolchains (comma-separated)")
+	addFlags.StringVar(&languageAddOptions.DefaultToolchain, "default-toolchain", "",
+		"default toolchain (defaults to the first compatible toolchain)")
+	addFlags.StringSliceVar(&languageAddOptions.SrcDirs, "src-dirs", nil,
+		"source directories (comma-separated)")
+	addFlags.StringSliceVar(&languageAddOptions.SrcPatterns, "src-patterns", nil,
+		"source filename patterns (comma-separated regular expressions)")
+	addFlags.StringSliceVar(&languageAddOptions.TestDirs, "test-dirs", nil,
+		"test directories (comma-separated)")
+	addFlags.StringSliceVar(&languageAddOptions.TestPatterns, "test-patterns", nil,
+		"test filename patterns (comma-separated regular expressions)")
+
+	languageCmd.AddCommand(languageListCmd)
+	languageCmd.AddCommand(languageShowCmd)
+	languageCmd.AddCommand(languageAddCmd)
+	languageCmd.AddCommand(languageTestCmd)
+	rootCmd.AddCommand(languageCmd)
+}
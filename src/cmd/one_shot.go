@@ -47,6 +47,7 @@ The return code of TCR "one-shot" is one of the following:
 | 3   | Error in configuration or parameter values                                     |
 | 4   | Error while interacting with the Version Control System                        |
 | 5   | Any other error                                                                |
+| 6   | Build or test infrastructure error, changes were left untouched                |
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 		parameters.Mode = runmode.OneShot{}
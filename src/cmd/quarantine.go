@@ -0,0 +1,115 @@
+/*
+Copyright (c) 2021 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"github.com/murex/tcr/quarantine"
+	"github.com/murex/tcr/utils"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// quarantineCmd represents the quarantine command
+var quarantineCmd = &cobra.Command{
+	Use:   "quarantine",
+	Short: "Manage quarantined tests",
+	Long: `
+TCR quarantine subcommand provides management of quarantined tests.
+
+Quarantined tests are known-broken tests whose failures are ignored by TCR
+when deciding whether changes should be committed or reverted. Their failures
+are still reported.
+
+Tests are identified by their xUnit class name and name, separated by a dot
+(for example: "com.example.SomeTest.someMethod"). The list of quarantined
+tests is stored in the TCR configuration directory (cf. -c option).
+
+This subcommand does not start TCR engine.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		_ = cmd.Usage()
+	},
+}
+
+// quarantineAddCmd represents the quarantine add command
+var quarantineAddCmd = &cobra.Command{
+	Use:   "add <test-id>...",
+	Short: "Add tests to quarantine",
+	Long: `
+quarantine add subcommand adds the provided tests to the list of quarantined tests.
+
+The return code is 1 when the quarantine file cannot be loaded, in which case it is left
+unchanged, and 0 otherwise.
+
+This subcommand does not start TCR engine.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		quarantine.Add(args...)
+		if err := quarantine.SaveConfig(); err != nil {
+			utils.Trace(err)
+			os.Exit(1) //nolint:revive
+		}
+	},
+}
+
+// quarantineRemoveCmd represents the quarantine remove command
+var quarantineRemoveCmd = &cobra.Command{
+	Use:   "remove <test-id>...",
+	Short: "Remove tests from quarantine",
+	Long: `
+quarantine remove subcommand removes the provided tests from the list of quarantined tests.
+
+The return code is 1 when one of the tests is not in quarantine or when the quarantine file
+cannot be loaded, in which case it is left unchanged, and 0 otherwise.
+
+This subcommand does not start TCR engine.`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		// Tests found in quarantine are removed even when some others are not
+		if err := errors.Join(quarantine.Remove(args...), quarantine.SaveConfig()); err != nil {
+			utils.Trace(err)
+			os.Exit(1) //nolint:revive
+		}
+	},
+}
+
+// quarantineListCmd represents the quarantine list command
+var quarantineListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List quarantined tests",
+	Long: `
+quarantine list subcommand displays the list of quarantined tests.
+
+This subcommand does not start TCR engine.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		quarantine.ShowConfig()
+	},
+}
+
+func init() {
+	quarantineCmd.AddCommand(quarantineAddCmd)
+	quarantineCmd.AddCommand(quarantineRemoveCmd)
+	quarantineCmd.AddCommand(quarantineListCmd)
+
+	rootCmd.AddCommand(quarantineCmd)
+}
@@ -57,12 +57,20 @@ The following stats are reported:
 - Failing tests count evolution (values for first and last commit) (*)
 - Skipped tests count evolution (values for first and last commit)
 - Test execution duration cumulated for all tests (values for first and last commit)
+- Line coverage evolution, in percent (values for first and last commit containing coverage data) (**)
+- Slow test failures: number of failed slow test runs (absolute value and percentage) (***)
 
 > (*) These metrics are relevant only if TCR commit history was created while running TCR with "commit-failures" option.
 > Without this option there is no record of test failures in TCR commit history, thus:
 > - "Number of passing commits" and "time in green" will always be at 100%
 > - "Number of failing commits" and "time in red" will always be at 0%
 > - "Failing tests" will always be at 0
+>
+> (**) This metric is relevant only if the toolchain is configured to collect code coverage (cf. coverage-report
+> section in toolchain configuration).
+>
+> (***) This metric is relevant only if the toolchain is configured with a slow test command (cf. slow-test
+> section in toolchain configuration).
 
 This subcommand does not start TCR engine.`,
 	Run: func(cmd *cobra.Command, args []string) {
@@ -0,0 +1,153 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package cmd
+
+import (
+	"github.com/murex/tcr/toolchain"
+	"github.com/murex/tcr/utils"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// toolchainCmd represents the toolchain command
+var toolchainCmd = &cobra.Command{
+	Use:   "toolchain",
+	Short: "Manage TCR toolchains",
+	Long: `
+TCR toolchain subcommand provides management of built-in and custom toolchains.
+
+Custom toolchains are stored in the toolchain directory of TCR configuration
+directory (cf. -c option).
+
+This subcommand does not start TCR engine.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		_ = cmd.Usage()
+	},
+}
+
+// toolchainListCmd represents the toolchain list command
+var toolchainListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List available toolchains",
+	Long: `
+toolchain list subcommand lists available toolchains, and where each of them is defined.
+
+This subcommand does not start TCR engine.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		toolchain.ShowList()
+	},
+}
+
+// toolchainShowCmd represents the toolchain show command
+var toolchainShowCmd = &cobra.Command{
+	Use:   "show <toolchain-name>",
+	Short: "Show a toolchain's configuration",
+	Long: `
+toolchain show subcommand displays the configuration of the provided toolchain.
+
+This subcommand does not start TCR engine.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := toolchain.ShowConfig(args[0]); err != nil {
+			utils.Trace(err)
+			os.Exit(1) //nolint:revive
+		}
+	},
+}
+
+// toolchainAddCmd represents the toolchain add command
+var toolchainAddCmd = &cobra.Command{
+	Use:   "add <toolchain-name>",
+	Short: "Add a custom toolchain",
+	Long: `
+toolchain add subcommand creates a custom toolchain configuration file.
+
+The toolchain is defined either from scratch, or from an existing toolchain used
+as a template (cf. --from option). Command line options override the template's
+settings, for instance:
+
+  tcr toolchain add make --build-command make --build-args build --test-command make --test-args test
+  tcr toolchain add quick-maven --from maven --test-args test,-o
+
+This subcommand does not start TCR engine.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := toolchain.Add(args[0], toolchainAddOptions); err != nil {
+			utils.Trace(err)
+			os.Exit(1) //nolint:revive
+		}
+	},
+}
+
+// toolchainTestCmd represents the toolchain test command
+var toolchainTestCmd = &cobra.Command{
+	Use:   "test <toolchain-name>",
+	Short: "Try a toolchain on the current project",
+	Long: `
+toolchain test subcommand runs the provided toolchain's build and test commands once
+from the work directory (cf. -w option), then checks whether test results were found
+in the toolchain's test result directory.
+
+The return code is 0 when build and tests pass and test results are found, and 1 otherwise.
+
+This subcommand does not start TCR engine.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := toolchain.SetBaseDir(parameters.BaseDir)
+		if err == nil {
+			err = toolchain.SetWorkDir(parameters.WorkDir)
+		}
+		if err == nil {
+			err = toolchain.Try(args[0])
+		}
+		if err != nil {
+			utils.Trace(err)
+			os.Exit(1) //nolint:revive
+		}
+	},
+}
+
+var toolchainAddOptions toolchain.AddOptions
+
+func init() {
+	addFlags := toolchainAddCmd.Flags()
+	addFlags.StringVar(&toolchainAddOptions.Template, "from", "",
+		"existing toolchain used as a template")
+	addFlags.StringVar(&toolchainAddOptions.BuildCommand, "build-command", "",
+		"build command path")
+	addFlags.StringSliceVar(&toolchainAddOptions.BuildArgs, "build-args", nil,
+		"build command arguments (comma-separated)")
+	addFlags.StringVar(&toolchainAddOptions.TestCommand, "test-command", "",
+		"test command path")
+	addFlags.StringSliceVar(&toolchainAddOptions.TestArgs, "test-args", nil,
+		"test command arguments (comma-separated)")
+	addFlags.StringVar(&toolchainAddOptions.TestResultDir, "test-result-dir", "",
+		"directory where test results are generated (in xUnit format)")
+
+	toolchainCmd.AddCommand(toolchainListCmd)
+	toolchainCmd.AddCommand(toolchainShowCmd)
+	toolchainCmd.AddCommand(toolchainAddCmd)
+	toolchainCmd.AddCommand(toolchainTestCmd)
+	rootCmd.AddCommand(toolchainCmd)
+}
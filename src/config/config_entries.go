@@ -0,0 +1,212 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"github.com/murex/tcr/language"
+	"github.com/murex/tcr/toolchain"
+	"github.com/murex/tcr/utils"
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Key prefixes for language and toolchain configuration entries
+const (
+	languageKeyPrefix  = "language"
+	toolchainKeyPrefix = "toolchain"
+)
+
+// storableParam is a parameter whose value can be stored in TCR configuration file
+type storableParam interface {
+	settings() paramSettings
+	storedValue() any
+	parseValue(value string) (any, error)
+}
+
+func (c TcrConfig) storableParams() []storableParam {
+	return []storableParam{
+		c.Language,
+		c.Toolchain,
+		c.Modules,
+		c.PollingPeriod,
+		c.MobTimerDuration,
+		c.AutoPush,
+		c.CommitFailures,
+		c.VCS,
+		c.Trace,
+	}
+}
+
+// findParam returns the parameter stored in configuration file under the provided key
+func (c TcrConfig) findParam(key string) (storableParam, error) {
+	for _, param := range c.storableParams() {
+		if s := param.settings(); s.viperSettings.enabled && s.getViperKey() == key {
+			return param, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown configuration key: %s", key)
+}
+
+// splitEntryKey splits a language or toolchain entry key (ex: "language.java.test-files.directories")
+// into its prefix, the language or toolchain name, and the path of the entry in its configuration file
+func splitEntryKey(key string) (prefix string, name string, path string, err error) {
+	parts := strings.SplitN(key, ".", 3)
+	if len(parts) < 3 || parts[1] == "" || parts[2] == "" {
+		return "", "", "", fmt.Errorf("invalid configuration key: %s (expected %s.<name>.<entry>)", key, parts[0])
+	}
+	return parts[0], parts[1], parts[2], nil
+}
+
+func isEntryKey(key string) bool {
+	return strings.HasPrefix(key, languageKeyPrefix+".") || strings.HasPrefix(key, toolchainKeyPrefix+".")
+}
+
+// GetValue returns the effective value for the provided configuration key
+func GetValue(key string) (any, error) {
+	key = strings.ToLower(key)
+	if isEntryKey(key) {
+		prefix, name, path, err := splitEntryKey(key)
+		if err != nil {
+			return nil, err
+		}
+		if prefix == languageKeyPrefix {
+			return language.GetConfigValue(name, path)
+		}
+		return toolchain.GetConfigValue(name, path)
+	}
+	if _, err := Config.findParam(key); err != nil {
+		return nil, err
+	}
+	return viper.Get(key), nil
+}
+
+// SetValue checks that value is valid for the provided configuration key, and saves it
+// in the corresponding configuration file
+func SetValue(key string, value string) error {
+	key = strings.ToLower(key)
+	if isEntryKey(key) {
+		prefix, name, path, err := splitEntryKey(key)
+		if err != nil {
+			return err
+		}
+		utils.Trace("Setting ", key, " to ", value)
+		if prefix == languageKeyPrefix {
+			return language.SetConfigValue(name, path, value)
+		}
+		return toolchain.SetConfigValue(name, path, value)
+	}
+	param, err := Config.findParam(key)
+	if err != nil {
+		return err
+	}
+	parsed, err := param.parseValue(value)
+	if err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+	utils.Trace("Setting ", key, " to ", value)
+	return updateConfigFile(func(entries map[string]any) error {
+		return utils.SetYAMLEntry(entries, key, parsed)
+	})
+}
+
+// UnsetValue removes the provided configuration key from the corresponding configuration file,
+// so that its default value applies again
+func UnsetValue(key string) error {
+	key = strings.ToLower(key)
+	if isEntryKey(key) {
+		prefix, name, path, err := splitEntryKey(key)
+		if err != nil {
+			return err
+		}
+		utils.Trace("Unsetting ", key)
+		if prefix == languageKeyPrefix {
+			return language.UnsetConfigValue(name, path)
+		}
+		return toolchain.UnsetConfigValue(name, path)
+	}
+	if _, err := Config.findParam(key); err != nil {
+		return err
+	}
+	utils.Trace("Unsetting ", key)
+	return updateConfigFile(func(entries map[string]any) error {
+		err := utils.UnsetYAMLEntry(entries, key)
+		if errors.Is(err, utils.ErrYAMLEntryNotFound) {
+			path, _ := targetConfigFilePath()
+			return fmt.Errorf("%s is not set in %s", key, path)
+		}
+		return err
+	})
+}
+
+// updateConfigFile applies the provided update to the active profile's configuration file if any,
+// or to the project configuration file otherwise, leaving values coming from other configuration
+// layers untouched
+func updateConfigFile(update func(entries map[string]any) error) error {
+	configFilePath, err := targetConfigFilePath()
+	if err != nil {
+		return err
+	}
+	return updateConfigFileAt(configFilePath, update)
+}
+
+// updateConfigFileAt applies the provided update to the configuration file located at configFilePath,
+// leaving other entries of this file untouched
+func updateConfigFileAt(configFilePath string, update func(entries map[string]any) error) error {
+	entries, err := readConfigLayer(configFilePath)
+	if os.IsNotExist(err) {
+		entries, err = make(map[string]any), nil
+	}
+	if err != nil {
+		return fmt.Errorf("cannot load %s: %w", configFilePath, err)
+	}
+	if err = update(entries); err != nil {
+		return err
+	}
+	createConfigDir()
+	utils.SaveToYAMLFile(afero.NewOsFs(), entries, configFilePath)
+	return nil
+}
+
+func projectConfigFilePath() string {
+	return filepath.Join(configDirPath, configFileName)
+}
+
+// targetConfigFilePath returns the path of the configuration file updated by config set and unset
+// subcommands: the active profile's configuration file if any, or the project configuration file
+func targetConfigFilePath() (string, error) {
+	if name := activeProfile(); name != "" {
+		if err := checkProfileName(name); err != nil {
+			return "", err
+		}
+		if !profileExists(name) {
+			return "", noSuchProfileError(name)
+		}
+		return profileConfigFilePath(name), nil
+	}
+	return projectConfigFilePath(), nil
+}
@@ -0,0 +1,136 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package config
+
+import (
+	"github.com/murex/tcr/utils"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func setupConfigEntries(t *testing.T) {
+	t.Helper()
+	AddParameters(NewCobraTestCmd(), t.TempDir())
+	previousConfigDirPath := configDirPath
+	configDirPath = filepath.Join(t.TempDir(), configDirRoot)
+	t.Cleanup(func() {
+		configDirPath = previousConfigDirPath
+	})
+}
+
+func Test_set_config_value(t *testing.T) {
+	testFlags := []struct {
+		key      string
+		value    string
+		expected any
+	}{
+		{"config.tcr.language", "java", "java"},
+		{"config.git.auto-push", "true", true},
+		{"config.mob-timer.duration", "90s", "1m30s"},
+		{"config.tcr.modules", "go@backend, rust@frontend", []any{"go@backend", "rust@frontend"}},
+		{"CONFIG.TCR.TOOLCHAIN", "maven", "maven"},
+	}
+	for _, tt := range testFlags {
+		t.Run(tt.key, func(t *testing.T) {
+			setupConfigEntries(t)
+			assert.NoError(t, SetValue(tt.key, tt.value))
+			entries, err := readConfigLayer(projectConfigFilePath())
+			assert.NoError(t, err)
+			value, _ := utils.GetYAMLEntry(entries, strings.ToLower(tt.key))
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func Test_set_config_value_with_invalid_value(t *testing.T) {
+	testFlags := []struct {
+		key   string
+		value string
+	}{
+		{"config.git.auto-push", "maybe"},
+		{"config.mob-timer.duration", "10 minutes"},
+		{"config.git.polling-period", "2"},
+	}
+	for _, tt := range testFlags {
+		t.Run(tt.key, func(t *testing.T) {
+			setupConfigEntries(t)
+			assert.Error(t, SetValue(tt.key, tt.value))
+			assert.NoFileExists(t, projectConfigFilePath())
+		})
+	}
+}
+
+func Test_set_config_value_with_unknown_key(t *testing.T) {
+	for _, key := range []string{"config.tcr.unknown", "config.tcr.base-dir", "language.java", "unknown"} {
+		t.Run(key, func(t *testing.T) {
+			setupConfigEntries(t)
+			assert.Error(t, SetValue(key, "value"))
+		})
+	}
+}
+
+func Test_set_config_value_keeps_other_values(t *testing.T) {
+	setupConfigEntries(t)
+	_ = SetValue("config.tcr.language", "java")
+	_ = SetValue("config.git.auto-push", "true")
+	entries, _ := readConfigLayer(projectConfigFilePath())
+	assert.Equal(t, map[string]any{
+		"config": map[string]any{
+			"tcr": map[string]any{"language": "java"},
+			"git": map[string]any{"auto-push": true},
+		},
+	}, entries)
+}
+
+func Test_get_config_value_with_unknown_key(t *testing.T) {
+	setupConfigEntries(t)
+	_, err := GetValue("config.tcr.unknown")
+	assert.Error(t, err)
+}
+
+func Test_get_language_entry_value(t *testing.T) {
+	setupConfigEntries(t)
+	value, err := GetValue("language.go.toolchains.default")
+	assert.NoError(t, err)
+	assert.Equal(t, "go-tools", value)
+}
+
+func Test_unset_config_value(t *testing.T) {
+	setupConfigEntries(t)
+	_ = SetValue("config.tcr.language", "java")
+	_ = SetValue("config.git.auto-push", "true")
+	assert.NoError(t, UnsetValue("config.tcr.language"))
+	entries, _ := readConfigLayer(projectConfigFilePath())
+	assert.Equal(t, map[string]any{
+		"config": map[string]any{
+			"git": map[string]any{"auto-push": true},
+		},
+	}, entries)
+}
+
+func Test_unset_config_value_not_in_config_file(t *testing.T) {
+	setupConfigEntries(t)
+	assert.Error(t, UnsetValue("config.tcr.language"))
+}
@@ -0,0 +1,230 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package config
+
+import (
+	"fmt"
+	"github.com/murex/tcr/settings"
+	"github.com/murex/tcr/utils"
+	"github.com/murex/tcr/vcs/git"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Configuration scopes, in increasing order of precedence. Profile scope is defined in profile.go
+const (
+	userScope    = "user"
+	projectScope = "project"
+	branchScope  = "branch"
+)
+
+const (
+	userConfigDirName   = "tcr"
+	branchConfigDirName = "branch"
+	defaultOrigin       = "default"
+	vcsNameKey          = "config.vcs.name"
+	detachedHead        = "HEAD"
+)
+
+// configLayer is a configuration file applying to one of the configuration scopes
+type configLayer struct {
+	scope string
+	path  string
+	keys  map[string]bool
+}
+
+var (
+	// userConfigDir returns the directory where user-level configuration is stored.
+	// It can be overridden for testing purpose
+	userConfigDir = os.UserConfigDir
+
+	// workingBranch returns the VCS branch used for selecting branch-level configuration.
+	// It can be overridden for testing purpose
+	workingBranch = git.WorkingBranch
+
+	// envKeyReplacer converts a configuration key into its environment variable counterpart
+	envKeyReplacer = strings.NewReplacer(".", "_", "-", "_")
+
+	// configLayers contains the configuration files that were loaded, in increasing order of precedence
+	configLayers []configLayer
+)
+
+// userConfigFilePath returns the path to the user-level configuration file,
+// or an empty string if there is no user configuration directory
+func userConfigFilePath() string {
+	dir, err := userConfigDir()
+	if err != nil || dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, userConfigDirName, configFileName)
+}
+
+// branchConfigFilePath returns the path to the configuration file applying to
+// the current working branch, or an empty string if the VCS is not git or if there
+// is no working branch
+func branchConfigFilePath(vcsName string) string {
+	if vcsName != git.Name {
+		return ""
+	}
+	baseDir := "."
+	if Config.BaseDir != nil && Config.BaseDir.GetValue() != "" {
+		baseDir = Config.BaseDir.GetValue()
+	}
+	branch, err := workingBranch(baseDir)
+	if err != nil || branch == "" || branch == detachedHead {
+		return ""
+	}
+	return filepath.Join(configDirPath, branchConfigDirName, filepath.FromSlash(branch)+"."+configFileType)
+}
+
+// loadConfigLayers reads user, project, branch and profile configuration files and returns
+// their merged content, each layer overriding the values of the previous ones.
+// An error is returned when the selected profile name is invalid or when the profile does not exist
+func loadConfigLayers(projectConfigFilePath string) (map[string]any, error) {
+	configLayers = nil
+	merged := make(map[string]any)
+	if name := activeProfile(); name != "" {
+		if err := checkProfileName(name); err != nil {
+			return merged, err
+		}
+	}
+	for _, layer := range []configLayer{
+		{scope: userScope, path: userConfigFilePath()},
+		{scope: projectScope, path: projectConfigFilePath},
+		{scope: branchScope},
+		{scope: profileScope, path: activeProfileConfigFilePath()},
+	} {
+		if layer.scope == branchScope {
+			// Branch configuration depends on the VCS selected by the previous layers
+			layer.path = branchConfigFilePath(selectedVCSName(merged))
+		}
+		values, err := readConfigLayer(layer.path)
+		switch {
+		case err == nil:
+			traceLayerLoading(layer)
+			traceIssues(validateConfigFile(layer.path))
+		case os.IsNotExist(err) || layer.path == "":
+			if layer.scope == projectScope {
+				utils.Trace("No configuration file found")
+			}
+			if layer.scope == profileScope && layer.path != "" {
+				return merged, noSuchProfileError(activeProfile())
+			}
+			continue
+		default:
+			if issues := validateConfigFile(layer.path); len(issues) > 0 {
+				traceIssues(issues)
+			} else {
+				utils.Trace("Error while loading configuration file ", layer.path, ": ", err)
+			}
+			continue
+		}
+		layer.keys = make(map[string]bool)
+		for _, key := range utils.FlattenYAMLKeys("", values) {
+			layer.keys[strings.ToLower(key)] = true
+		}
+		configLayers = append(configLayers, layer)
+		merged = utils.MergeYAMLMaps(merged, values)
+	}
+	return merged, nil
+}
+
+// selectedVCSName returns the name of the VCS selected through command line, environment
+// or the provided configuration values, in this order of precedence. Defaults to git
+func selectedVCSName(values map[string]any) string {
+	if flag, found := boundFlags[vcsNameKey]; found && flag.Changed {
+		return flag.Value.String()
+	}
+	if name := os.Getenv(envVarName(vcsNameKey)); name != "" {
+		return name
+	}
+	if name, err := utils.GetYAMLEntry(values, vcsNameKey); err == nil && name != nil {
+		return fmt.Sprint(name)
+	}
+	return git.Name
+}
+
+func traceLayerLoading(layer configLayer) {
+	if layer.scope == projectScope {
+		utils.Trace("Loading configuration: ", layer.path)
+	} else {
+		utils.Trace("Loading ", layer.scope, " configuration: ", layer.path)
+	}
+}
+
+func readConfigLayer(path string) (values map[string]any, err error) {
+	if path == "" {
+		return nil, os.ErrNotExist
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err = yaml.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	if values == nil {
+		values = make(map[string]any)
+	}
+	return values, nil
+}
+
+// envVarName returns the name of the environment variable that can be used to set the provided key
+func envVarName(key string) string {
+	return envKeyReplacer.Replace(strings.ToUpper(settings.ApplicationName + "_" + key))
+}
+
+// valueOrigin returns where the effective value of the provided configuration key comes from.
+// Precedence order is: command line flag, environment variable, profile, branch, project and user
+// configuration files, and finally default value
+func valueOrigin(key string) string {
+	if flag, found := boundFlags[key]; found && flag.Changed {
+		return "flag --" + flag.Name
+	}
+	if env := envVarName(key); os.Getenv(env) != "" {
+		return "env " + env
+	}
+	if layer, found := configLayerOf(key); found {
+		return layer.scope + " " + layer.path
+	}
+	return defaultOrigin
+}
+
+// configLayerOf returns the configuration file with the highest precedence defining the provided key, if any
+func configLayerOf(key string) (configLayer, bool) {
+	for i := len(configLayers) - 1; i >= 0; i-- {
+		if configLayers[i].keys[key] {
+			return configLayers[i], true
+		}
+	}
+	return configLayer{}, false
+}
+
+// isSetInOtherConfigLayer indicates if the effective value of the provided key comes from
+// a configuration file other than the project configuration file
+func isSetInOtherConfigLayer(key string) bool {
+	layer, found := configLayerOf(key)
+	return found && layer.scope != projectScope && valueOrigin(key) == layer.scope+" "+layer.path
+}
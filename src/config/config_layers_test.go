@@ -0,0 +1,234 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"github.com/murex/tcr/utils"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const languageKey = "config.tcr.language"
+
+type layeredConfigFiles struct {
+	user    string
+	project string
+	branch  string
+}
+
+// setupLayeredConfig creates the configuration files provided in files, and makes
+// TCR configuration use them. Empty contents mean that the file does not exist
+func setupLayeredConfig(t *testing.T, branch string, files layeredConfigFiles) (userDir string, projectDir string) {
+	t.Helper()
+	userDir, projectDir = t.TempDir(), filepath.Join(t.TempDir(), configDirRoot)
+
+	previousConfigDirPath, previousUserConfigDir, previousWorkingBranch :=
+		configDirPath, userConfigDir, workingBranch
+	configDirPath = projectDir
+	userConfigDir = func() (string, error) { return userDir, nil }
+	workingBranch = func(_ string) (string, error) {
+		if branch == "" {
+			return "", errors.New("no branch")
+		}
+		return branch, nil
+	}
+	t.Cleanup(func() {
+		configDirPath, userConfigDir, workingBranch =
+			previousConfigDirPath, previousUserConfigDir, previousWorkingBranch
+		configLayers = nil
+		// Drop values set by previous operations, which would otherwise override configuration files
+		viper.Reset()
+	})
+
+	writeConfigFile(t, filepath.Join(userDir, userConfigDirName, configFileName), files.user)
+	writeConfigFile(t, filepath.Join(projectDir, configFileName), files.project)
+	writeConfigFile(t, filepath.Join(projectDir, branchConfigDirName, branch+"."+configFileType), files.branch)
+	return userDir, projectDir
+}
+
+func writeConfigFile(t *testing.T, path string, content string) {
+	t.Helper()
+	if content == "" {
+		return
+	}
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0600))
+}
+
+func initTCRConfigSilently() {
+	utils.SetSimpleTrace(io.Discard)
+	_ = initTCRConfig()
+}
+
+func languageConfig(lang string) string {
+	return "config:\n  tcr:\n    language: " + lang + "\n"
+}
+
+func Test_config_layers_precedence(t *testing.T) {
+	testFlags := []struct {
+		desc           string
+		files          layeredConfigFiles
+		expectedValue  string
+		expectedOrigin string
+	}{
+		{
+			"no configuration file",
+			layeredConfigFiles{},
+			"", defaultOrigin,
+		},
+		{
+			"user configuration only",
+			layeredConfigFiles{user: languageConfig("go")},
+			"go", userScope,
+		},
+		{
+			"project overrides user",
+			layeredConfigFiles{user: languageConfig("go"), project: languageConfig("java")},
+			"java", projectScope,
+		},
+		{
+			"branch overrides project",
+			layeredConfigFiles{user: languageConfig("go"), project: languageConfig("java"), branch: languageConfig("rust")},
+			"rust", branchScope,
+		},
+		{
+			"branch overrides user",
+			layeredConfigFiles{user: languageConfig("go"), branch: languageConfig("rust")},
+			"rust", branchScope,
+		},
+	}
+
+	for _, tt := range testFlags {
+		t.Run(tt.desc, func(t *testing.T) {
+			setupLayeredConfig(t, "feature/some-branch", tt.files)
+			initTCRConfigSilently()
+			assert.Equal(t, tt.expectedValue, viper.GetString(languageKey))
+			assert.True(t, strings.HasPrefix(valueOrigin(languageKey), tt.expectedOrigin))
+		})
+	}
+}
+
+func Test_branch_config_layer_is_skipped_when_not_applicable(t *testing.T) {
+	testFlags := []struct {
+		desc    string
+		branch  string
+		project string
+		args    []string
+		env     string
+	}{
+		{"no working branch", "", languageConfig("java"), nil, ""},
+		{"detached head", "HEAD", languageConfig("java"), nil, ""},
+		{"VCS set in project configuration", "main", "config:\n  tcr:\n    language: java\n  vcs:\n    name: p4\n", nil, ""},
+		{"VCS set on command line", "main", languageConfig("java"), []string{"--vcs", "p4"}, ""},
+		{"VCS set in environment", "main", languageConfig("java"), nil, "p4"},
+	}
+
+	for _, tt := range testFlags {
+		t.Run(tt.desc, func(t *testing.T) {
+			setupLayeredConfig(t, tt.branch, layeredConfigFiles{project: tt.project, branch: languageConfig("rust")})
+			if tt.env != "" {
+				t.Setenv("TCR_CONFIG_VCS_NAME", tt.env)
+			}
+			runWithArgs(t, tt.args, func() {
+				initTCRConfigSilently()
+				assert.Equal(t, "java", viper.GetString(languageKey))
+				assert.True(t, strings.HasPrefix(valueOrigin(languageKey), projectScope))
+			})
+		})
+	}
+}
+
+func Test_save_tcr_config_does_not_copy_other_layers_values(t *testing.T) {
+	_, projectDir := setupLayeredConfig(t, "", layeredConfigFiles{
+		user:    languageConfig("go"),
+		project: "config:\n  tcr:\n    toolchain: maven\n",
+	})
+	AddParameters(NewCobraTestCmd(), t.TempDir())
+	initTCRConfigSilently()
+	saveTCRConfig()
+
+	entries, err := readConfigLayer(filepath.Join(projectDir, configFileName))
+	assert.NoError(t, err)
+	_, err = utils.GetYAMLEntry(entries, languageKey)
+	assert.ErrorIs(t, err, utils.ErrYAMLEntryNotFound)
+	toolchainValue, _ := utils.GetYAMLEntry(entries, "config.tcr.toolchain")
+	assert.Equal(t, "maven", toolchainValue)
+}
+
+func Test_config_layers_are_merged_key_by_key(t *testing.T) {
+	setupLayeredConfig(t, "main", layeredConfigFiles{
+		user:    "config:\n  tcr:\n    language: go\n    toolchain: go-tools\n",
+		project: languageConfig("java"),
+	})
+	initTCRConfigSilently()
+	assert.Equal(t, "java", viper.GetString(languageKey))
+	assert.Equal(t, "go-tools", viper.GetString("config.tcr.toolchain"))
+}
+
+func Test_env_variable_overrides_config_layers(t *testing.T) {
+	setupLayeredConfig(t, "main", layeredConfigFiles{branch: languageConfig("rust")})
+	t.Setenv("TCR_CONFIG_TCR_LANGUAGE", "python")
+	initTCRConfigSilently()
+	assert.Equal(t, "python", viper.GetString(languageKey))
+	assert.Equal(t, "env TCR_CONFIG_TCR_LANGUAGE", valueOrigin(languageKey))
+}
+
+func Test_init_tcr_config_traces_loaded_layers(t *testing.T) {
+	userDir, projectDir := setupLayeredConfig(t, "main", layeredConfigFiles{
+		user:    languageConfig("go"),
+		project: languageConfig("java"),
+		branch:  languageConfig("rust"),
+	})
+	expected := []string{
+		"Loading user configuration: " + filepath.Join(userDir, userConfigDirName, configFileName),
+		"Loading configuration: " + filepath.Join(projectDir, configFileName),
+		"Loading branch configuration: " + filepath.Join(projectDir, branchConfigDirName, "main.yml"),
+	}
+	utils.AssertSimpleTrace(t, expected,
+		func() {
+			_ = initTCRConfig()
+		},
+	)
+}
+
+func Test_show_tcr_config_with_origin(t *testing.T) {
+	_, projectDir := setupLayeredConfig(t, "main", layeredConfigFiles{branch: languageConfig("rust")})
+	initTCRConfigSilently()
+	expected := "- " + languageKey + ": rust (from branch " +
+		filepath.Join(projectDir, branchConfigDirName, "main.yml") + ")"
+	var output bytes.Buffer
+	utils.SetSimpleTrace(&output)
+	showTCRConfig(true)
+	assert.Contains(t, output.String(), expected)
+}
+
+func Test_env_var_name(t *testing.T) {
+	assert.Equal(t, "TCR_CONFIG_MOB_TIMER_DURATION", envVarName("config.mob-timer.duration"))
+}
@@ -0,0 +1,170 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"github.com/murex/tcr/utils"
+	"github.com/spf13/afero"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	profileScope      = "profile"
+	profileDirName    = "profile"
+	activeProfileMark = " (active)"
+)
+
+var profileNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+
+// activeProfile returns the name of the profile selected with --profile option, if any
+func activeProfile() string {
+	if Config.Profile == nil {
+		return ""
+	}
+	return Config.Profile.GetValue()
+}
+
+func profileDirPath() string {
+	return filepath.Join(configDirPath, profileDirName)
+}
+
+func profileConfigFilePath(name string) string {
+	return utils.BuildYAMLFilePath(profileDirPath(), name)
+}
+
+// activeProfileConfigFilePath returns the path to the configuration file of the active
+// profile, or an empty string if no valid profile is selected
+func activeProfileConfigFilePath() string {
+	if name := activeProfile(); name != "" && checkProfileName(name) == nil {
+		return profileConfigFilePath(name)
+	}
+	return ""
+}
+
+// checkProfileName makes sure that the provided name can be used as a profile name.
+// This prevents profile names from referring to a file outside the profile directory
+func checkProfileName(name string) error {
+	if !profileNameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid profile name: %q (only letters, digits, '-' and '_' are allowed)", name)
+	}
+	return nil
+}
+
+func profileExists(name string) bool {
+	if checkProfileName(name) != nil {
+		return false
+	}
+	_, err := os.Stat(profileConfigFilePath(name))
+	return err == nil
+}
+
+func noSuchProfileError(name string) error {
+	available := ProfileNames()
+	if len(available) == 0 {
+		return fmt.Errorf("no such configuration profile: %s (no profile defined)", name)
+	}
+	return fmt.Errorf("no such configuration profile: %s (available profiles: %s)",
+		name, strings.Join(available, ", "))
+}
+
+// ProfileNames returns the names of the configuration profiles found in configuration directory
+func ProfileNames() (names []string) {
+	for _, entry := range utils.ListYAMLFilesIn(afero.NewOsFs(), profileDirPath()) {
+		names = append(names, utils.ExtractNameFromYAMLFilename(entry))
+	}
+	return names
+}
+
+// ShowProfiles displays the list of configuration profiles, along with the values they override
+func ShowProfiles() {
+	utils.Trace("Configuration profiles:")
+	names := ProfileNames()
+	if len(names) == 0 {
+		utils.Trace("- none")
+	}
+	for _, name := range names {
+		mark := ""
+		if strings.EqualFold(name, activeProfile()) {
+			mark = activeProfileMark
+		}
+		utils.Trace("- ", name, mark)
+		values, err := readConfigLayer(profileConfigFilePath(name))
+		if err != nil {
+			utils.Trace("  Error while loading configuration file: ", err)
+			continue
+		}
+		for _, key := range utils.FlattenYAMLKeys("", values) {
+			value, _ := utils.GetYAMLEntry(values, key)
+			utils.Trace("  - ", key, ": ", value)
+		}
+	}
+}
+
+// CreateProfile creates a configuration profile with the provided name. The profile is initialized
+// with the configuration parameters provided on the command line, if any
+func CreateProfile(name string) error {
+	if err := checkProfileName(name); err != nil {
+		return err
+	}
+	if profileExists(name) {
+		return fmt.Errorf("configuration profile %s already exists", name)
+	}
+	entries := make(map[string]any)
+	for _, param := range Config.storableParams() {
+		key := param.settings().getViperKey()
+		if flag, found := boundFlags[key]; found && flag.Changed {
+			if err := utils.SetYAMLEntry(entries, key, param.storedValue()); err != nil {
+				return err
+			}
+		}
+	}
+	utils.Trace("Creating configuration profile ", name, ": ", profileConfigFilePath(name))
+	utils.CreateSubDir(afero.NewOsFs(), profileDirPath(), "TCR configuration profile directory")
+	utils.SaveToYAMLFile(afero.NewOsFs(), entries, profileConfigFilePath(name))
+	for _, key := range utils.FlattenYAMLKeys("", entries) {
+		value, _ := utils.GetYAMLEntry(entries, key)
+		utils.TraceKeyValue(key, value)
+	}
+	return nil
+}
+
+// DeleteProfile deletes the configuration profile with the provided name
+func DeleteProfile(name string) error {
+	if err := checkProfileName(name); err != nil {
+		return err
+	}
+	if !profileExists(name) {
+		return noSuchProfileError(name)
+	}
+	utils.Trace("Deleting configuration profile ", name, ": ", profileConfigFilePath(name))
+	err := os.Remove(profileConfigFilePath(name))
+	if errors.Is(err, os.ErrNotExist) {
+		return noSuchProfileError(name)
+	}
+	return err
+}
@@ -0,0 +1,152 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package config
+
+import (
+	"github.com/murex/tcr/utils"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"path/filepath"
+	"testing"
+)
+
+// runWithArgs runs operation from a command using the provided command line arguments
+func runWithArgs(t *testing.T, args []string, operation func()) {
+	t.Helper()
+	utils.SetSimpleTrace(io.Discard)
+	cmd := &cobra.Command{
+		Use: "test",
+		Run: func(_ *cobra.Command, _ []string) {
+			operation()
+		},
+	}
+	AddParameters(cmd, t.TempDir())
+	cmd.SetArgs(args)
+	assert.NoError(t, cmd.Execute())
+}
+
+func Test_create_profile_with_command_line_values(t *testing.T) {
+	setupLayeredConfig(t, "main", layeredConfigFiles{})
+	runWithArgs(t, []string{"--duration", "5m", "--commit-failures", "--auto-push", "--language", "go"}, func() {
+		assert.NoError(t, CreateProfile("kata"))
+	})
+
+	entries, err := readConfigLayer(profileConfigFilePath("kata"))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"config": map[string]any{
+			"git":       map[string]any{"auto-push": true, "commit-failures": true},
+			"mob-timer": map[string]any{"duration": "5m0s"},
+			"tcr":       map[string]any{"language": "go"},
+		},
+	}, entries)
+}
+
+func Test_create_empty_profile(t *testing.T) {
+	setupLayeredConfig(t, "main", layeredConfigFiles{})
+	runWithArgs(t, nil, func() {
+		assert.NoError(t, CreateProfile("empty"))
+	})
+	assert.FileExists(t, profileConfigFilePath("empty"))
+	assert.Equal(t, []string{"empty"}, ProfileNames())
+}
+
+func Test_create_profile_errors(t *testing.T) {
+	setupLayeredConfig(t, "main", layeredConfigFiles{})
+	runWithArgs(t, nil, func() {
+		assert.NoError(t, CreateProfile("kata"))
+		assert.Error(t, CreateProfile("kata"))
+		assert.Error(t, CreateProfile("bad name"))
+		assert.Error(t, CreateProfile("../outside"))
+	})
+	assert.Equal(t, []string{"kata"}, ProfileNames())
+}
+
+func Test_delete_profile(t *testing.T) {
+	setupLayeredConfig(t, "main", layeredConfigFiles{})
+	runWithArgs(t, nil, func() {
+		_ = CreateProfile("kata")
+		_ = CreateProfile("production")
+		assert.NoError(t, DeleteProfile("kata"))
+		assert.Error(t, DeleteProfile("kata"))
+	})
+	assert.Equal(t, []string{"production"}, ProfileNames())
+}
+
+func Test_profile_names_cannot_refer_to_files_outside_profile_directory(t *testing.T) {
+	_, projectDir := setupLayeredConfig(t, "main", layeredConfigFiles{project: languageConfig("java")})
+	projectConfigFile := filepath.Join(projectDir, configFileName)
+	const invalidNameError = `invalid profile name: "../config" (only letters, digits, '-' and '_' are allowed)`
+
+	runWithArgs(t, nil, func() {
+		assert.EqualError(t, DeleteProfile("../config"), invalidNameError)
+	})
+	assert.FileExists(t, projectConfigFile)
+
+	runWithArgs(t, []string{"--profile", "../config"}, func() {
+		assert.EqualError(t, initTCRConfig(), invalidNameError)
+		assert.EqualError(t, SetValue("config.tcr.language", "go"), invalidNameError)
+	})
+	projectEntries, _ := readConfigLayer(projectConfigFile)
+	assert.Equal(t, map[string]any{"config": map[string]any{"tcr": map[string]any{"language": "java"}}}, projectEntries)
+}
+
+func Test_profile_overrides_other_config_layers(t *testing.T) {
+	_, projectDir := setupLayeredConfig(t, "main", layeredConfigFiles{
+		project: languageConfig("java"),
+		branch:  languageConfig("rust"),
+	})
+	writeConfigFile(t, filepath.Join(projectDir, profileDirName, "kata.yml"), languageConfig("go"))
+
+	runWithArgs(t, []string{"--profile", "kata"}, func() {
+		assert.NoError(t, initTCRConfig())
+		assert.Equal(t, "go", Config.Language.GetValue())
+		assert.Equal(t, profileScope+" "+filepath.Join(projectDir, profileDirName, "kata.yml"), valueOrigin(languageKey))
+	})
+}
+
+func Test_unknown_profile_is_an_error(t *testing.T) {
+	setupLayeredConfig(t, "main", layeredConfigFiles{project: languageConfig("java")})
+	runWithArgs(t, []string{"--profile", "unknown"}, func() {
+		utils.AssertSimpleTrace(t, []string{
+			"Loading configuration: " + projectConfigFilePath(),
+		}, func() {
+			assert.EqualError(t, initTCRConfig(), "no such configuration profile: unknown (no profile defined)")
+		})
+	})
+}
+
+func Test_set_config_value_in_active_profile(t *testing.T) {
+	setupLayeredConfig(t, "main", layeredConfigFiles{project: languageConfig("java")})
+	runWithArgs(t, []string{"--profile", "kata"}, func() {
+		assert.Error(t, SetValue("config.tcr.language", "go"))
+		assert.NoError(t, CreateProfile("kata"))
+		assert.NoError(t, SetValue("config.tcr.language", "go"))
+	})
+
+	profileEntries, _ := readConfigLayer(profileConfigFilePath("kata"))
+	assert.Equal(t, map[string]any{"config": map[string]any{"tcr": map[string]any{"language": "go"}}}, profileEntries)
+	projectEntries, _ := readConfigLayer(projectConfigFilePath())
+	assert.Equal(t, map[string]any{"config": map[string]any{"tcr": map[string]any{"language": "java"}}}, projectEntries)
+}
@@ -71,9 +71,13 @@ func (vs viperSettings) getViperKey() string {
 	return ""
 }
 
+// boundFlags keeps track of the command line flag bound to each viper key
+var boundFlags = make(map[string]*pflag.Flag)
+
 func (vs viperSettings) bindToViper(flag *pflag.Flag) {
 	if vs.enabled {
 		_ = viper.BindPFlag(vs.getViperKey(), flag)
+		boundFlags[vs.getViperKey()] = flag
 	}
 }
 
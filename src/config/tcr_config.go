@@ -23,13 +23,16 @@ SOFTWARE.
 package config
 
 import (
+	"bytes"
 	"github.com/murex/tcr/language"
 	"github.com/murex/tcr/params"
+	"github.com/murex/tcr/quarantine"
 	"github.com/murex/tcr/settings"
 	"github.com/murex/tcr/toolchain"
 	"github.com/murex/tcr/utils"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
 	"io"
 	"os"
 	"path/filepath"
@@ -41,8 +44,10 @@ type TcrConfig struct {
 	BaseDir          *StringParam
 	WorkDir          *StringParam
 	ConfigDir        *StringParam
+	Profile          *StringParam
 	Language         *StringParam
 	Toolchain        *StringParam
+	Modules          *StringSliceParam
 	PollingPeriod    *DurationParam
 	MobTimerDuration *DurationParam
 	AutoPush         *BoolParam
@@ -55,6 +60,7 @@ type TcrConfig struct {
 func (c TcrConfig) reset() {
 	c.Language.reset()
 	c.Toolchain.reset()
+	c.Modules.reset()
 	c.PollingPeriod.reset()
 	c.MobTimerDuration.reset()
 	c.AutoPush.reset()
@@ -78,25 +84,33 @@ var (
 	configDirPath string
 )
 
-// StandardInit initializes TCR configuration
+// StandardInit initializes TCR configuration. TCR stops when the selected
+// configuration profile does not exist
 func StandardInit() {
 	// With standard initialization, configuration Trace is sent to stderr
-	initConfig(os.Stderr)
+	if err := initConfig(os.Stderr); err != nil {
+		utils.Trace("Error while loading configuration profile: ", err)
+		os.Exit(1) //nolint:revive
+	}
 }
 
-func initConfig(writer io.Writer) {
+func initConfig(writer io.Writer) error {
 	utils.SetSimpleTrace(writer)
 	// Initialize configuration directory path
 	initConfigDirPath()
 	// Make sure configuration directory exists
 	createConfigDir()
 
-	initTCRConfig()
+	if err := initTCRConfig(); err != nil {
+		return err
+	}
 	toolchain.InitConfig(configDirPath)
 	language.InitConfig(configDirPath)
+	quarantine.InitConfig(configDirPath)
+	return nil
 }
 
-func initTCRConfig() {
+func initTCRConfig() error {
 	// Viper setup
 	configFilePath := filepath.Join(configDirPath, configFileName)
 	viper.AddConfigPath(configDirPath)
@@ -104,14 +118,22 @@ func initTCRConfig() {
 	viper.SetConfigName(configFileName)
 	viper.SetConfigFile(configFilePath)
 	viper.SetEnvPrefix(settings.ApplicationName)
+	viper.SetEnvKeyReplacer(envKeyReplacer)
 	viper.AutomaticEnv() // read in environment variables that match
 
-	// If a config file is found, read it in.
-	if err := viper.ReadInConfig(); err == nil {
-		utils.Trace("Loading configuration: ", viper.ConfigFileUsed())
-	} else {
-		utils.Trace("No configuration file found")
+	// Merge user, project, branch and profile configuration files, if any
+	layers, err := loadConfigLayers(configFilePath)
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(layers)
+	if err == nil {
+		err = viper.ReadConfig(bytes.NewReader(data))
 	}
+	if err != nil {
+		utils.Trace("Error while loading configuration: ", err)
+	}
+	return nil
 }
 
 func initConfigDirPath() {
@@ -128,6 +150,11 @@ func GetConfigDirPath() string {
 	return configDirPath
 }
 
+// GetConfigFilePath returns the project configuration file path
+func GetConfigFilePath() string {
+	return projectConfigFilePath()
+}
+
 func createConfigDir() {
 	_, err := os.Stat(configDirPath)
 	if os.IsNotExist(err) {
@@ -147,14 +174,28 @@ func Save() {
 	language.SaveConfigs()
 }
 
+// saveTCRConfig stores TCR parameter values into the project configuration file.
+// Values coming from user, branch or profile configuration files are not copied into it
 func saveTCRConfig() {
-	utils.Trace("Saving configuration: ", viper.ConfigFileUsed())
-	if err := viper.WriteConfig(); err != nil {
-		if os.IsNotExist(err) {
-			_ = viper.WriteConfigAs(viper.ConfigFileUsed())
-		} else {
-			utils.Trace("Error while saving configuration file: ", err)
+	configFilePath := projectConfigFilePath()
+	utils.Trace("Saving configuration: ", configFilePath)
+	err := updateConfigFileAt(configFilePath, func(entries map[string]any) error {
+		for _, param := range Config.storableParams() {
+			s := param.settings()
+			if !s.viperSettings.enabled {
+				continue
+			}
+			if isSetInOtherConfigLayer(s.getViperKey()) {
+				continue
+			}
+			if err := utils.SetYAMLEntry(entries, s.getViperKey(), param.storedValue()); err != nil {
+				return err
+			}
 		}
+		return nil
+	})
+	if err != nil {
+		utils.Trace("Error while saving configuration file: ", err)
 	}
 }
 
@@ -171,20 +212,25 @@ func resetTCRConfig() {
 	Config.reset()
 }
 
-// Show displays current TCR configuration
-func Show() {
+// Show displays current TCR configuration. When withOrigin is set, the origin
+// of each value (flag, environment variable, configuration file or default) is displayed
+func Show(withOrigin bool) {
 	utils.Trace()
-	showTCRConfig()
+	showTCRConfig(withOrigin)
 	toolchain.ShowConfigs()
 	language.ShowConfigs()
 }
 
-func showTCRConfig() {
+func showTCRConfig(withOrigin bool) {
 	keys := viper.AllKeys()
 	sort.Strings(keys)
 	utils.Trace("TCR configuration:")
 	for _, key := range keys {
-		utils.TraceKeyValue(key, viper.Get(key))
+		if withOrigin {
+			utils.TraceKeyValueWithOrigin(key, viper.Get(key), valueOrigin(key))
+		} else {
+			utils.TraceKeyValue(key, viper.Get(key))
+		}
 	}
 }
 
@@ -193,8 +239,10 @@ func AddParameters(cmd *cobra.Command, defaultDir string) {
 	Config.BaseDir = AddBaseDirParamWithDefault(cmd, defaultDir)
 	Config.WorkDir = AddWorkDirParamWithDefault(cmd, defaultDir)
 	Config.ConfigDir = AddConfigDirParam(cmd)
+	Config.Profile = AddProfileParam(cmd)
 	Config.Language = AddLanguageParam(cmd)
 	Config.Toolchain = AddToolchainParam(cmd)
+	Config.Modules = AddModulesParam(cmd)
 	Config.PollingPeriod = AddPollingPeriodParam(cmd)
 	Config.MobTimerDuration = AddMobTimerDurationParam(cmd)
 	Config.AutoPush = AddAutoPushParam(cmd)
@@ -212,6 +260,7 @@ func UpdateEngineParams(p *params.Params) {
 	p.MobTurnDuration = Config.MobTimerDuration.GetValue()
 	p.Language = Config.Language.GetValue()
 	p.Toolchain = Config.Toolchain.GetValue()
+	p.Modules = Config.Modules.GetValue()
 	p.PollingPeriod = Config.PollingPeriod.GetValue()
 	p.AutoPush = Config.AutoPush.GetValue()
 	p.CommitFailures = Config.CommitFailures.GetValue()
@@ -219,3 +268,30 @@ func UpdateEngineParams(p *params.Params) {
 	p.MessageSuffix = Config.MessageSuffix.GetValue()
 	p.Trace = Config.Trace.GetValue()
 }
+
+// SaveEngineParams stores the provided TCR engine parameters into the project configuration file.
+// Only parameters that make sense at project level are stored (language, toolchain, VCS, auto-push,
+// commit-failures and mob timer duration). Other entries of the configuration file are left untouched
+func SaveEngineParams(p params.Params) error {
+	values := []struct {
+		param storableParam
+		value any
+	}{
+		{Config.Language, p.Language},
+		{Config.Toolchain, p.Toolchain},
+		{Config.VCS, p.VCS},
+		{Config.AutoPush, p.AutoPush},
+		{Config.CommitFailures, p.CommitFailures},
+		{Config.MobTimerDuration, p.MobTurnDuration.String()},
+	}
+	configFilePath := projectConfigFilePath()
+	utils.Trace("Saving configuration: ", configFilePath)
+	return updateConfigFileAt(configFilePath, func(entries map[string]any) error {
+		for _, v := range values {
+			if err := utils.SetYAMLEntry(entries, v.param.settings().getViperKey(), v.value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
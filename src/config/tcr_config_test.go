@@ -167,7 +167,7 @@ func Test_init_tcr_config_with_no_config_file(t *testing.T) {
 	expected := []string{"No configuration file found"}
 	utils.AssertSimpleTrace(t, expected,
 		func() {
-			initTCRConfig()
+			_ = initTCRConfig()
 		},
 	)
 }
@@ -230,7 +230,7 @@ func NewCobraTestCmd() *cobra.Command {
 }
 
 func InitForTest() {
-	initConfig(nil)
+	_ = initConfig(nil)
 }
 
 func Test_show_tcr_config_with_default_values(t *testing.T) {
@@ -242,13 +242,37 @@ func Test_show_tcr_config_with_default_values(t *testing.T) {
 		fmt.Sprintf("%v.git.polling-period: %v", prefix, 2*time.Second),
 		fmt.Sprintf("%v.mob-timer.duration: %v", prefix, 5*time.Minute),
 		fmt.Sprintf("%v.tcr.language: %v", prefix, ""),
+		fmt.Sprintf("%v.tcr.modules: %v", prefix, []string{}),
 		fmt.Sprintf("%v.tcr.toolchain: %v", prefix, ""),
 		fmt.Sprintf("%v.tcr.trace: %v", prefix, "none"),
 		fmt.Sprintf("%v.vcs.name: %v", prefix, "git"),
 	}
 	utils.AssertSimpleTrace(t, expected,
 		func() {
-			showTCRConfig()
+			showTCRConfig(false)
 		},
 	)
 }
+
+func Test_save_engine_params(t *testing.T) {
+	setupConfigEntries(t)
+	_ = SetValue("config.git.polling-period", "5s")
+	p := params.Params{
+		Language:        "java",
+		Toolchain:       "maven",
+		VCS:             "git",
+		AutoPush:        true,
+		CommitFailures:  false,
+		MobTurnDuration: 10 * time.Minute,
+	}
+	assert.NoError(t, SaveEngineParams(p))
+	entries, _ := readConfigLayer(projectConfigFilePath())
+	assert.Equal(t, map[string]any{
+		"config": map[string]any{
+			"tcr":       map[string]any{"language": "java", "toolchain": "maven"},
+			"vcs":       map[string]any{"name": "git"},
+			"git":       map[string]any{"auto-push": true, "commit-failures": false, "polling-period": "5s"},
+			"mob-timer": map[string]any{"duration": "10m0s"},
+		},
+	}, entries)
+}
@@ -23,8 +23,10 @@ SOFTWARE.
 package config
 
 import (
+	"fmt"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"strconv"
 )
 
 type paramValueBool struct {
@@ -81,3 +83,19 @@ func (param *BoolParam) reset() {
 		viper.Set(param.s.getViperKey(), param.v.value)
 	}
 }
+
+func (param *BoolParam) settings() paramSettings {
+	return param.s
+}
+
+func (param *BoolParam) storedValue() any {
+	return param.GetValue()
+}
+
+func (param *BoolParam) parseValue(value string) (any, error) {
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a boolean value", value)
+	}
+	return parsed, nil
+}
@@ -23,6 +23,7 @@ SOFTWARE.
 package config
 
 import (
+	"fmt"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"time"
@@ -81,3 +82,19 @@ func (param *DurationParam) reset() {
 		viper.Set(param.s.getViperKey(), param.v.value)
 	}
 }
+
+func (param *DurationParam) settings() paramSettings {
+	return param.s
+}
+
+func (param *DurationParam) storedValue() any {
+	return param.GetValue().String()
+}
+
+func (param *DurationParam) parseValue(value string) (any, error) {
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a duration value (ex: 2s, 5m)", value)
+	}
+	return parsed.String(), nil
+}
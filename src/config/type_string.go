@@ -81,3 +81,15 @@ func (param *StringParam) reset() {
 		viper.Set(param.s.getViperKey(), param.v.value)
 	}
 }
+
+func (param *StringParam) settings() paramSettings {
+	return param.s
+}
+
+func (param *StringParam) storedValue() any {
+	return param.GetValue()
+}
+
+func (param *StringParam) parseValue(value string) (any, error) {
+	return value, nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package config
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"strings"
+)
+
+type paramValueStringSlice struct {
+	value        []string
+	defaultValue []string
+}
+
+func (p *paramValueStringSlice) reset() {
+	p.value = p.defaultValue
+}
+
+// StringSliceParam is a parameter of type string slice that can be handled by both viper and cobra frameworks.
+// On the command line, the flag can be repeated or receive a comma-separated list of values
+type StringSliceParam struct {
+	s paramSettings
+	v paramValueStringSlice
+}
+
+func (param *StringSliceParam) addToCommand(cmd *cobra.Command) {
+	flags := param.s.getCmdFlags(cmd)
+	flags.StringSliceVarP(&param.v.value,
+		param.s.cobraSettings.name,
+		param.s.cobraSettings.shorthand,
+		nil,
+		param.s.cobraSettings.usage)
+
+	flag := flags.Lookup(param.s.cobraSettings.name)
+	param.s.bindToViper(flag)
+}
+
+func (param *StringSliceParam) useDefaultValueIfNotSet() {
+	if len(param.v.value) == 0 {
+		if param.s.viperSettings.enabled {
+			if cfgValue := viper.GetStringSlice(param.s.getViperKey()); len(cfgValue) > 0 {
+				param.v.value = cfgValue
+			} else {
+				param.reset()
+			}
+		} else {
+			param.reset()
+		}
+	}
+}
+
+// GetValue returns the current value for this parameter
+func (param *StringSliceParam) GetValue() []string {
+	param.useDefaultValueIfNotSet()
+	return param.v.value
+}
+
+func (param *StringSliceParam) reset() {
+	param.v.reset()
+	if param.s.enabled {
+		viper.Set(param.s.getViperKey(), param.v.value)
+	}
+}
+
+func (param *StringSliceParam) settings() paramSettings {
+	return param.s
+}
+
+func (param *StringSliceParam) storedValue() any {
+	return param.GetValue()
+}
+
+func (param *StringSliceParam) parseValue(value string) (any, error) {
+	values := make([]string, 0)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package config
+
+import (
+	"github.com/murex/tcr/language"
+	"github.com/murex/tcr/quarantine"
+	"github.com/murex/tcr/schema"
+	"github.com/murex/tcr/toolchain"
+	"github.com/murex/tcr/utils"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Validate checks all TCR configuration files against their schema, and returns the issues found
+func Validate() (issues schema.Issues) {
+	for _, path := range configFilePaths() {
+		issues = append(issues, validateConfigFile(path)...)
+	}
+	issues = append(issues, language.ValidateConfigFiles()...)
+	issues = append(issues, toolchain.ValidateConfigFiles()...)
+	issues = append(issues, quarantine.ValidateConfigFile()...)
+	return issues
+}
+
+// ShowValidation validates all TCR configuration files and displays the issues found.
+// Returns false if at least one issue was found
+func ShowValidation() bool {
+	utils.Trace()
+	utils.Trace("Validating TCR configuration files:")
+	issues := Validate()
+	traceIssues(issues)
+	if len(issues) == 0 {
+		utils.Trace("No issue found")
+		return true
+	}
+	utils.Trace(len(issues), " issue(s) found")
+	return false
+}
+
+// configFilePaths returns the paths of all existing user, project, branch and profile configuration files
+func configFilePaths() (paths []string) {
+	for _, path := range []string{userConfigFilePath(), projectConfigFilePath()} {
+		if _, err := os.Stat(path); path != "" && err == nil {
+			paths = append(paths, path)
+		}
+	}
+	for _, dir := range []string{filepath.Join(configDirPath, branchConfigDirName), profileDirPath()} {
+		_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err == nil && !d.IsDir() && strings.HasSuffix(path, "."+configFileType) {
+				paths = append(paths, path)
+			}
+			return nil
+		})
+	}
+	return paths
+}
+
+func validateConfigFile(path string) schema.Issues {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	_, issues := schema.ValidateData(schema.Config, path, data)
+	return issues
+}
+
+func traceIssues(issues schema.Issues) {
+	for _, issue := range issues {
+		utils.Trace(issue.String())
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package config
+
+import (
+	"github.com/murex/tcr/schema"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_saved_config_matches_config_schema(t *testing.T) {
+	path := filepath.Join(testDataDirJava, configDirRoot, configFileName)
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	_, issues := schema.ValidateData(schema.Config, path, data)
+	assert.Empty(t, issues)
+}
+
+func Test_validate_config_files(t *testing.T) {
+	userDir, projectDir := setupLayeredConfig(t, "feature/x", layeredConfigFiles{
+		user:    "config:\n  git:\n    auto-push: sometimes\n",
+		project: "config:\n  mob-timer:\n    duration: 5 minutes\n",
+		branch:  "config:\n  tcr:\n    langage: java\n",
+	})
+	issues := Validate()
+	assert.Equal(t, schema.Issues{
+		{
+			File: filepath.Join(userDir, userConfigDirName, configFileName), Line: 3, Key: "config.git.auto-push",
+			Message: `expected a boolean, got "sometimes"`, Hint: "use true or false",
+		},
+		{
+			File: filepath.Join(projectDir, configFileName), Line: 3, Key: "config.mob-timer.duration",
+			Message: `invalid duration "5 minutes"`, Hint: "use a duration such as 90s, 5m or 1h30m",
+		},
+		{
+			File: filepath.Join(projectDir, branchConfigDirName, "feature", "x.yml"), Line: 3, Key: "config.tcr.langage",
+			Message: "unknown key", Hint: `did you mean "language"?`,
+		},
+	}, issues)
+}
+
+func Test_validate_valid_config_files(t *testing.T) {
+	setupLayeredConfig(t, "main", layeredConfigFiles{
+		project: languageConfig("java"),
+		branch:  "config:\n  git:\n    auto-push: true\n",
+	})
+	assert.Empty(t, Validate())
+}
@@ -0,0 +1,190 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package coverage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/spf13/afero"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Format is the format of a coverage report
+type Format string
+
+// List of supported coverage report formats
+const (
+	FormatCobertura      Format = "cobertura"
+	FormatLCOV           Format = "lcov"
+	FormatGoCoverProfile Format = "go-coverprofile"
+)
+
+// GetAllFormats returns the list of all supported coverage report formats
+func GetAllFormats() []Format {
+	return []Format{FormatCobertura, FormatLCOV, FormatGoCoverProfile}
+}
+
+// CheckFormat returns an error if the provided coverage report format is not supported
+func CheckFormat(format Format) error {
+	for _, f := range GetAllFormats() {
+		if f == format {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported coverage report format: %q", format)
+}
+
+// ParseFile parses the coverage report file found at the provided path
+func ParseFile(format Format, filePath string) (*Report, error) {
+	data, err := afero.ReadFile(appFS, filePath)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(format, data)
+}
+
+// Parse parses coverage report data in the provided format
+func Parse(format Format, data []byte) (*Report, error) {
+	switch format {
+	case FormatCobertura:
+		return parseCobertura(data)
+	case FormatLCOV:
+		return parseLCOV(data)
+	case FormatGoCoverProfile:
+		return parseGoCoverProfile(data)
+	default:
+		return nil, CheckFormat(format)
+	}
+}
+
+type (
+	coberturaXML struct {
+		Sources  []string              `xml:"sources>source"`
+		Packages []coberturaPackageXML `xml:"packages>package"`
+	}
+
+	coberturaPackageXML struct {
+		Classes []coberturaClassXML `xml:"classes>class"`
+	}
+
+	coberturaClassXML struct {
+		Filename string             `xml:"filename,attr"`
+		Lines    []coberturaLineXML `xml:"lines>line"`
+	}
+
+	coberturaLineXML struct {
+		Number int `xml:"number,attr"`
+		Hits   int `xml:"hits,attr"`
+	}
+)
+
+func parseCobertura(data []byte) (*Report, error) {
+	var cobertura coberturaXML
+	if err := xml.Unmarshal(data, &cobertura); err != nil {
+		return nil, err
+	}
+	r := NewReport()
+	for _, pkg := range cobertura.Packages {
+		for _, class := range pkg.Classes {
+			filename := class.Filename
+			if len(cobertura.Sources) == 1 && !path.IsAbs(filename) {
+				filename = path.Join(cobertura.Sources[0], filename)
+			}
+			for _, line := range class.Lines {
+				r.AddLine(filename, line.Number, line.Hits)
+			}
+		}
+	}
+	return r, nil
+}
+
+func parseLCOV(data []byte) (*Report, error) {
+	r := NewReport()
+	var file string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "SF:"):
+			file = strings.TrimPrefix(line, "SF:")
+		case strings.HasPrefix(line, "DA:"):
+			if file == "" {
+				return nil, fmt.Errorf("lcov line %d: line data found outside of a source file record", lineNumber)
+			}
+			fields := strings.Split(strings.TrimPrefix(line, "DA:"), ",")
+			if len(fields) < 2 {
+				return nil, fmt.Errorf("lcov line %d: invalid line data: %s", lineNumber, line)
+			}
+			number, err1 := strconv.Atoi(fields[0])
+			hits, err2 := strconv.Atoi(fields[1])
+			if err := errors.Join(err1, err2); err != nil {
+				return nil, fmt.Errorf("lcov line %d: %w", lineNumber, err)
+			}
+			r.AddLine(file, number, hits)
+		case line == "end_of_record":
+			file = ""
+		}
+	}
+	return r, scanner.Err()
+}
+
+func parseGoCoverProfile(data []byte) (*Report, error) {
+	r := NewReport()
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "mode:") {
+			continue
+		}
+		file, start, end, hits, err := parseGoCoverProfileBlock(line)
+		if err != nil {
+			return nil, fmt.Errorf("coverprofile line %d: %w", lineNumber, err)
+		}
+		for l := start; l <= end; l++ {
+			r.AddLine(file, l, hits)
+		}
+	}
+	return r, scanner.Err()
+}
+
+// parseGoCoverProfileBlock parses a coverprofile block line, which has the following format:
+// <file>:<startLine>.<startCol>,<endLine>.<endCol> <numStatements> <count>
+func parseGoCoverProfileBlock(line string) (file string, start, end, hits int, err error) {
+	colon := strings.LastIndex(line, ":")
+	if colon < 0 {
+		return "", 0, 0, 0, fmt.Errorf("invalid block: %s", line)
+	}
+	file = line[:colon]
+	var startCol, endCol, statements int
+	_, err = fmt.Sscanf(line[colon+1:], "%d.%d,%d.%d %d %d",
+		&start, &startCol, &end, &endCol, &statements, &hits)
+	if err != nil {
+		return "", 0, 0, 0, fmt.Errorf("invalid block: %s", line)
+	}
+	return file, start, end, hits, nil
+}
@@ -0,0 +1,126 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package coverage
+
+import (
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_check_format(t *testing.T) {
+	for _, format := range GetAllFormats() {
+		t.Run(string(format), func(t *testing.T) {
+			assert.NoError(t, CheckFormat(format))
+		})
+	}
+	t.Run("unknown format", func(t *testing.T) {
+		assert.Error(t, CheckFormat("unknown"))
+	})
+}
+
+func Test_parse_cobertura_report(t *testing.T) {
+	data := `<?xml version="1.0" ?>
+<coverage line-rate="0.5">
+	<sources><source>/root/project/src</source></sources>
+	<packages>
+		<package name="pkg">
+			<classes>
+				<class name="A" filename="pkg/a.py">
+					<lines>
+						<line number="1" hits="1"/>
+						<line number="2" hits="0"/>
+					</lines>
+				</class>
+			</classes>
+		</package>
+	</packages>
+</coverage>`
+	r, err := Parse(FormatCobertura, []byte(data))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/root/project/src/pkg/a.py"}, r.Files())
+	assert.Equal(t, LineStats{Covered: 1, Total: 2}, r.LineStats())
+}
+
+func Test_parse_invalid_cobertura_report(t *testing.T) {
+	_, err := Parse(FormatCobertura, []byte("<coverage"))
+	assert.Error(t, err)
+}
+
+func Test_parse_lcov_report(t *testing.T) {
+	data := "TN:\nSF:src/a.js\nDA:1,1\nDA:2,0\nDA:3,5,checksum\nend_of_record\nSF:src/b.js\nDA:1,0\nend_of_record\n"
+	r, err := Parse(FormatLCOV, []byte(data))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"src/a.js", "src/b.js"}, r.Files())
+	assert.Equal(t, LineStats{Covered: 2, Total: 4}, r.LineStats())
+}
+
+func Test_parse_invalid_lcov_report(t *testing.T) {
+	tests := []struct {
+		desc string
+		data string
+	}{
+		{"line data outside of file record", "DA:1,1\n"},
+		{"missing hit count", "SF:a.js\nDA:1\n"},
+		{"invalid line number", "SF:a.js\nDA:x,1\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := Parse(FormatLCOV, []byte(test.data))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_parse_go_coverprofile_report(t *testing.T) {
+	data := "mode: set\n" +
+		"github.com/some/module/a.go:3.10,5.2 2 1\n" +
+		"github.com/some/module/a.go:7.10,8.2 1 0\n" +
+		"github.com/some/module/a.go:5.2,6.3 1 0\n"
+	r, err := Parse(FormatGoCoverProfile, []byte(data))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"github.com/some/module/a.go"}, r.Files())
+	assert.Equal(t, LineStats{Covered: 3, Total: 6}, r.LineStats())
+}
+
+func Test_parse_invalid_go_coverprofile_report(t *testing.T) {
+	_, err := Parse(FormatGoCoverProfile, []byte("mode: set\na.go 1 2\n"))
+	assert.Error(t, err)
+}
+
+func Test_parse_report_with_unsupported_format(t *testing.T) {
+	_, err := Parse("unknown", []byte(""))
+	assert.Error(t, err)
+}
+
+func Test_parse_report_file(t *testing.T) {
+	appFS = afero.NewMemMapFs()
+	_ = afero.WriteFile(appFS, "lcov.info", []byte("SF:a.js\nDA:1,1\nend_of_record\n"), 0644)
+
+	r, err := ParseFile(FormatLCOV, "lcov.info")
+	assert.NoError(t, err)
+	assert.Equal(t, LineStats{Covered: 1, Total: 1}, r.LineStats())
+
+	_, err = ParseFile(FormatLCOV, "missing.info")
+	assert.Error(t, err)
+}
@@ -0,0 +1,174 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package coverage
+
+import (
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type (
+	// LineStats contains the number of covered lines vs the total number
+	// of instrumented lines
+	LineStats struct {
+		Covered int
+		Total   int
+	}
+
+	// Report contains line coverage data extracted from a coverage report.
+	// For each file, it provides the number of hits per instrumented line
+	Report struct {
+		files map[string]map[int]int
+	}
+)
+
+// NewReport creates a new empty coverage Report instance
+func NewReport() *Report {
+	return &Report{files: make(map[string]map[int]int)}
+}
+
+// AddLine records hits for the provided line of the provided file.
+// When the same line is recorded several times, the highest hit count is kept
+func (r *Report) AddLine(file string, line int, hits int) {
+	file = normalizePath(file)
+	if _, found := r.files[file]; !found {
+		r.files[file] = make(map[int]int)
+	}
+	if current, found := r.files[file][line]; !found || hits > current {
+		r.files[file][line] = hits
+	}
+}
+
+// Merge adds line coverage data from the provided report into this report.
+// When the same line appears in both reports, the highest hit count is kept
+func (r *Report) Merge(other *Report) {
+	if other == nil {
+		return
+	}
+	for file, lines := range other.files {
+		for line, hits := range lines {
+			r.AddLine(file, line, hits)
+		}
+	}
+}
+
+// Files returns the sorted list of files present in the coverage report
+func (r *Report) Files() []string {
+	var files []string
+	for file := range r.files {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return files
+}
+
+// LineStats returns line coverage stats for all files in the coverage report
+func (r *Report) LineStats() (stats LineStats) {
+	for file := range r.files {
+		stats = stats.Add(r.fileStats(file))
+	}
+	return stats
+}
+
+// LineStatsForLines returns line coverage stats for the lines of the provided file matching
+// the provided predicate. found is false when the file cannot be found in the coverage report
+func (r *Report) LineStatsForLines(file string, predicate func(line int) bool) (stats LineStats, found bool) {
+	entry, found := r.lookup(file)
+	if !found {
+		return stats, false
+	}
+	for line, hits := range r.files[entry] {
+		if predicate(line) {
+			stats.Total++
+			if hits > 0 {
+				stats.Covered++
+			}
+		}
+	}
+	return stats, true
+}
+
+func (r *Report) fileStats(file string) (stats LineStats) {
+	for _, hits := range r.files[file] {
+		stats.Total++
+		if hits > 0 {
+			stats.Covered++
+		}
+	}
+	return stats
+}
+
+// lookup finds the coverage report entry corresponding to the provided file path.
+// Coverage reports usually contain paths that are relative to some root directory
+// (or package import paths in the case of Go), while the provided path is usually absolute.
+// The matching entry is the one sharing the highest number of trailing path elements
+// with the provided path (at least 2, unless the entry is made of a single element)
+func (r *Report) lookup(file string) (entry string, found bool) {
+	file = normalizePath(file)
+	if _, ok := r.files[file]; ok {
+		return file, true
+	}
+	fileElements := strings.Split(file, "/")
+	bestScore := 0
+	for candidate := range r.files {
+		candidateElements := strings.Split(candidate, "/")
+		score := commonTrailingElements(fileElements, candidateElements)
+		if score < len(candidateElements) && score < 2 {
+			continue
+		}
+		if score > bestScore || (score == bestScore && candidate < entry) {
+			bestScore, entry = score, candidate
+		}
+	}
+	return entry, bestScore > 0
+}
+
+func commonTrailingElements(a, b []string) (count int) {
+	for i, j := len(a)-1, len(b)-1; i >= 0 && j >= 0 && a[i] == b[j]; i, j = i-1, j-1 {
+		count++
+	}
+	return count
+}
+
+func normalizePath(p string) string {
+	return strings.TrimPrefix(path.Clean(filepath.ToSlash(p)), "./")
+}
+
+// Add returns the sum of both line coverage stats
+func (s LineStats) Add(other LineStats) LineStats {
+	return LineStats{
+		Covered: s.Covered + other.Covered,
+		Total:   s.Total + other.Total,
+	}
+}
+
+// Percentage returns the percentage (rounded down) of covered lines.
+// Returns 100 when there is no instrumented line
+func (s LineStats) Percentage() int {
+	if s.Total == 0 {
+		return 100
+	}
+	return 100 * s.Covered / s.Total
+}
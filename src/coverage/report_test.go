@@ -0,0 +1,142 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package coverage
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_report_line_stats(t *testing.T) {
+	r := NewReport()
+	r.AddLine("a.go", 1, 1)
+	r.AddLine("a.go", 2, 0)
+	r.AddLine("b.go", 1, 3)
+	assert.Equal(t, LineStats{Covered: 2, Total: 3}, r.LineStats())
+}
+
+func Test_report_keeps_highest_hit_count_for_a_line(t *testing.T) {
+	r := NewReport()
+	r.AddLine("a.go", 1, 0)
+	r.AddLine("a.go", 1, 2)
+	r.AddLine("a.go", 1, 0)
+	assert.Equal(t, LineStats{Covered: 1, Total: 1}, r.LineStats())
+}
+
+func Test_report_line_stats_for_selected_files(t *testing.T) {
+	r := NewReport()
+	r.AddLine("src/main/a.go", 1, 1)
+	r.AddLine("src/main/a.go", 2, 0)
+	r.AddLine("github.com/some/module/pkg/b.go", 1, 0)
+	r.AddLine("c.go", 1, 1)
+	r.AddLine("other/a.go", 1, 1)
+
+	tests := []struct {
+		desc     string
+		files    []string
+		expected LineStats
+	}{
+		{"no file", nil, LineStats{}},
+		{"file not in report", []string{"/root/project/unknown.go"}, LineStats{}},
+		{"relative path suffix", []string{"/root/project/src/main/a.go"}, LineStats{Covered: 1, Total: 2}},
+		{"go package import path", []string{"/root/module/pkg/b.go"}, LineStats{Covered: 0, Total: 1}},
+		{"single element path", []string{"/root/project/c.go"}, LineStats{Covered: 1, Total: 1}},
+		{"only file name in common", []string{"/root/project/x/b.go"}, LineStats{}},
+		{"several files", []string{"/p/src/main/a.go", "/p/pkg/b.go"}, LineStats{Covered: 1, Total: 3}},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			var stats LineStats
+			for _, file := range test.files {
+				fileStats, _ := r.LineStatsForLines(file, func(_ int) bool { return true })
+				stats = stats.Add(fileStats)
+			}
+			assert.Equal(t, test.expected, stats)
+		})
+	}
+}
+
+func Test_report_line_stats_for_selected_lines(t *testing.T) {
+	r := NewReport()
+	r.AddLine("src/main/a.go", 1, 1)
+	r.AddLine("src/main/a.go", 2, 0)
+	r.AddLine("src/main/a.go", 3, 1)
+
+	tests := []struct {
+		desc          string
+		file          string
+		predicate     func(line int) bool
+		expected      LineStats
+		expectedFound bool
+	}{
+		{"file not in report", "/p/unknown.go", func(_ int) bool { return true }, LineStats{}, false},
+		{"all lines", "/p/src/main/a.go", func(_ int) bool { return true }, LineStats{Covered: 2, Total: 3}, true},
+		{"no line", "/p/src/main/a.go", func(_ int) bool { return false }, LineStats{}, true},
+		{"some lines", "/p/src/main/a.go", func(line int) bool { return line >= 2 }, LineStats{Covered: 1, Total: 2}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			stats, found := r.LineStatsForLines(test.file, test.predicate)
+			assert.Equal(t, test.expected, stats)
+			assert.Equal(t, test.expectedFound, found)
+		})
+	}
+}
+
+func Test_report_merge(t *testing.T) {
+	r := NewReport()
+	r.AddLine("a.go", 1, 0)
+	r.AddLine("a.go", 2, 1)
+	other := NewReport()
+	other.AddLine("a.go", 1, 1)
+	other.AddLine("b.ts", 1, 0)
+	r.Merge(other)
+	r.Merge(nil)
+	assert.Equal(t, LineStats{Covered: 2, Total: 3}, r.LineStats())
+	assert.Equal(t, []string{"a.go", "b.ts"}, r.Files())
+}
+
+func Test_report_files(t *testing.T) {
+	r := NewReport()
+	r.AddLine("./b.go", 1, 1)
+	r.AddLine("a.go", 1, 1)
+	assert.Equal(t, []string{"a.go", "b.go"}, r.Files())
+}
+
+func Test_line_stats_percentage(t *testing.T) {
+	tests := []struct {
+		stats    LineStats
+		expected int
+	}{
+		{LineStats{Covered: 0, Total: 0}, 100},
+		{LineStats{Covered: 0, Total: 4}, 0},
+		{LineStats{Covered: 1, Total: 3}, 33},
+		{LineStats{Covered: 4, Total: 4}, 100},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprint(test.stats), func(t *testing.T) {
+			assert.Equal(t, test.expected, test.stats.Percentage())
+		})
+	}
+}
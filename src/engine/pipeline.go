@@ -0,0 +1,302 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"github.com/murex/tcr/coverage"
+	"github.com/murex/tcr/language"
+	"github.com/murex/tcr/params"
+	"github.com/murex/tcr/report"
+	"github.com/murex/tcr/status"
+	"github.com/murex/tcr/toolchain"
+	"github.com/murex/tcr/vcs"
+	"os"
+	"path/filepath"
+)
+
+// pipeline gathers a language and the toolchain used for building and testing it.
+// A TCR session has a single pipeline, unless the project is made of several modules
+// (polyglot project). In this case, each module has its own pipeline, with the module's
+// subdirectory used as both base and work directory.
+// - module is the module specification. It is empty when no module is configured.
+// - baseDir is the base directory for the language files.
+// - workDir is the directory where toolchain commands are run. When empty,
+// the work directory of the TCR session is used.
+// - testSelection keeps track of test runs when only affected tests are run.
+type pipeline struct {
+	module        string
+	baseDir       string
+	workDir       string
+	language      language.LangInterface
+	toolchain     toolchain.TchnInterface
+	testSelection *testSelectionState
+}
+
+// newModulePipelines creates one pipeline per provided module specification.
+// Module subdirectories are relative to the provided base directory.
+// Each pipeline has a language instance of its own, so that several modules can use the same language
+func newModulePipelines(baseDir string, modules []string) (pipelines []pipeline, err error) {
+	for _, module := range modules {
+		p, err := newModulePipeline(baseDir, module)
+		if err != nil {
+			return nil, err
+		}
+		pipelines = append(pipelines, p)
+	}
+	return pipelines, nil
+}
+
+func newModulePipeline(baseDir string, module string) (p pipeline, err error) {
+	m, err := params.ParseModule(module)
+	if err != nil {
+		return p, err
+	}
+	dir, err := filepath.Abs(filepath.Join(baseDir, m.SubDir))
+	if err != nil {
+		return p, err
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return p, errors.New("module \"" + module + "\": cannot access directory " + dir)
+	}
+	p = pipeline{module: module, baseDir: dir, workDir: dir, testSelection: &testSelectionState{}}
+	if p.language, err = language.GetLanguageInstance(m.Language, dir); err != nil {
+		return p, fmt.Errorf("module \"%s\": %w", module, err)
+	}
+	if p.toolchain, err = p.language.GetToolchain(m.Toolchain); err != nil {
+		return p, fmt.Errorf("module \"%s\": %w", module, err)
+	}
+	return p, nil
+}
+
+// describe returns a short description of the pipeline to be appended to trace messages.
+// Returns an empty string when no module is configured
+func (p pipeline) describe() string {
+	if p.module == "" {
+		return ""
+	}
+	return " for module " + p.module
+}
+
+// activate makes the pipeline's directories and changed files available to toolchain commands
+func (p pipeline) activate(diffs vcs.FileDiffs) error {
+	if p.workDir != "" {
+		if err := toolchain.SetWorkDir(p.workDir); err != nil {
+			return err
+		}
+		if err := toolchain.SetBaseDir(p.baseDir); err != nil {
+			return err
+		}
+	}
+	var srcFiles, testFiles []string
+	for _, diff := range diffs {
+		if p.language.IsTestFile(diff.Path) {
+			testFiles = append(testFiles, diff.Path)
+		} else if p.language.IsSrcFile(diff.Path) {
+			srcFiles = append(srcFiles, diff.Path)
+		}
+	}
+	toolchain.SetChangedFiles(srcFiles, testFiles)
+	return nil
+}
+
+// isAffectedBy indicates if at least one of the provided changes is a file of the pipeline's language
+func (p pipeline) isAffectedBy(diffs vcs.FileDiffs) bool {
+	for _, diff := range diffs {
+		if p.language.IsLanguageFile(diff.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// pipelines returns the pipelines of the TCR session. When no module is configured,
+// there is a single pipeline relying on the session's language and toolchain
+func (tcr *TCREngine) pipelines() []pipeline {
+	if len(tcr.modules) > 0 {
+		return tcr.modules
+	}
+	return []pipeline{{
+		baseDir:       tcr.sourceTree.GetBaseDir(),
+		language:      tcr.language,
+		toolchain:     tcr.toolchain,
+		testSelection: &tcr.testSelection,
+	}}
+}
+
+// affectedPipelines returns the pipelines whose language files were changed.
+// All pipelines are returned when none of the changes can be related to a pipeline
+func (tcr *TCREngine) affectedPipelines(diffs vcs.FileDiffs) []pipeline {
+	all := tcr.pipelines()
+	if len(all) == 1 {
+		return all
+	}
+	var affected []pipeline
+	for _, p := range all {
+		if p.isAffectedBy(diffs) {
+			affected = append(affected, p)
+		}
+	}
+	if len(affected) == 0 {
+		return all
+	}
+	for _, p := range affected {
+		report.PostInfo("Changes detected in module ", p.module)
+	}
+	return affected
+}
+
+// activate makes the provided pipeline the one used by toolchain commands
+func (tcr *TCREngine) activate(p pipeline, diffs vcs.FileDiffs) error {
+	err := p.activate(diffs)
+	if err != nil {
+		tcr.handleError(err, false, status.ConfigError)
+	}
+	return err
+}
+
+// restoreSession makes the session's primary pipeline the one used by toolchain commands again,
+// restoring the session's work and base directories after running other pipelines
+func (tcr *TCREngine) restoreSession(diffs vcs.FileDiffs) {
+	_ = tcr.activate(tcr.pipelines()[0], diffs)
+}
+
+// isSrcFile returns true if the provided file is a source file for any of the session's languages
+func (tcr *TCREngine) isSrcFile(path string) bool {
+	for _, p := range tcr.pipelines() {
+		if p.language.IsSrcFile(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// isTestFile returns true if the provided file is a test file for any of the session's languages
+func (tcr *TCREngine) isTestFile(path string) bool {
+	for _, p := range tcr.pipelines() {
+		if p.language.IsTestFile(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// isLanguageFile returns true if the provided file belongs to any of the session's languages
+func (tcr *TCREngine) isLanguageFile(path string) bool {
+	for _, p := range tcr.pipelines() {
+		if p.language.IsLanguageFile(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// dirsToWatch returns the directories to watch for all the session's languages
+func (tcr *TCREngine) dirsToWatch() (dirs []string) {
+	for _, p := range tcr.pipelines() {
+		dirs = append(dirs, p.language.DirsToWatch(p.baseDir)...)
+	}
+	return dirs
+}
+
+// buildAll runs the build of each provided pipeline. It stops at the first pipeline failing to build,
+// or that cannot be activated
+func (tcr *TCREngine) buildAll(pipelines []pipeline, diffs vcs.FileDiffs) (result toolchain.CommandResult) {
+	defer tcr.restoreSession(diffs)
+	for _, p := range pipelines {
+		if err := tcr.activate(p, diffs); err != nil {
+			result.Status = toolchain.CommandStatusError
+			break
+		}
+		r := tcr.build(p)
+		result.Status = r.Status
+		result.Output += r.Output
+		result.Steps = append(result.Steps, r.Steps...)
+		if r.Failed() {
+			break
+		}
+	}
+	return result
+}
+
+// testAll runs the tests of each provided pipeline, and combines their results.
+// Tests are considered as passing only if they pass for all pipelines.
+// It stops at the first pipeline with a test infrastructure error, or that cannot be activated.
+// The primary pipeline is activated again when done, so that slow tests are run with it
+func (tcr *TCREngine) testAll(pipelines []pipeline, diffs vcs.FileDiffs) (result toolchain.TestCommandResult) {
+	defer tcr.restoreSession(diffs)
+	for i, p := range pipelines {
+		if err := tcr.activate(p, diffs); err != nil {
+			result.Status = toolchain.CommandStatusError
+			break
+		}
+		r := tcr.checkCoverage(p, tcr.test(p))
+		if i == 0 {
+			result = r
+		} else {
+			result = combineTestResults(result, r)
+		}
+		if r.InfraError() {
+			break
+		}
+	}
+	return result
+}
+
+// combineTestResults combines the results of 2 test runs. The combined status is the worst of both
+func combineTestResults(a, b toolchain.TestCommandResult) (result toolchain.TestCommandResult) {
+	result.Status = worstStatus(a.Status, b.Status)
+	result.Output = a.Output + b.Output
+	result.Steps = append(append(result.Steps, a.Steps...), b.Steps...)
+	result.Stats = toolchain.TestStats{
+		TotalRun:    a.Stats.TotalRun + b.Stats.TotalRun,
+		Passed:      a.Stats.Passed + b.Stats.Passed,
+		Failed:      a.Stats.Failed + b.Stats.Failed,
+		Skipped:     a.Stats.Skipped + b.Stats.Skipped,
+		WithErrors:  a.Stats.WithErrors + b.Stats.WithErrors,
+		Duration:    a.Stats.Duration + b.Stats.Duration,
+		FailedTests: append(append([]string(nil), a.Stats.FailedTests...), b.Stats.FailedTests...),
+		PassedTests: append(append([]string(nil), a.Stats.PassedTests...), b.Stats.PassedTests...),
+	}
+	if a.Coverage != nil || b.Coverage != nil {
+		result.Coverage = coverage.NewReport()
+		result.Coverage.Merge(a.Coverage)
+		result.Coverage.Merge(b.Coverage)
+	}
+	return result
+}
+
+// worstStatus returns the worst of the 2 provided command statuses
+func worstStatus(a, b toolchain.CommandStatus) toolchain.CommandStatus {
+	for _, s := range []toolchain.CommandStatus{
+		toolchain.CommandStatusError,
+		toolchain.CommandStatusFail,
+		toolchain.CommandStatusUnknown,
+	} {
+		if a == s || b == s {
+			return s
+		}
+	}
+	return a
+}
@@ -0,0 +1,270 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package engine
+
+import (
+	"github.com/murex/tcr/coverage"
+	"github.com/murex/tcr/language"
+	"github.com/murex/tcr/status"
+	"github.com/murex/tcr/toolchain"
+	"github.com/murex/tcr/vcs"
+	"github.com/murex/tcr/vcs/fake"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func setupModuleDirs(t *testing.T, subDirs ...string) string {
+	t.Helper()
+	baseDir := t.TempDir()
+	for _, subDir := range subDirs {
+		if err := os.MkdirAll(filepath.Join(baseDir, subDir), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return baseDir
+}
+
+func Test_new_module_pipelines(t *testing.T) {
+	baseDir := setupModuleDirs(t, "backend", "frontend")
+	pipelines, err := newModulePipelines(baseDir, []string{"go@backend", "rust:cargo@frontend"})
+	assert.NoError(t, err)
+	if assert.Len(t, pipelines, 2) {
+		assert.Equal(t, "go", pipelines[0].language.GetName())
+		assert.Equal(t, "go-tools", pipelines[0].toolchain.GetName())
+		assert.Equal(t, filepath.Join(baseDir, "backend"), pipelines[0].baseDir)
+		assert.Equal(t, filepath.Join(baseDir, "backend"), pipelines[0].workDir)
+		assert.Equal(t, "rust", pipelines[1].language.GetName())
+		assert.Equal(t, "cargo", pipelines[1].toolchain.GetName())
+		assert.Equal(t, filepath.Join(baseDir, "frontend"), pipelines[1].baseDir)
+	}
+}
+
+func Test_new_module_pipelines_with_same_language(t *testing.T) {
+	baseDir := setupModuleDirs(t, "backend", "frontend")
+	registered, _ := language.Get("go")
+	pipelines, err := newModulePipelines(baseDir, []string{"go@backend", "go@frontend"})
+	assert.NoError(t, err)
+	if assert.Len(t, pipelines, 2) {
+		backendFile := filepath.Join(baseDir, "backend", "hello.go")
+		frontendFile := filepath.Join(baseDir, "frontend", "hello.go")
+		assert.True(t, pipelines[0].language.IsSrcFile(backendFile))
+		assert.False(t, pipelines[0].language.IsSrcFile(frontendFile))
+		assert.True(t, pipelines[1].language.IsSrcFile(frontendFile))
+		assert.False(t, pipelines[1].language.IsSrcFile(backendFile))
+		assert.NotSame(t, registered, pipelines[0].language)
+	}
+}
+
+func Test_new_module_pipelines_errors(t *testing.T) {
+	baseDir := setupModuleDirs(t, "backend", "frontend")
+	tests := []struct {
+		desc    string
+		modules []string
+	}{
+		{"invalid module", []string{"go"}},
+		{"missing directory", []string{"go@unknown"}},
+		{"unknown language", []string{"unknown-language@backend"}},
+		{"incompatible toolchain", []string{"go:gradle@backend"}},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := newModulePipelines(baseDir, test.modules)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_affected_pipelines(t *testing.T) {
+	baseDir := setupModuleDirs(t, "backend", "frontend")
+	tcr, _ := initTCREngineWithFakes(nil, nil, nil, nil)
+	var err error
+	tcr.modules, err = newModulePipelines(baseDir, []string{"go@backend", "rust@frontend"})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		desc     string
+		diffs    vcs.FileDiffs
+		expected []string
+	}{
+		{
+			"go file changed",
+			vcs.FileDiffs{vcs.NewFileDiff(filepath.Join(baseDir, "backend", "hello.go"), 1, 1)},
+			[]string{"go@backend"},
+		},
+		{
+			"rust file changed",
+			vcs.FileDiffs{vcs.NewFileDiff(filepath.Join(baseDir, "frontend", "src", "lib.rs"), 1, 1)},
+			[]string{"rust@frontend"},
+		},
+		{
+			"go and rust files changed",
+			vcs.FileDiffs{
+				vcs.NewFileDiff(filepath.Join(baseDir, "backend", "hello.go"), 1, 1),
+				vcs.NewFileDiff(filepath.Join(baseDir, "frontend", "tests", "hello_test.rs"), 1, 1),
+			},
+			[]string{"go@backend", "rust@frontend"},
+		},
+		{
+			"no language file changed",
+			vcs.FileDiffs{vcs.NewFileDiff(filepath.Join(baseDir, "README.md"), 1, 1)},
+			[]string{"go@backend", "rust@frontend"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			var modules []string
+			for _, p := range tcr.affectedPipelines(test.diffs) {
+				modules = append(modules, p.module)
+			}
+			assert.Equal(t, test.expected, modules)
+		})
+	}
+}
+
+func Test_tcr_cycle_with_modules(t *testing.T) {
+	tests := []struct {
+		desc            string
+		failures        []toolchain.Operations
+		expectedStatus  status.Status
+		expectedCommand fake.Command
+	}{
+		{
+			"all modules passing",
+			[]toolchain.Operations{nil, nil},
+			status.Ok, fake.PushCommand,
+		},
+		{
+			"build failing in one module",
+			[]toolchain.Operations{nil, {toolchain.BuildOperation}},
+			status.BuildFailed, fake.DiffCommand,
+		},
+		{
+			"tests failing in one module",
+			[]toolchain.Operations{{toolchain.TestOperation}, nil},
+			status.Ok, fake.RestoreCommand,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			status.RecordState(status.Ok)
+			tcr, vcsFake := initTCREngineWithFakes(nil, nil, nil, nil)
+			for i, failures := range test.failures {
+				tcr.modules = append(tcr.modules, pipeline{
+					module:        "module-" + string(rune('a'+i)),
+					language:      language.NewFakeLanguage("fake-toolchain"),
+					toolchain:     toolchain.NewFakeToolchain(failures, toolchain.TestStats{}),
+					testSelection: &testSelectionState{},
+				})
+			}
+			tcr.RunTCRCycle()
+			assert.Equal(t, test.expectedStatus, status.GetCurrentState())
+			assert.Equal(t, test.expectedCommand, vcsFake.GetLastCommand())
+		})
+	}
+}
+
+func Test_tcr_cycle_with_module_directories(t *testing.T) {
+	baseDir := setupModuleDirs(t, "backend", "frontend")
+	tests := []struct {
+		desc           string
+		secondModule   string
+		failures       toolchain.Operations
+		expectedStatus status.Status
+	}{
+		{"build failing in second module", "frontend", toolchain.Operations{toolchain.BuildOperation}, status.BuildFailed},
+		{"tests failing in second module", "frontend", toolchain.Operations{toolchain.TestOperation}, status.Ok},
+		{"second module cannot be activated", "missing", nil, status.ConfigError},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			previousWorkDir := toolchain.GetWorkDir()
+			t.Cleanup(func() { _ = toolchain.SetWorkDir(previousWorkDir) })
+			status.RecordState(status.Ok)
+			tcr, _ := initTCREngineWithFakes(nil, nil, nil, nil)
+			for i, subDir := range []string{"backend", test.secondModule} {
+				dir := filepath.Join(baseDir, subDir)
+				var failures toolchain.Operations
+				if i > 0 {
+					failures = test.failures
+				}
+				tcr.modules = append(tcr.modules, pipeline{
+					module:        "fake@" + subDir,
+					baseDir:       dir,
+					workDir:       dir,
+					language:      language.NewFakeLanguage("fake-toolchain"),
+					toolchain:     toolchain.NewFakeToolchain(failures, toolchain.TestStats{}),
+					testSelection: &testSelectionState{},
+				})
+			}
+			tcr.RunTCRCycle()
+			assert.Equal(t, test.expectedStatus, status.GetCurrentState())
+			// Session directories are the primary module's ones once the cycle is over
+			assert.Equal(t, filepath.Join(baseDir, "backend"), toolchain.GetWorkDir())
+		})
+	}
+}
+
+func Test_combine_test_results(t *testing.T) {
+	coverageA := coverage.NewReport()
+	coverageA.AddLine("a.go", 1, 1)
+	coverageB := coverage.NewReport()
+	coverageB.AddLine("b.ts", 1, 0)
+	a := toolchain.TestCommandResult{
+		CommandResult: toolchain.CommandResult{Status: toolchain.CommandStatusPass, Output: "a"},
+		Stats: toolchain.TestStats{TotalRun: 2, Passed: 2, Duration: time.Second,
+			PassedTests: []string{"a1", "a2"}},
+		Coverage: coverageA,
+	}
+	b := toolchain.TestCommandResult{
+		CommandResult: toolchain.CommandResult{Status: toolchain.CommandStatusFail, Output: "b"},
+		Stats: toolchain.TestStats{TotalRun: 2, Passed: 1, Failed: 1, Duration: 2 * time.Second,
+			PassedTests: []string{"b1"}, FailedTests: []string{"b2"}},
+		Coverage: coverageB,
+	}
+	result := combineTestResults(a, b)
+	assert.Equal(t, toolchain.CommandStatusFail, result.Status)
+	assert.Equal(t, "ab", result.Output)
+	assert.Equal(t, toolchain.TestStats{TotalRun: 4, Passed: 3, Failed: 1, Duration: 3 * time.Second,
+		PassedTests: []string{"a1", "a2", "b1"}, FailedTests: []string{"b2"}}, result.Stats)
+	assert.Equal(t, coverage.LineStats{Covered: 1, Total: 2}, result.Coverage.LineStats())
+}
+
+func Test_worst_status(t *testing.T) {
+	tests := []struct {
+		a, b     toolchain.CommandStatus
+		expected toolchain.CommandStatus
+	}{
+		{toolchain.CommandStatusPass, toolchain.CommandStatusPass, toolchain.CommandStatusPass},
+		{toolchain.CommandStatusPass, toolchain.CommandStatusFail, toolchain.CommandStatusFail},
+		{toolchain.CommandStatusFail, toolchain.CommandStatusError, toolchain.CommandStatusError},
+		{toolchain.CommandStatusError, toolchain.CommandStatusPass, toolchain.CommandStatusError},
+	}
+	for _, test := range tests {
+		t.Run(string(test.a)+"-"+string(test.b), func(t *testing.T) {
+			assert.Equal(t, test.expected, worstStatus(test.a, test.b))
+		})
+	}
+}
@@ -28,6 +28,7 @@ type SessionInfo struct {
 	WorkDir           string
 	LanguageName      string
 	ToolchainName     string
+	Modules           []string
 	VCSName           string
 	VCSSessionSummary string
 	CommitOnFail      bool
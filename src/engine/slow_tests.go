@@ -0,0 +1,54 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package engine
+
+import (
+	"github.com/murex/tcr/events"
+	"github.com/murex/tcr/report"
+)
+
+const (
+	slowTestFailureMessage = "Slow tests are failing!"
+	slowTestSuccessMessage = "Slow tests passed"
+)
+
+// runSlowTests runs the toolchain's slow tests, if any, and returns their status.
+// Slow tests run once the tests deciding between commit and revert have passed, so that their
+// status is recorded with the changes they were run on. A slow test failure does not prevent
+// changes from being committed. An empty status is returned when the toolchain has no slow tests
+func (tcr *TCREngine) runSlowTests() events.CommandStatus {
+	if len(tcr.toolchain.GetSlowTestCommands()) == 0 {
+		return ""
+	}
+	report.PostInfo("Running slow tests")
+	result := tcr.toolchain.RunSlowTests()
+	if result.Passed() {
+		report.PostInfo(slowTestSuccessMessage)
+		return events.StatusPass
+	}
+	if result.Output != "" {
+		report.PostText(result.Output)
+	}
+	tcr.ui.NotifyAlert(slowTestFailureMessage)
+	return events.StatusFail
+}
@@ -0,0 +1,65 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package engine
+
+import (
+	"github.com/murex/tcr/events"
+	"github.com/murex/tcr/status"
+	"github.com/murex/tcr/toolchain"
+	"github.com/murex/tcr/vcs/fake"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_slow_tests_are_not_run_when_toolchain_has_none(t *testing.T) {
+	tcr, _ := initTCREngineWithFakes(nil, nil, nil, nil)
+	assert.Equal(t, events.CommandStatus(""), tcr.runSlowTests())
+}
+
+func Test_run_slow_tests(t *testing.T) {
+	tests := []struct {
+		desc     string
+		failures toolchain.Operations
+		expected events.CommandStatus
+	}{
+		{"passing slow tests", nil, events.StatusPass},
+		{"failing slow tests", toolchain.Operations{toolchain.SlowTestOperation}, events.StatusFail},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			tcr, _ := initTCREngineWithFakes(nil, nil, nil, nil)
+			tcr.toolchain = toolchain.NewFakeToolchain(test.failures, toolchain.TestStats{}).WithSlowTests()
+			assert.Equal(t, test.expected, tcr.runSlowTests())
+		})
+	}
+}
+
+func Test_failing_slow_tests_do_not_prevent_commit(t *testing.T) {
+	status.RecordState(status.Ok)
+	tcr, vcsFake := initTCREngineWithFakes(nil, nil, nil, nil)
+	tcr.toolchain = toolchain.NewFakeToolchain(
+		toolchain.Operations{toolchain.SlowTestOperation}, toolchain.TestStats{}).WithSlowTests()
+	tcr.RunTCRCycle()
+	assert.Equal(t, status.Ok, status.GetCurrentState())
+	assert.Equal(t, fake.PushCommand, vcsFake.GetLastCommand())
+}
@@ -23,11 +23,14 @@ SOFTWARE.
 package engine
 
 import (
+	"fmt"
 	"github.com/murex/tcr/checker"
+	"github.com/murex/tcr/coverage"
 	"github.com/murex/tcr/events"
 	"github.com/murex/tcr/filesystem"
 	"github.com/murex/tcr/language"
 	"github.com/murex/tcr/params"
+	"github.com/murex/tcr/quarantine"
 	"github.com/murex/tcr/report"
 	"github.com/murex/tcr/role"
 	"github.com/murex/tcr/runmode"
@@ -58,6 +61,7 @@ type (
 		RunAsNavigator()
 		Stop()
 		RunTCRCycle()
+		RequestFullTestRun()
 		GetSessionInfo() SessionInfo
 		ReportMobTimerStatus()
 		SetRunMode(m runmode.RunMode)
@@ -76,6 +80,7 @@ type (
 		vcs             vcs.Interface
 		language        language.LangInterface
 		toolchain       toolchain.TchnInterface
+		modules         []pipeline
 		sourceTree      filesystem.SourceTree
 		pollingPeriod   time.Duration
 		mobTurnDuration time.Duration
@@ -93,6 +98,8 @@ type (
 		// after a filesystem event was detected. The default value should not be changed except
 		// when running tests
 		fsWatchRearmDelay time.Duration
+		// testSelection keeps track of test runs when only affected tests are run
+		testSelection testSelectionState
 	}
 )
 
@@ -109,6 +116,15 @@ const (
 	testSuccessMessage  = "Tests passed!"
 )
 
+// infraErrorMessage is reported when the build or test infrastructure fails. Changes are neither committed nor reverted
+const infraErrorMessage = "Build or test infrastructure error! Leaving changes untouched"
+
+// coverageFailureMessage expects actual and threshold coverage percentages as parameters
+const coverageFailureMessage = "Changed lines are not covered enough by tests: %d%% (threshold is %d%%)"
+
+// coverageUnknownMessage is used when the coverage report contains no data for changed source files
+const coverageUnknownMessage = "Coverage of changed lines is unknown: no coverage data found for changed source files"
+
 var (
 	// TCR is TCR Engine singleton instance
 	TCR TCRInterface
@@ -144,16 +160,22 @@ func (tcr *TCREngine) Init(u ui.UserInterface, p params.Params) {
 
 	tcr.initSourceTree(p)
 
-	tcr.language, err = language.GetLanguage(p.Language, tcr.sourceTree.GetBaseDir())
-	tcr.handleError(err, true, status.ConfigError)
-	tcr.reportFileStats()
+	if len(p.Modules) > 0 {
+		tcr.initModules(p.Modules)
+	} else {
+		tcr.language, err = language.GetLanguage(p.Language, tcr.sourceTree.GetBaseDir())
+		tcr.handleError(err, true, status.ConfigError)
+		reportFileStats(tcr.language)
 
-	tcr.toolchain, err = tcr.language.GetToolchain(p.Toolchain)
-	tcr.handleError(err, true, status.ConfigError)
+		tcr.toolchain, err = tcr.language.GetToolchain(p.Toolchain)
+		tcr.handleError(err, true, status.ConfigError)
 
-	err = toolchain.SetWorkDir(p.WorkDir)
-	tcr.handleError(err, true, status.ConfigError)
-	report.PostInfo("Work directory is ", toolchain.GetWorkDir())
+		err = toolchain.SetWorkDir(p.WorkDir)
+		tcr.handleError(err, true, status.ConfigError)
+		report.PostInfo("Work directory is ", toolchain.GetWorkDir())
+		err = toolchain.SetBaseDir(tcr.sourceTree.GetBaseDir())
+		tcr.handleError(err, true, status.ConfigError)
+	}
 
 	tcr.initVCS(p.VCS, p.Trace)
 	tcr.setMessageSuffix(p.MessageSuffix)
@@ -167,6 +189,23 @@ func (tcr *TCREngine) Init(u ui.UserInterface, p params.Params) {
 	tcr.warnIfOnRootBranch(tcr.mode.IsInteractive())
 }
 
+// initModules initializes one pipeline per module of a polyglot project.
+// The first module's language and toolchain are used as the session's primary ones
+func (tcr *TCREngine) initModules(modules []string) {
+	var err error
+	tcr.modules, err = newModulePipelines(tcr.sourceTree.GetBaseDir(), modules)
+	tcr.handleError(err, true, status.ConfigError)
+	for _, p := range tcr.modules {
+		report.PostInfo("Module ", p.module, ": language=", p.language.GetName(),
+			", toolchain=", p.toolchain.GetName(), ", directory=", p.baseDir)
+		reportFileStats(p.language)
+	}
+	tcr.language = tcr.modules[0].language
+	tcr.toolchain = tcr.modules[0].toolchain
+	_ = tcr.activate(tcr.modules[0], nil)
+	report.PostInfo("Work directory is ", toolchain.GetWorkDir())
+}
+
 // SetCommitOnFail sets VCS commit-on-fail option to the provided value
 func (tcr *TCREngine) SetCommitOnFail(flag bool) {
 	tcr.commitOnFail = flag
@@ -184,9 +223,15 @@ func (tcr *TCREngine) setMobTimerDuration(duration time.Duration) {
 	}
 }
 
-// RunCheck checks the provided parameters and prints out corresponding report
-func (*TCREngine) RunCheck(p params.Params) {
+// RunCheck checks the provided parameters and prints out corresponding report.
+// In fix mode, available remediations are proposed one by one, and checks are run
+// again when at least one of them was applied
+func (tcr *TCREngine) RunCheck(p params.Params) {
 	checker.Run(p)
+	if p.CheckFix && checker.Fix(&p, tcr.ui.Confirm) > 0 {
+		report.PostInfo("Checking again after applying fixes")
+		checker.Run(p)
+	}
 }
 
 // PrintLog prints the TCR VCS commit history
@@ -428,8 +473,7 @@ func (tcr *TCREngine) fromBirthTillDeath(
 }
 
 func (tcr *TCREngine) waitForChange(interrupt <-chan bool) bool {
-	languageDirs := tcr.language.DirsToWatch(tcr.sourceTree.GetBaseDir())
-	existingDirs, err := language.ExistingDirsIn(languageDirs)
+	existingDirs, err := language.ExistingDirsIn(tcr.dirsToWatch())
 	if err != nil {
 		tcr.handleError(err, true, status.OtherError)
 	}
@@ -440,25 +484,43 @@ func (tcr *TCREngine) waitForChange(interrupt <-chan bool) bool {
 
 	return tcr.sourceTree.Watch(
 		existingDirs,
-		tcr.language.IsLanguageFile,
+		tcr.isLanguageFile,
 		interrupt)
 }
 
 // RunTCRCycle is the core of TCR engine: e.g. it runs one test && commit || revert cycle
 func (tcr *TCREngine) RunTCRCycle() {
 	status.RecordState(status.Ok)
-	if tcr.build().Failed() {
+	diffs := tcr.changedFiles()
+	pipelines := tcr.affectedPipelines(diffs)
+	buildResult := tcr.buildAll(pipelines, diffs)
+	if buildResult.Failed() {
+		return
+	}
+	result := tcr.testAll(pipelines, diffs)
+	if result.InfraError() {
 		return
 	}
-	result := tcr.test()
 	event := tcr.createTCREvent(result)
+	event.Steps = asStepResults(append(buildResult.Steps, result.Steps...))
 	if result.Passed() {
+		event.SlowTests = tcr.runSlowTests()
 		tcr.commit(event)
 	} else {
 		tcr.revert(event)
 	}
 }
 
+// changedFiles returns the files changed since last commit
+func (tcr *TCREngine) changedFiles() vcs.FileDiffs {
+	diffs, err := tcr.vcs.Diff()
+	if err != nil {
+		report.PostWarning(err)
+		return nil
+	}
+	return diffs
+}
+
 func (tcr *TCREngine) createTCREvent(testResult toolchain.TestCommandResult) (event events.TCREvent) {
 	diffs, err := tcr.vcs.Diff()
 	if err != nil {
@@ -468,11 +530,11 @@ func (tcr *TCREngine) createTCREvent(testResult toolchain.TestCommandResult) (ev
 	if testResult.Passed() {
 		commandStatus = events.StatusPass
 	}
-	return events.NewTCREvent(
+	event = events.NewTCREvent(
 		commandStatus,
 		events.NewChangedLines(
-			diffs.ChangedLines(tcr.language.IsSrcFile),
-			diffs.ChangedLines(tcr.language.IsTestFile),
+			diffs.ChangedLines(tcr.isSrcFile),
+			diffs.ChangedLines(tcr.isTestFile),
 		),
 		events.NewTestStats(
 			testResult.Stats.TotalRun,
@@ -482,23 +544,57 @@ func (tcr *TCREngine) createTCREvent(testResult toolchain.TestCommandResult) (ev
 			testResult.Stats.WithErrors,
 			testResult.Stats.Duration,
 		),
+		asLineCoverage(testResult.Coverage),
 	)
+	return event
+}
+
+func asStepResults(steps []toolchain.StepResult) (out []events.StepResult) {
+	for _, step := range steps {
+		status := events.StatusFail
+		if step.Status == toolchain.CommandStatusPass {
+			status = events.StatusPass
+		}
+		out = append(out, events.NewStepResult(step.Name, status, step.Duration))
+	}
+	return out
 }
 
-func (tcr *TCREngine) build() (result toolchain.CommandResult) {
-	report.PostInfo("Launching Build")
-	result = tcr.toolchain.RunBuild()
-	if result.Failed() {
+// reportSteps reports the result and duration of each step, when the toolchain has additional steps
+func reportSteps(steps []toolchain.StepResult) {
+	for _, step := range steps {
+		report.PostInfo("Step ", step.Name, ": ", step.Status, " (", step.Duration.Round(time.Millisecond), ")")
+	}
+}
+
+func asLineCoverage(coverageReport *coverage.Report) events.LineCoverage {
+	if coverageReport == nil {
+		return events.NewLineCoverage(0, 0)
+	}
+	stats := coverageReport.LineStats()
+	return events.NewLineCoverage(stats.Covered, stats.Total)
+}
+
+func (tcr *TCREngine) build(p pipeline) (result toolchain.CommandResult) {
+	report.PostInfo("Launching Build", p.describe())
+	result = p.toolchain.RunBuild()
+	reportSteps(result.Steps)
+	if result.InfraError() {
+		tcr.reportInfraError()
+	} else if result.Failed() {
 		status.RecordState(status.BuildFailed)
 		report.PostWarningWithEmphasis(buildFailureMessage)
 	}
 	return result
 }
 
-func (tcr *TCREngine) test() (result toolchain.TestCommandResult) {
-	report.PostInfo("Running Tests")
-	result = tcr.toolchain.RunTests()
-	if result.Failed() {
+func (tcr *TCREngine) test(p pipeline) (result toolchain.TestCommandResult) {
+	report.PostInfo("Running Tests", p.describe())
+	result = applyQuarantine(tcr.runTests(p))
+	reportSteps(result.Steps)
+	if result.InfraError() {
+		tcr.reportInfraError()
+	} else if result.Failed() {
 		status.RecordState(status.TestFailed)
 		report.PostErrorWithEmphasis(testFailureMessage)
 	} else {
@@ -507,6 +603,78 @@ func (tcr *TCREngine) test() (result toolchain.TestCommandResult) {
 	return result
 }
 
+// reportInfraError loudly reports a build or test infrastructure error
+func (tcr *TCREngine) reportInfraError() {
+	status.RecordState(status.InfraError)
+	report.PostErrorWithEmphasis(infraErrorMessage)
+	tcr.ui.NotifyAlert(infraErrorMessage)
+}
+
+// applyQuarantine ignores failures coming from quarantined tests. Quarantined test failures
+// are still reported. When all failing tests are quarantined, tests are considered as passing
+func applyQuarantine(result toolchain.TestCommandResult) toolchain.TestCommandResult {
+	ignored, remaining := quarantine.Split(result.Stats.FailedTests)
+	for _, testID := range ignored {
+		report.PostWarning("Ignoring failure of quarantined test ", testID)
+	}
+	passing, _ := quarantine.Split(result.Stats.PassedTests)
+	for _, testID := range passing {
+		report.PostWarning("Quarantined test ", testID, " is now passing. It can be removed from quarantine")
+	}
+	if result.Status == toolchain.CommandStatusFail && len(ignored) > 0 && len(remaining) == 0 {
+		result.Status = toolchain.CommandStatusPass
+	}
+	return result
+}
+
+// checkCoverage reports code coverage when available, and enforces the toolchain's coverage
+// threshold on lines changed in source files. Passing tests with a coverage of changed lines
+// below the threshold, or with no coverage data for changed source files, are considered as failing
+func (tcr *TCREngine) checkCoverage(p pipeline, result toolchain.TestCommandResult) toolchain.TestCommandResult {
+	if result.Coverage == nil {
+		return result
+	}
+	stats := result.Coverage.LineStats()
+	report.PostInfo("Line coverage: ", stats.Percentage(), "% (", stats.Covered, "/", stats.Total, " lines)")
+
+	settings := p.toolchain.GetCoverageReport()
+	if result.Failed() || settings == nil || settings.Threshold == 0 {
+		return result
+	}
+	diffs, err := tcr.vcs.Diff()
+	if err != nil {
+		report.PostWarning(err)
+		return result
+	}
+	var changed coverage.LineStats
+	changedSrcFiles, reportedSrcFiles := 0, 0
+	for _, diff := range diffs {
+		if !p.language.IsSrcFile(diff.Path) {
+			continue
+		}
+		changedSrcFiles++
+		if stats, found := result.Coverage.LineStatsForLines(diff.Path, diff.IsChangedLine); found {
+			reportedSrcFiles++
+			changed = changed.Add(stats)
+		}
+	}
+	switch {
+	case changedSrcFiles == 0:
+		// no source file was changed: nothing to enforce
+	case reportedSrcFiles == 0:
+		status.RecordState(status.TestFailed)
+		report.PostErrorWithEmphasis(coverageUnknownMessage)
+		result.Status = toolchain.CommandStatusFail
+	case changed.Percentage() < settings.Threshold:
+		status.RecordState(status.TestFailed)
+		report.PostErrorWithEmphasis(fmt.Sprintf(coverageFailureMessage, changed.Percentage(), settings.Threshold))
+		result.Status = toolchain.CommandStatusFail
+	default:
+		report.PostInfo("Line coverage of changed lines: ", changed.Percentage(), "%")
+	}
+	return result
+}
+
 func (tcr *TCREngine) commit(event events.TCREvent) {
 	report.PostInfo("Committing changes on ", tcr.vcs.SessionSummary())
 	var err error
@@ -578,7 +746,7 @@ func (tcr *TCREngine) revertSrcFiles() {
 	}
 	var reverted int
 	for _, diff := range diffs {
-		if tcr.language.IsSrcFile(diff.Path) {
+		if tcr.isSrcFile(diff.Path) {
 			err := tcr.revertFile(diff.Path)
 			tcr.handleError(err, false, status.VCSError)
 			if err == nil {
@@ -605,6 +773,7 @@ func (tcr *TCREngine) GetSessionInfo() SessionInfo {
 		WorkDir:           toolchain.GetWorkDir(),
 		LanguageName:      tcr.language.GetName(),
 		ToolchainName:     tcr.toolchain.GetName(),
+		Modules:           tcr.moduleNames(),
 		VCSName:           tcr.vcs.Name(),
 		VCSSessionSummary: tcr.vcs.SessionSummary(),
 		GitAutoPush:       tcr.vcs.IsPushEnabled(),
@@ -613,6 +782,15 @@ func (tcr *TCREngine) GetSessionInfo() SessionInfo {
 	}
 }
 
+// moduleNames returns the specifications of the modules of a polyglot project.
+// Returns nil when no module is configured
+func (tcr *TCREngine) moduleNames() (names []string) {
+	for _, p := range tcr.modules {
+		names = append(names, p.module)
+	}
+	return names
+}
+
 func (tcr *TCREngine) initTimer() {
 	if settings.EnableMobTimer {
 		tcr.mobTimer = timer.NewMobTurnCountdown(tcr.mode, tcr.mobTurnDuration)
@@ -682,14 +860,14 @@ func (tcr *TCREngine) VCSPush() {
 }
 
 // reportFileStats traces summary information about the source and test files and directories
-func (tcr *TCREngine) reportFileStats() {
-	srcFileCount := countFiles("source", tcr.language.AllSrcFiles)
-	testFileCount := countFiles("test", tcr.language.AllTestFiles)
+func reportFileStats(lang language.LangInterface) {
+	srcFileCount := countFiles("source", lang.AllSrcFiles)
+	testFileCount := countFiles("test", lang.AllTestFiles)
 	if srcFileCount+testFileCount == 0 {
-		report.PostWarning("No matching ", tcr.language.GetName(), " file found")
+		report.PostWarning("No matching ", lang.GetName(), " file found")
 	} else {
 		report.PostInfo("Found ", srcFileCount, " source and ",
-			testFileCount, " test file(s) for ", tcr.language.GetName(), " language")
+			testFileCount, " test file(s) for ", lang.GetName(), " language")
 	}
 }
 
@@ -24,9 +24,11 @@ package engine
 
 import (
 	"fmt"
+	"github.com/murex/tcr/coverage"
 	"github.com/murex/tcr/events"
 	"github.com/murex/tcr/language"
 	"github.com/murex/tcr/params"
+	"github.com/murex/tcr/quarantine"
 	"github.com/murex/tcr/report"
 	"github.com/murex/tcr/role"
 	"github.com/murex/tcr/runmode"
@@ -55,7 +57,7 @@ func Test_tcr_command_end_state(t *testing.T) {
 			"build with no failure",
 			func() toolchain.CommandResult {
 				tcr, _ := initTCREngineWithFakes(nil, nil, nil, nil)
-				return tcr.build()
+				return tcr.build(tcr.pipelines()[0])
 			},
 			toolchain.CommandStatusPass, status.Ok,
 		},
@@ -63,7 +65,7 @@ func Test_tcr_command_end_state(t *testing.T) {
 			"build with failure",
 			func() toolchain.CommandResult {
 				tcr, _ := initTCREngineWithFakes(nil, toolchain.Operations{toolchain.BuildOperation}, nil, nil)
-				return tcr.build()
+				return tcr.build(tcr.pipelines()[0])
 			},
 			toolchain.CommandStatusFail, status.BuildFailed,
 		},
@@ -71,7 +73,7 @@ func Test_tcr_command_end_state(t *testing.T) {
 			"test with no failure",
 			func() toolchain.CommandResult {
 				tcr, _ := initTCREngineWithFakes(nil, nil, nil, nil)
-				result := tcr.test()
+				result := tcr.test(tcr.pipelines()[0])
 				return result.CommandResult
 			},
 			toolchain.CommandStatusPass, status.Ok,
@@ -80,7 +82,7 @@ func Test_tcr_command_end_state(t *testing.T) {
 			"test with failure",
 			func() toolchain.CommandResult {
 				tcr, _ := initTCREngineWithFakes(nil, toolchain.Operations{toolchain.TestOperation}, nil, nil)
-				result := tcr.test()
+				result := tcr.test(tcr.pipelines()[0])
 				return result.CommandResult
 			},
 			toolchain.CommandStatusFail, status.TestFailed,
@@ -153,8 +155,8 @@ func Test_tcr_reports_and_emphasises(t *testing.T) {
 			sniffer := report.NewSniffer(tt.isExpectedMessage)
 
 			tcr, _ := initTCREngineWithFakes(nil, toolchain.Operations{tt.failAt}, nil, nil)
-			tcr.build()
-			tcr.test()
+			tcr.build(tcr.pipelines()[0])
+			tcr.test(tcr.pipelines()[0])
 			sniffer.Stop()
 
 			assert.Equal(t, 1, sniffer.GetMatchCount())
@@ -336,6 +338,20 @@ func Test_tcr_cycle_end_state(t *testing.T) {
 	}
 }
 
+func Test_tcr_cycle_with_infra_error(t *testing.T) {
+	for _, operation := range []toolchain.Operation{toolchain.BuildOperation, toolchain.TestOperation} {
+		t.Run(string(operation), func(t *testing.T) {
+			status.RecordState(status.Ok)
+			tcr, vcsFake := initTCREngineWithFakes(nil, nil, nil, nil)
+			tcr.toolchain = toolchain.NewFakeToolchain(nil, toolchain.TestStats{}).WithInfraErrors(operation)
+			tcr.RunTCRCycle()
+			assert.Equal(t, status.InfraError, status.GetCurrentState())
+			assert.NotContains(t, []fake.Command{fake.CommitCommand, fake.RestoreCommand, fake.RevertCommand},
+				vcsFake.GetLastCommand())
+		})
+	}
+}
+
 func initTCREngineWithFakes(
 	p *params.Params,
 	toolchainFailures toolchain.Operations,
@@ -677,6 +693,7 @@ func Test_parse_commit_message(t *testing.T) {
 				events.StatusPass,
 				events.NewChangedLines(2, 7),
 				events.NewTestStats(1, 1, 0, 1, 0, 2*time.Millisecond),
+				events.NewLineCoverage(0, 0),
 			),
 		},
 		{
@@ -698,6 +715,7 @@ func Test_parse_commit_message(t *testing.T) {
 				events.StatusFail,
 				events.NewChangedLines(1, 3),
 				events.NewTestStats(10, 8, 2, 1, 0, 40*time.Millisecond),
+				events.NewLineCoverage(0, 0),
 			),
 		},
 		{
@@ -720,6 +738,7 @@ func Test_parse_commit_message(t *testing.T) {
 				events.StatusPass,
 				events.NewChangedLines(1, 2),
 				events.NewTestStats(3, 4, 5, 6, 7, 8*time.Millisecond),
+				events.NewLineCoverage(0, 0),
 			),
 		},
 	}
@@ -876,3 +895,214 @@ func Test_count_files(t *testing.T) {
 		})
 	}
 }
+
+func Test_apply_quarantine_on_test_results(t *testing.T) {
+	tests := []struct {
+		desc             string
+		status           toolchain.CommandStatus
+		failedTests      []string
+		passedTests      []string
+		expectedStatus   toolchain.CommandStatus
+		expectedWarnings int
+	}{
+		{
+			"passing tests with no quarantined test",
+			toolchain.CommandStatusPass, nil, []string{"test-a"},
+			toolchain.CommandStatusPass, 0,
+		},
+		{
+			"failing tests with no quarantined test",
+			toolchain.CommandStatusFail, []string{"test-a"}, nil,
+			toolchain.CommandStatusFail, 0,
+		},
+		{
+			"failing tests with all failures quarantined",
+			toolchain.CommandStatusFail, []string{"quarantined-1", "quarantined-2"}, []string{"test-a"},
+			toolchain.CommandStatusPass, 2,
+		},
+		{
+			"failing tests with some failures quarantined",
+			toolchain.CommandStatusFail, []string{"quarantined-1", "test-a"}, nil,
+			toolchain.CommandStatusFail, 1,
+		},
+		{
+			"failing tests with no test report",
+			toolchain.CommandStatusFail, nil, nil,
+			toolchain.CommandStatusFail, 0,
+		},
+		{
+			"quarantined tests now passing",
+			toolchain.CommandStatusPass, nil, []string{"quarantined-1", "test-a"},
+			toolchain.CommandStatusPass, 1,
+		},
+	}
+
+	quarantine.Add("quarantined-1", "quarantined-2")
+	defer func() {
+		_ = quarantine.Remove("quarantined-1", "quarantined-2")
+	}()
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			sniffer := report.NewSniffer(func(msg report.Message) bool {
+				return msg.Type.Severity == report.Warning
+			})
+			result := toolchain.TestCommandResult{
+				CommandResult: toolchain.CommandResult{Status: test.status},
+				Stats:         toolchain.TestStats{FailedTests: test.failedTests, PassedTests: test.passedTests},
+			}
+			result = applyQuarantine(result)
+			sniffer.Stop()
+
+			assert.Equal(t, test.expectedStatus, result.Status)
+			assert.Equal(t, test.expectedWarnings, sniffer.GetMatchCount())
+		})
+	}
+}
+
+func Test_check_coverage_on_test_results(t *testing.T) {
+	tests := []struct {
+		desc           string
+		status         toolchain.CommandStatus
+		settings       *toolchain.CoverageReport
+		covered        int
+		expectedStatus toolchain.CommandStatus
+	}{
+		{
+			"no coverage settings",
+			toolchain.CommandStatusPass, nil, 0,
+			toolchain.CommandStatusPass,
+		},
+		{
+			"coverage threshold not set",
+			toolchain.CommandStatusPass,
+			&toolchain.CoverageReport{Format: coverage.FormatLCOV, Path: "lcov.info"}, 0,
+			toolchain.CommandStatusPass,
+		},
+		{
+			"coverage above threshold",
+			toolchain.CommandStatusPass,
+			&toolchain.CoverageReport{Format: coverage.FormatLCOV, Path: "lcov.info", Threshold: 50}, 3,
+			toolchain.CommandStatusPass,
+		},
+		{
+			"coverage equal to threshold",
+			toolchain.CommandStatusPass,
+			&toolchain.CoverageReport{Format: coverage.FormatLCOV, Path: "lcov.info", Threshold: 50}, 2,
+			toolchain.CommandStatusPass,
+		},
+		{
+			"coverage below threshold",
+			toolchain.CommandStatusPass,
+			&toolchain.CoverageReport{Format: coverage.FormatLCOV, Path: "lcov.info", Threshold: 50}, 1,
+			toolchain.CommandStatusFail,
+		},
+		{
+			"failing tests",
+			toolchain.CommandStatusFail,
+			&toolchain.CoverageReport{Format: coverage.FormatLCOV, Path: "lcov.info", Threshold: 50}, 4,
+			toolchain.CommandStatusFail,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			// fake VCS reports changes on "fake-src" file, which is
+			// considered as a source file by the fake language
+			coverageData := coverage.NewReport()
+			for line := 1; line <= 4; line++ {
+				hits := 0
+				if line <= test.covered {
+					hits = 1
+				}
+				coverageData.AddLine("fake-src", line, hits)
+			}
+			coverageData.AddLine("other-src", 1, 0)
+
+			tcr, _ := initTCREngineWithFakes(nil, nil, nil, nil)
+			tcr.toolchain = toolchain.NewFakeToolchain(nil, toolchain.TestStats{}).
+				WithCoverage(test.settings, coverageData)
+			result := toolchain.TestCommandResult{
+				CommandResult: toolchain.CommandResult{Status: test.status},
+				Coverage:      coverageData,
+			}
+			assert.Equal(t, test.expectedStatus, tcr.checkCoverage(tcr.pipelines()[0], result).Status)
+		})
+	}
+}
+
+func Test_check_coverage_on_changed_lines(t *testing.T) {
+	// lines 1 and 2 of "fake-src" are covered, lines 3 and 4 are not
+	coverageData := coverage.NewReport()
+	for line := 1; line <= 4; line++ {
+		hits := 0
+		if line <= 2 {
+			hits = 1
+		}
+		coverageData.AddLine("fake-src", line, hits)
+	}
+	settings := &toolchain.CoverageReport{Format: coverage.FormatLCOV, Path: "lcov.info", Threshold: 100}
+	tests := []struct {
+		desc           string
+		changedFiles   vcs.FileDiffs
+		expectedStatus toolchain.CommandStatus
+	}{
+		{
+			"changed lines covered",
+			vcs.FileDiffs{vcs.NewFileDiff("fake-src", 2, 0).WithChangedRanges(vcs.LineRange{Start: 1, End: 2})},
+			toolchain.CommandStatusPass,
+		},
+		{
+			"changed lines not covered",
+			vcs.FileDiffs{vcs.NewFileDiff("fake-src", 2, 0).WithChangedRanges(vcs.LineRange{Start: 2, End: 3})},
+			toolchain.CommandStatusFail,
+		},
+		{
+			"removed lines only",
+			vcs.FileDiffs{vcs.NewFileDiff("fake-src", 0, 2).WithChangedRanges()},
+			toolchain.CommandStatusPass,
+		},
+		{
+			"no coverage data for changed source files",
+			vcs.FileDiffs{vcs.NewFileDiff("other-src", 2, 0).WithChangedRanges(vcs.LineRange{Start: 1, End: 2})},
+			toolchain.CommandStatusFail,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			tcr, _ := initTCREngineWithFakes(nil, nil, nil, nil)
+			tcr.setVCS(fake.NewVCSFake(fake.Settings{ChangedFiles: test.changedFiles}))
+			tcr.toolchain = toolchain.NewFakeToolchain(nil, toolchain.TestStats{}).
+				WithCoverage(settings, coverageData)
+			result := toolchain.TestCommandResult{
+				CommandResult: toolchain.CommandResult{Status: toolchain.CommandStatusPass},
+				Coverage:      coverageData,
+			}
+			assert.Equal(t, test.expectedStatus, tcr.checkCoverage(tcr.pipelines()[0], result).Status)
+		})
+	}
+}
+
+func Test_tcr_event_contains_line_coverage(t *testing.T) {
+	coverageData := coverage.NewReport()
+	coverageData.AddLine("fake-src", 1, 1)
+	coverageData.AddLine("fake-src", 2, 0)
+	tcr, _ := initTCREngineWithFakes(nil, nil, nil, nil)
+	event := tcr.createTCREvent(toolchain.TestCommandResult{
+		CommandResult: toolchain.CommandResult{Status: toolchain.CommandStatusPass},
+		Coverage:      coverageData,
+	})
+	assert.Equal(t, events.NewLineCoverage(1, 2), event.Coverage)
+}
+
+func Test_convert_step_results_for_tcr_event(t *testing.T) {
+	steps := []toolchain.StepResult{
+		{Name: "lint", Status: toolchain.CommandStatusFail, Duration: time.Second},
+		{Name: "build", Status: toolchain.CommandStatusPass, Duration: 2 * time.Second, FailsCycle: true},
+	}
+	assert.Equal(t, []events.StepResult{
+		events.NewStepResult("lint", events.StatusFail, time.Second),
+		events.NewStepResult("build", events.StatusPass, 2*time.Second),
+	}, asStepResults(steps))
+	assert.Nil(t, asStepResults(nil))
+}
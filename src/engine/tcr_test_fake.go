@@ -49,6 +49,7 @@ const (
 	TCRCallPrintStats           TCRCall = "print-stats"
 	TCRCallVCSPull              TCRCall = "vcs-pull"
 	TCRCallVCSPush              TCRCall = "vcs-push"
+	TCRCallRequestFullTestRun   TCRCall = "request-full-test-run"
 )
 
 var NoTCRCall []TCRCall
@@ -137,6 +138,11 @@ func (fake *FakeTCREngine) RunTCRCycle() {
 	fake.recordCall(TCRCallRunTcrCycle)
 }
 
+// RequestFullTestRun tells TCR engine to run all tests on next TCR cycle
+func (fake *FakeTCREngine) RequestFullTestRun() {
+	fake.recordCall(TCRCallRequestFullTestRun)
+}
+
 // RunCheck checks the provided parameters and prints out corresponding report
 func (fake *FakeTCREngine) RunCheck(_ params.Params) {
 	fake.recordCall(TCRCallRunCheck)
@@ -0,0 +1,118 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package engine
+
+import (
+	"github.com/murex/tcr/report"
+	"github.com/murex/tcr/selection"
+	"github.com/murex/tcr/toolchain"
+	"sync"
+)
+
+// testSelectionState keeps track of test runs when the toolchain runs only affected tests.
+// - cycles is the number of test runs since TCR engine started.
+// - fullRunRequested indicates that the user asked for running all tests on next cycle.
+type testSelectionState struct {
+	mutex            sync.Mutex
+	cycles           int
+	fullRunRequested bool
+}
+
+// RequestFullTestRun tells TCR engine to run all tests on next TCR cycle,
+// even when the toolchain is configured to run only affected tests
+func (tcr *TCREngine) RequestFullTestRun() {
+	requested := false
+	for _, p := range tcr.pipelines() {
+		if p.toolchain.GetTestSelection() != nil {
+			p.testSelection.requestFullRun()
+			requested = true
+		}
+	}
+	if !requested {
+		report.PostInfo("Test selection is not configured: all tests are run on every cycle")
+		return
+	}
+	report.PostInfo("All tests will run on next cycle")
+}
+
+func (s *testSelectionState) requestFullRun() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.fullRunRequested = true
+}
+
+// runTests runs either all tests or only the tests affected by changes, depending on
+// the pipeline's toolchain test selection settings
+func (tcr *TCREngine) runTests(p pipeline) toolchain.TestCommandResult {
+	targets := tcr.selectTests(p)
+	if len(targets) == 0 {
+		return p.toolchain.RunTests()
+	}
+	report.PostInfo("Running ", len(targets), " test target(s) affected by changes")
+	return p.toolchain.RunSelectedTests(targets)
+}
+
+// selectTests returns the test targets affected by changes.
+// Returns nil when all tests should be run
+func (tcr *TCREngine) selectTests(p pipeline) []string {
+	settings := p.toolchain.GetTestSelection()
+	if settings == nil || p.testSelection.isFullRunDue(settings.FullRunEvery) {
+		return nil
+	}
+	diffs, err := tcr.vcs.Diff()
+	if err != nil {
+		report.PostWarning("Cannot retrieve changes (", err, "). Running all tests")
+		return nil
+	}
+	var changedFiles []string
+	for _, diff := range diffs {
+		changedFiles = append(changedFiles, diff.Path)
+	}
+	targets, err := selection.AffectedTests(settings, p.language, toolchain.GetWorkDir(), changedFiles)
+	if err != nil {
+		report.PostWarning("Cannot select affected tests (", err, "). Running all tests")
+		return nil
+	}
+	if len(targets) == 0 {
+		report.PostInfo("No test target could be mapped to changes. Running all tests")
+	}
+	return targets
+}
+
+// isFullRunDue indicates if all tests should run on this cycle, either because the user asked for it,
+// or because this is the periodic full run
+func (s *testSelectionState) isFullRunDue(fullRunEvery int) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.cycles++
+	if s.fullRunRequested {
+		s.fullRunRequested = false
+		report.PostInfo("Running all tests (as requested)")
+		return true
+	}
+	if fullRunEvery > 0 && s.cycles%fullRunEvery == 0 {
+		report.PostInfo("Running all tests (periodic full run)")
+		return true
+	}
+	return false
+}
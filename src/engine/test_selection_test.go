@@ -0,0 +1,75 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package engine
+
+import (
+	"github.com/murex/tcr/toolchain"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func initTCREngineWithTestSelection(fullRunEvery int) (*TCREngine, *toolchain.FakeToolchain) {
+	tcr, _ := initTCREngineWithFakes(nil, nil, nil, nil)
+	tchn := toolchain.NewFakeToolchain(nil, toolchain.TestStats{})
+	if fullRunEvery >= 0 {
+		tchn.WithTestSelection(toolchain.NewTestSelection(toolchain.NamingMapping, "", fullRunEvery, nil))
+	}
+	tcr.toolchain = tchn
+	// fake VCS reports changes on "fake-src" file, which
+	// is considered as a test file by the fake language
+	return tcr, tchn
+}
+
+func Test_run_all_tests_when_test_selection_is_not_configured(t *testing.T) {
+	tcr, tchn := initTCREngineWithTestSelection(-1)
+	tcr.runTests(tcr.pipelines()[0])
+	assert.Nil(t, tchn.GetSelectedTargets())
+}
+
+func Test_run_only_affected_tests_when_test_selection_is_configured(t *testing.T) {
+	tcr, tchn := initTCREngineWithTestSelection(0)
+	tcr.runTests(tcr.pipelines()[0])
+	assert.Equal(t, []string{"fake-src"}, tchn.GetSelectedTargets())
+}
+
+func Test_run_all_tests_periodically_when_test_selection_is_configured(t *testing.T) {
+	tcr, _ := initTCREngineWithTestSelection(3)
+	var fullRuns []bool
+	for i := 0; i < 6; i++ {
+		fullRuns = append(fullRuns, tcr.selectTests(tcr.pipelines()[0]) == nil)
+	}
+	assert.Equal(t, []bool{false, false, true, false, false, true}, fullRuns)
+}
+
+func Test_run_all_tests_on_request_when_test_selection_is_configured(t *testing.T) {
+	tcr, _ := initTCREngineWithTestSelection(0)
+	tcr.RequestFullTestRun()
+	assert.Nil(t, tcr.selectTests(tcr.pipelines()[0]))
+	assert.Equal(t, []string{"fake-src"}, tcr.selectTests(tcr.pipelines()[0]))
+}
+
+func Test_request_full_test_run_when_test_selection_is_not_configured(t *testing.T) {
+	tcr, _ := initTCREngineWithTestSelection(-1)
+	tcr.RequestFullTestRun()
+	assert.False(t, tcr.testSelection.fullRunRequested)
+}
@@ -46,11 +46,32 @@ type (
 		Duration time.Duration
 	}
 
-	// TCREvent is the structure containing information related to a TCR event
+	// LineCoverage is the structure containing info related to code coverage.
+	// Total is 0 when no code coverage data is available
+	LineCoverage struct {
+		Covered int
+		Total   int
+	}
+
+	// StepResult is the structure containing info related to a build or test step execution
+	StepResult struct {
+		Name     string
+		Status   CommandStatus
+		Duration time.Duration
+	}
+
+	// TCREvent is the structure containing information related to a TCR event.
+	// SlowTests is the status of the slow tests run on the changes of this TCR event.
+	// It is empty when slow tests were not run.
+	// Steps is the list of build and test step results. It is empty when the toolchain
+	// has no additional build or test step
 	TCREvent struct {
-		Status  CommandStatus
-		Changes ChangedLines
-		Tests   TestStats
+		Status    CommandStatus
+		Changes   ChangedLines
+		Tests     TestStats
+		Coverage  LineCoverage
+		SlowTests CommandStatus
+		Steps     []StepResult
 	}
 )
 
@@ -62,11 +83,12 @@ const (
 )
 
 // NewTCREvent creates a new TCREvent instance
-func NewTCREvent(status CommandStatus, changes ChangedLines, stats TestStats) TCREvent {
+func NewTCREvent(status CommandStatus, changes ChangedLines, stats TestStats, coverage LineCoverage) TCREvent {
 	return TCREvent{
-		Status:  status,
-		Changes: changes,
-		Tests:   stats,
+		Status:   status,
+		Changes:  changes,
+		Tests:    stats,
+		Coverage: coverage,
 	}
 }
 
@@ -82,6 +104,15 @@ func NewTestStats(run int, passed int, failed int, skipped int, errors int, dura
 	}
 }
 
+// NewStepResult creates a new StepResult instance
+func NewStepResult(name string, status CommandStatus, duration time.Duration) StepResult {
+	return StepResult{
+		Name:     name,
+		Status:   status,
+		Duration: duration,
+	}
+}
+
 // NewChangedLines creates a new ChangedLines instance
 func NewChangedLines(srcLines, testLines int) ChangedLines {
 	return ChangedLines{
@@ -90,6 +121,20 @@ func NewChangedLines(srcLines, testLines int) ChangedLines {
 	}
 }
 
+// NewLineCoverage creates a new LineCoverage instance
+func NewLineCoverage(covered, total int) LineCoverage {
+	return LineCoverage{
+		Covered: covered,
+		Total:   total,
+	}
+}
+
+// Percentage returns the percentage (rounded) of covered lines.
+// Returns 0 when no code coverage data is available
+func (c LineCoverage) Percentage() int {
+	return asPercentage(c.Covered, c.Total)
+}
+
 // ToYAML converts a TCREvent to a YAML string
 func (event TCREvent) ToYAML() string {
 	return tcrEventToYAML(event)
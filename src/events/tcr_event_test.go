@@ -54,3 +54,9 @@ func Test_yaml_conversion_on_sample_tcr_event(t *testing.T) {
 	assert.Equal(t, expected, yaml)
 	assert.Equal(t, *event, FromYAML(yaml))
 }
+
+func Test_line_coverage_percentage(t *testing.T) {
+	assert.Equal(t, 0, NewLineCoverage(0, 0).Percentage())
+	assert.Equal(t, 33, NewLineCoverage(1, 3).Percentage())
+	assert.Equal(t, 100, NewLineCoverage(5, 5).Percentage())
+}
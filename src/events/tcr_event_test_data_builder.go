@@ -32,6 +32,7 @@ func ATcrEvent(builders ...func(tcrEvent *TCREvent)) *TCREvent {
 		StatusUnknown,
 		NewChangedLines(0, 0),
 		NewTestStats(0, 0, 0, 0, 0, 0),
+		NewLineCoverage(0, 0),
 	)
 
 	for _, build := range builders {
@@ -102,3 +103,24 @@ func WithTestsDuration(duration time.Duration) func(filter *TCREvent) {
 		tcrEvent.Tests.Duration = duration
 	}
 }
+
+// WithLineCoverage sets the number of covered and instrumented lines to TCR event test data builder
+func WithLineCoverage(covered, total int) func(filter *TCREvent) {
+	return func(tcrEvent *TCREvent) {
+		tcrEvent.Coverage = NewLineCoverage(covered, total)
+	}
+}
+
+// WithSlowTestsStatus sets the status of slow test runs to TCR event test data builder
+func WithSlowTestsStatus(status CommandStatus) func(filter *TCREvent) {
+	return func(tcrEvent *TCREvent) {
+		tcrEvent.SlowTests = status
+	}
+}
+
+// WithSteps sets the build and test step results to TCR event test data builder
+func WithSteps(steps ...StepResult) func(filter *TCREvent) {
+	return func(tcrEvent *TCREvent) {
+		tcrEvent.Steps = steps
+	}
+}
@@ -274,6 +274,24 @@ func (events *TcrEvents) TestDurationEvolution() DurationValueEvolution {
 	}
 }
 
+// LineCoverageEvolution returns the evolution of the line coverage percentage
+// from the first to the last TCR event containing code coverage data
+func (events *TcrEvents) LineCoverageEvolution() IntValueEvolution {
+	var withCoverage []DatedTcrEvent
+	for _, e := range *events {
+		if e.Event.Coverage.Total > 0 {
+			withCoverage = append(withCoverage, e)
+		}
+	}
+	if len(withCoverage) == 0 {
+		return IntValueEvolution{from: 0, to: 0}
+	}
+	return IntValueEvolution{
+		from: withCoverage[0].Event.Coverage.Percentage(),
+		to:   withCoverage[len(withCoverage)-1].Event.Coverage.Percentage(),
+	}
+}
+
 // PassingRecords provides the total number of passing records and their percentage
 // vs the total number of records
 func (events *TcrEvents) PassingRecords() IntValueAndRatio {
@@ -286,6 +304,25 @@ func (events *TcrEvents) FailingRecords() IntValueAndRatio {
 	return events.recordsWithState(StatusFail)
 }
 
+// SlowTestFailures provides the number of records reporting slow test failures and their
+// percentage vs the number of records containing slow test results
+func (events *TcrEvents) SlowTestFailures() IntValueAndRatio {
+	var withSlowTests, failures int
+	for _, e := range *events {
+		switch e.Event.SlowTests {
+		case StatusFail:
+			failures++
+			withSlowTests++
+		case StatusPass:
+			withSlowTests++
+		}
+	}
+	return IntValueAndRatio{
+		value:      failures,
+		percentage: asPercentage(failures, withSlowTests),
+	}
+}
+
 func (events *TcrEvents) recordsWithState(status CommandStatus) IntValueAndRatio {
 	if len(*events) == 0 {
 		return IntValueAndRatio{0, 0}
@@ -620,3 +620,84 @@ func Test_test_counters_evolution(t *testing.T) {
 		})
 	}
 }
+
+func Test_line_coverage_evolution(t *testing.T) {
+	testFlags := []struct {
+		desc     string
+		events   TcrEvents
+		expected IntValueEvolution
+	}{
+		{
+			"no record",
+			*NewTcrEvents(),
+			IntValueEvolution{0, 0},
+		},
+		{
+			"records without coverage data",
+			TcrEvents{
+				*ADatedTcrEvent(WithTcrEvent(*ATcrEvent())),
+				*ADatedTcrEvent(WithTcrEvent(*ATcrEvent())),
+			},
+			IntValueEvolution{0, 0},
+		},
+		{
+			"1 record",
+			TcrEvents{
+				*ADatedTcrEvent(WithTcrEvent(*ATcrEvent(WithLineCoverage(1, 4)))),
+			},
+			IntValueEvolution{25, 25},
+		},
+		{
+			"records with and without coverage data",
+			TcrEvents{
+				*ADatedTcrEvent(WithTcrEvent(*ATcrEvent())),
+				*ADatedTcrEvent(WithTcrEvent(*ATcrEvent(WithLineCoverage(1, 2)))),
+				*ADatedTcrEvent(WithTcrEvent(*ATcrEvent(WithLineCoverage(2, 3)))),
+				*ADatedTcrEvent(WithTcrEvent(*ATcrEvent())),
+			},
+			IntValueEvolution{50, 67},
+		},
+	}
+	for _, tt := range testFlags {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.events.LineCoverageEvolution())
+		})
+	}
+}
+
+func Test_slow_test_failures(t *testing.T) {
+	testFlags := []struct {
+		desc     string
+		events   TcrEvents
+		expected IntValueAndRatio
+	}{
+		{
+			"no record",
+			*NewTcrEvents(),
+			IntValueAndRatio{0, 0},
+		},
+		{
+			"records without slow test results",
+			TcrEvents{
+				*ADatedTcrEvent(WithTcrEvent(*ATcrEvent())),
+			},
+			IntValueAndRatio{0, 0},
+		},
+		{
+			"records with slow test results",
+			TcrEvents{
+				*ADatedTcrEvent(WithTcrEvent(*ATcrEvent(WithSlowTestsStatus(StatusPass)))),
+				*ADatedTcrEvent(WithTcrEvent(*ATcrEvent())),
+				*ADatedTcrEvent(WithTcrEvent(*ATcrEvent(WithSlowTestsStatus(StatusFail)))),
+				*ADatedTcrEvent(WithTcrEvent(*ATcrEvent(WithSlowTestsStatus(StatusPass)))),
+				*ADatedTcrEvent(WithTcrEvent(*ATcrEvent(WithSlowTestsStatus(StatusPass)))),
+			},
+			IntValueAndRatio{1, 25},
+		},
+	}
+	for _, tt := range testFlags {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.events.SlowTestFailures())
+		})
+	}
+}
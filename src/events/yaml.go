@@ -46,10 +46,26 @@ type (
 		Duration time.Duration `yaml:"duration"`
 	}
 
+	// LineCoverageYAML provides the YAML structure containing info related to code coverage
+	LineCoverageYAML struct {
+		Covered int `yaml:"covered"`
+		Total   int `yaml:"total"`
+	}
+
+	// StepResultYAML provides the YAML structure containing info related to a build or test step execution
+	StepResultYAML struct {
+		Name     string        `yaml:"name"`
+		Status   CommandStatus `yaml:"status"`
+		Duration time.Duration `yaml:"duration"`
+	}
+
 	// TCREventYAML provides the YAML structure containing information related to a TCR event
 	TCREventYAML struct {
-		Changes ChangedLinesYAML `yaml:"changed-lines"`
-		Tests   TestStatsYAML    `yaml:"test-stats"`
+		Changes   ChangedLinesYAML `yaml:"changed-lines"`
+		Tests     TestStatsYAML    `yaml:"test-stats"`
+		Coverage  LineCoverageYAML `yaml:"line-coverage,omitempty"`
+		SlowTests CommandStatus    `yaml:"slow-tests,omitempty"`
+		Steps     []StepResultYAML `yaml:"steps,omitempty"`
 	}
 )
 
@@ -63,13 +79,29 @@ func yamlToTCREvent(yaml string) TCREvent {
 
 func newTCREventYAML(event TCREvent) TCREventYAML {
 	return TCREventYAML{
-		Changes: ChangedLinesYAML(event.Changes),
-		Tests:   TestStatsYAML(event.Tests),
+		Changes:   ChangedLinesYAML(event.Changes),
+		Tests:     TestStatsYAML(event.Tests),
+		Coverage:  LineCoverageYAML(event.Coverage),
+		SlowTests: event.SlowTests,
+		Steps:     newStepResultsYAML(event.Steps),
 	}
 }
 
+func newStepResultsYAML(steps []StepResult) (out []StepResultYAML) {
+	for _, step := range steps {
+		out = append(out, StepResultYAML(step))
+	}
+	return out
+}
+
 func (event TCREventYAML) toTCREvent() TCREvent {
-	return NewTCREvent(StatusUnknown, ChangedLines(event.Changes), TestStats(event.Tests))
+	tcrEvent := NewTCREvent(StatusUnknown,
+		ChangedLines(event.Changes), TestStats(event.Tests), LineCoverage(event.Coverage))
+	tcrEvent.SlowTests = event.SlowTests
+	for _, step := range event.Steps {
+		tcrEvent.Steps = append(tcrEvent.Steps, StepResult(step))
+	}
+	return tcrEvent
 }
 
 func (event TCREventYAML) marshal() string {
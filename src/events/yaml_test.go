@@ -185,3 +185,38 @@ func buildYAMLSectionLine(section string) string {
 func buildYAMLKeyValueLine(key, value string) string {
 	return "    " + key + ": " + value + "\n"
 }
+
+func Test_convert_line_coverage_to_and_from_yaml(t *testing.T) {
+	event := *ATcrEvent(WithLineCoverage(3, 4))
+	yamlString := buildYAMLString("0", "0", "0", "0", "0", "0", "0", "0s") +
+		buildYAMLSectionLine("line-coverage") +
+		buildYAMLKeyValueLine("covered", "3") +
+		buildYAMLKeyValueLine("total", "4")
+	assert.Equal(t, yamlString, tcrEventToYAML(event))
+	assert.Equal(t, event, yamlToTCREvent(yamlString))
+}
+
+func Test_convert_slow_tests_status_to_and_from_yaml(t *testing.T) {
+	event := *ATcrEvent(WithSlowTestsStatus(StatusFail))
+	yamlString := buildYAMLString("0", "0", "0", "0", "0", "0", "0", "0s") +
+		"slow-tests: fail\n"
+	assert.Equal(t, yamlString, tcrEventToYAML(event))
+	assert.Equal(t, event, yamlToTCREvent(yamlString))
+}
+
+func Test_convert_step_results_to_and_from_yaml(t *testing.T) {
+	event := *ATcrEvent(WithSteps(
+		NewStepResult("lint", StatusFail, 2*time.Second),
+		NewStepResult("build", StatusPass, 3*time.Second),
+	))
+	yamlString := buildYAMLString("0", "0", "0", "0", "0", "0", "0", "0s") +
+		"steps:\n" +
+		"    - name: lint\n" +
+		"      status: fail\n" +
+		"      duration: 2s\n" +
+		"    - name: build\n" +
+		"      status: pass\n" +
+		"      duration: 3s\n"
+	assert.Equal(t, yamlString, tcrEventToYAML(event))
+	assert.Equal(t, event, yamlToTCREvent(yamlString))
+}
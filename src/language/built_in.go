@@ -24,6 +24,7 @@ package language
 
 import (
 	"embed"
+	"errors"
 	"github.com/murex/tcr/utils"
 	"path"
 )
@@ -55,6 +56,16 @@ func loadBuiltInLanguages() {
 	}
 }
 
+// loadBuiltInLanguageData returns the raw YAML definition of the built-in language with the provided name.
+// It is used by language configurations extending a built-in language
+func loadBuiltInLanguageData(name string) (data []byte, origin string, err error) {
+	data, err = builtInFS.ReadFile(path.Join(builtInDir, utils.BuildYAMLFilename(name)))
+	if err != nil {
+		return nil, "", errors.New("cannot extend " + name + ": no such built-in language")
+	}
+	return data, "built-in " + name, nil
+}
+
 func loadBuiltInLanguage(yamlFilename string) *configYAML {
 	var languageCfg configYAML
 
@@ -0,0 +1,41 @@
+//go:build test_helper
+
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package built_in_test_data //nolint:revive
+
+func init() {
+	addBuiltIn(BuiltInTestData{
+		Name:                 "scala",
+		Extensions:           []string{".scala"},
+		DefaultToolchain:     "sbt",
+		CompatibleToolchains: []string{"sbt", "bazel", "gradle", "gradle-wrapper", "maven", "maven-wrapper", "make"},
+		DirsToWatch:          []string{"src/main/scala", "src/test/scala"},
+		SrcMatchersDefs: []MatcherDef{
+			{"src/main/scala", "SomeSrcFile"},
+		},
+		TestMatcherDefs: []MatcherDef{
+			{"src/test/scala", "SomeTestFile"},
+		},
+	})
+}
@@ -29,7 +29,7 @@ func init() {
 		Name:                 "typescript",
 		Extensions:           []string{".ts"},
 		DefaultToolchain:     "yarn",
-		CompatibleToolchains: []string{"yarn", "bazel", "make"},
+		CompatibleToolchains: []string{"yarn", "npm", "pnpm", "jest", "vitest", "bazel", "make"},
 		DirsToWatch:          []string{"src", "test"},
 		SrcMatchersDefs: []MatcherDef{
 			{"src", "someSrcFile"},
@@ -24,6 +24,7 @@ package language
 
 import (
 	"github.com/murex/tcr/utils"
+	"github.com/spf13/afero"
 	"os"
 	"path/filepath"
 )
@@ -45,13 +46,26 @@ type (
 
 	// fileTreeFilterConfigYAML defines the structure for file tree filtering configuration related to a language
 	fileTreeFilterConfigYAML struct {
-		Directories  []string `yaml:"directories,flow"`
-		FilePatterns []string `yaml:"patterns,flow"`
+		Directories  []string                 `yaml:"directories,flow"`
+		FilePatterns []string                 `yaml:"patterns,flow"`
+		FileGlobs    []string                 `yaml:"globs,flow,omitempty"`
+		Exclude      *fileExclusionConfigYAML `yaml:"exclude,omitempty"`
+		UseGitignore bool                     `yaml:"use-gitignore,omitempty"`
+	}
+
+	// fileExclusionConfigYAML defines the structure for files excluded from a file tree
+	fileExclusionConfigYAML struct {
+		Directories  []string `yaml:"directories,flow,omitempty"`
+		FilePatterns []string `yaml:"patterns,flow,omitempty"`
+		FileGlobs    []string `yaml:"globs,flow,omitempty"`
 	}
 
 	// configYAML defines the structure of a language configuration.
+	// Extends and Origins are set only when the configuration extends a built-in language
 	configYAML struct {
 		Name        string                   `yaml:"-"`
+		Extends     string                   `yaml:"-"`
+		Origins     utils.YAMLOrigins        `yaml:"-"`
 		Toolchains  toolchainConfigYAML      `yaml:"toolchains"`
 		SourceFiles fileTreeFilterConfigYAML `yaml:"source-files"`
 		TestFiles   fileTreeFilterConfigYAML `yaml:"test-files"`
@@ -75,9 +89,25 @@ func SaveConfigs() {
 	}
 }
 
+// saveConfig saves the configuration of the language with the provided name. When the existing
+// configuration file extends a built-in language, only the values differing from the built-in language
+// are saved, so that the file keeps extending it
 func saveConfig(name string) {
 	lang, _ := Get(name)
-	utils.SaveToYAMLFile(appFS, asConfig(lang), utils.BuildYAMLFilePath(languageDirPath, name))
+	filePath := utils.BuildYAMLFilePath(languageDirPath, name)
+	if exists, _ := afero.Exists(appFS, filePath); exists {
+		existing, err := readConfig(utils.BuildYAMLFilename(name))
+		if err == nil && existing.Extends != "" {
+			layer, err := utils.BuildYAMLLayer(asConfig(lang), existing.Extends, loadBuiltInLanguageData)
+			if err != nil {
+				utils.Trace("Error while saving ", filePath, ": ", err)
+				return
+			}
+			utils.SaveToYAMLFile(appFS, layer, filePath)
+			return
+		}
+	}
+	utils.SaveToYAMLFile(appFS, asConfig(lang), filePath)
 }
 
 // GetConfigFileList returns the list of language configuration files found in language directory
@@ -89,7 +119,16 @@ func loadConfigs() {
 	utils.Trace("Loading languages configuration")
 	// Loop on all YAML files in language directory
 	for _, entry := range GetConfigFileList() {
-		err := Register(asLanguage(*loadConfig(entry)))
+		if issues := ValidateConfigFile(entry); len(issues) > 0 {
+			traceIssues(issues)
+			continue
+		}
+		cfg := loadConfig(entry)
+		if cfg == nil {
+			// loadConfig already traced the reason why the configuration could not be loaded
+			continue
+		}
+		err := Register(asLanguage(*cfg))
 		if err != nil {
 			utils.Trace("Error in ", entry, ": ", err)
 		}
@@ -97,14 +136,25 @@ func loadConfigs() {
 }
 
 func loadConfig(yamlFilename string) *configYAML {
-	var languageCfg configYAML
-	err := utils.LoadFromYAMLFile(os.DirFS(languageDirPath), yamlFilename, &languageCfg)
+	languageCfg, err := readConfig(yamlFilename)
 	if err != nil {
 		utils.Trace("Error in ", yamlFilename, ": ", err)
 		return nil
 	}
+	return languageCfg
+}
+
+func readConfig(yamlFilename string) (*configYAML, error) {
+	var languageCfg configYAML
+	var err error
+	languageCfg.Extends, languageCfg.Origins, err = utils.LoadFromLayeredYAMLFile(
+		os.DirFS(languageDirPath), yamlFilename, filepath.Join(languageDirPath, yamlFilename),
+		loadBuiltInLanguageData, &languageCfg)
+	if err != nil {
+		return nil, err
+	}
 	languageCfg.Name = utils.ExtractNameFromYAMLFilename(yamlFilename)
-	return &languageCfg
+	return &languageCfg, nil
 }
 
 func asLanguage(languageCfg configYAML) *Language {
@@ -117,10 +167,18 @@ func asLanguage(languageCfg configYAML) *Language {
 }
 
 func asFileTreeFilter(filesCfg fileTreeFilterConfigYAML) FileTreeFilter {
-	return FileTreeFilter{
+	filter := FileTreeFilter{
 		Directories:  asDirectoryTable(filesCfg.Directories),
 		FilePatterns: asFilePatternTable(filesCfg.FilePatterns),
+		FileGlobs:    asOptionalTable(filesCfg.FileGlobs),
+		UseGitignore: filesCfg.UseGitignore,
+	}
+	if filesCfg.Exclude != nil {
+		filter.ExcludedDirectories = asOptionalTable(filesCfg.Exclude.Directories)
+		filter.ExcludedPatterns = asOptionalTable(filesCfg.Exclude.FilePatterns)
+		filter.ExcludedGlobs = asOptionalTable(filesCfg.Exclude.FileGlobs)
 	}
+	return filter
 }
 
 func asToolchains(toolchainsCfg toolchainConfigYAML) Toolchains {
@@ -142,6 +200,10 @@ func asFilePatternTable(filePatternTableCfg []string) []string {
 	return append([]string{}, filePatternTableCfg...)
 }
 
+func asOptionalTable(tableCfg []string) []string {
+	return append([]string(nil), tableCfg...)
+}
+
 // ResetConfigs resets the languages configuration
 func ResetConfigs() {
 	utils.Trace("Resetting languages configuration")
@@ -162,10 +224,20 @@ func asConfig(lang LangInterface) configYAML {
 }
 
 func asFileTreeFilterConfig(files FileTreeFilter) fileTreeFilterConfigYAML {
-	return fileTreeFilterConfigYAML{
+	cfg := fileTreeFilterConfigYAML{
 		Directories:  asDirectoryTableConfig(files.Directories),
 		FilePatterns: asFilePatternTableConfig(files.FilePatterns),
+		FileGlobs:    asOptionalTable(files.FileGlobs),
+		UseGitignore: files.UseGitignore,
+	}
+	if len(files.ExcludedDirectories)+len(files.ExcludedPatterns)+len(files.ExcludedGlobs) > 0 {
+		cfg.Exclude = &fileExclusionConfigYAML{
+			Directories:  asOptionalTable(files.ExcludedDirectories),
+			FilePatterns: asOptionalTable(files.ExcludedPatterns),
+			FileGlobs:    asOptionalTable(files.ExcludedGlobs),
+		}
 	}
+	return cfg
 }
 
 func asToolchainsTableConfig(toolchains []string) []string {
@@ -208,23 +280,53 @@ func ShowConfigs() {
 		utils.Trace("- none (will use built-in languages)")
 	}
 	for _, entry := range entries {
-		loadConfig(entry).show()
+		if cfg := loadConfig(entry); cfg != nil {
+			cfg.show()
+		}
 	}
 }
 
+// show traces the language configuration. When the configuration extends a built-in language,
+// the origin of each value is traced as well
 func (l configYAML) show() {
 	prefix := "language." + l.Name
-	l.Toolchains.show(prefix + ".toolchains")
-	l.SourceFiles.show(prefix + ".source-files")
-	l.TestFiles.show(prefix + ".test-files")
+	trace := utils.TraceKeyValue
+	if l.Extends != "" {
+		utils.TraceKeyValue(prefix+".extends", l.Extends)
+		trace = l.Origins.Tracer(prefix + ".")
+	}
+	l.Toolchains.show(prefix+".toolchains", trace)
+	l.SourceFiles.show(prefix+".source-files", trace)
+	l.TestFiles.show(prefix+".test-files", trace)
 }
 
-func (lt toolchainConfigYAML) show(prefix string) {
-	utils.TraceKeyValue(prefix+".default", lt.Default)
-	utils.TraceKeyValue(prefix+".compatible-with", lt.Compatible)
+func (lt toolchainConfigYAML) show(prefix string, trace func(key string, value any)) {
+	trace(prefix+".default", lt.Default)
+	trace(prefix+".compatible-with", lt.Compatible)
 }
 
-func (ftf fileTreeFilterConfigYAML) show(prefix string) {
-	utils.TraceKeyValue(prefix+".directories", ftf.Directories)
-	utils.TraceKeyValue(prefix+".patterns", ftf.FilePatterns)
+func (ftf fileTreeFilterConfigYAML) show(prefix string, trace func(key string, value any)) {
+	trace(prefix+".directories", ftf.Directories)
+	trace(prefix+".patterns", ftf.FilePatterns)
+	if len(ftf.FileGlobs) > 0 {
+		trace(prefix+".globs", ftf.FileGlobs)
+	}
+	if ftf.Exclude != nil {
+		ftf.Exclude.show(prefix+".exclude", trace)
+	}
+	if ftf.UseGitignore {
+		trace(prefix+".use-gitignore", ftf.UseGitignore)
+	}
+}
+
+func (fe fileExclusionConfigYAML) show(prefix string, trace func(key string, value any)) {
+	if len(fe.Directories) > 0 {
+		trace(prefix+".directories", fe.Directories)
+	}
+	if len(fe.FilePatterns) > 0 {
+		trace(prefix+".patterns", fe.FilePatterns)
+	}
+	if len(fe.FileGlobs) > 0 {
+		trace(prefix+".globs", fe.FileGlobs)
+	}
 }
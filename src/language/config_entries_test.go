@@ -0,0 +1,104 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package language
+
+import (
+	"github.com/murex/tcr/utils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func setupLanguageConfigDir(t *testing.T) {
+	t.Helper()
+	appFS = afero.NewMemMapFs()
+	initConfigDirPath(t.TempDir())
+}
+
+func Test_get_language_config_value(t *testing.T) {
+	setupLanguageConfigDir(t)
+	value, err := GetConfigValue("java", "toolchains.default")
+	assert.NoError(t, err)
+	assert.Equal(t, "gradle-wrapper", value)
+}
+
+func Test_get_language_config_value_with_unknown_key(t *testing.T) {
+	setupLanguageConfigDir(t)
+	_, err := GetConfigValue("java", "toolchains.unknown")
+	assert.ErrorIs(t, err, utils.ErrYAMLEntryNotFound)
+}
+
+func Test_get_language_config_value_with_unknown_language(t *testing.T) {
+	setupLanguageConfigDir(t)
+	_, err := GetConfigValue("unknown-language", "toolchains.default")
+	assert.Error(t, err)
+}
+
+func Test_set_language_config_value_extends_built_in_language(t *testing.T) {
+	setupLanguageConfigDir(t)
+	assert.NoError(t, SetConfigValue("java", "test-files.directories", "[src/it/java]"))
+
+	entries, _ := utils.LoadYAMLEntries(appFS, utils.BuildYAMLFilePath(languageDirPath, "java"))
+	assert.Equal(t, map[string]any{
+		"extends":    "java",
+		"test-files": map[string]any{"directories": []any{"src/it/java"}},
+	}, entries)
+}
+
+func Test_set_language_config_value_with_invalid_value(t *testing.T) {
+	testFlags := []struct {
+		desc  string
+		path  string
+		value string
+	}{
+		{"unknown key", "test-files.directory", "src"},
+		{"list instead of a string", "toolchains.default", "[gradle, maven]"},
+		{"string instead of a list", "test-files.directories", "src"},
+	}
+	for _, tt := range testFlags {
+		t.Run(tt.desc, func(t *testing.T) {
+			setupLanguageConfigDir(t)
+			assert.Error(t, SetConfigValue("java", tt.path, tt.value))
+			exists, _ := afero.Exists(appFS, utils.BuildYAMLFilePath(languageDirPath, "java"))
+			assert.False(t, exists)
+		})
+	}
+}
+
+func Test_unset_language_config_value(t *testing.T) {
+	setupLanguageConfigDir(t)
+	_ = SetConfigValue("java", "toolchains.default", "maven")
+	_ = SetConfigValue("java", "test-files.directories", "[src/it/java]")
+
+	assert.NoError(t, UnsetConfigValue("java", "toolchains.default"))
+	entries, _ := utils.LoadYAMLEntries(appFS, utils.BuildYAMLFilePath(languageDirPath, "java"))
+	assert.Equal(t, map[string]any{
+		"extends":    "java",
+		"test-files": map[string]any{"directories": []any{"src/it/java"}},
+	}, entries)
+}
+
+func Test_unset_language_config_value_not_in_config_file(t *testing.T) {
+	setupLanguageConfigDir(t)
+	assert.ErrorIs(t, UnsetConfigValue("java", "toolchains.default"), utils.ErrYAMLEntryNotFound)
+}
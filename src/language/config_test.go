@@ -27,6 +27,7 @@ import (
 	"github.com/murex/tcr/utils"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"io"
 	"os"
 	"testing"
 )
@@ -74,6 +75,41 @@ func Test_convert_language_src_filter_patterns_to_config(t *testing.T) {
 	assert.Equal(t, cfg.SourceFiles.FilePatterns, asLanguage(cfg).GetSrcFileFilter().FilePatterns)
 }
 
+func Test_convert_language_src_filter_globs_and_exclusions_to_config(t *testing.T) {
+	lang := ALanguage(
+		WithSrcFiles(
+			AFileTreeFilter(
+				WithGlob("**/*.ts"),
+				WithExcludedDirectory("src/generated"),
+				WithExcludedPattern(".*\\.d\\.ts"),
+				WithExcludedGlob("**/*.gen.ts"),
+				WithGitignore(),
+			),
+		),
+	)
+	cfg := asConfig(lang)
+	assert.Equal(t, []string{"**/*.ts"}, cfg.SourceFiles.FileGlobs)
+	assert.Equal(t, &fileExclusionConfigYAML{
+		Directories:  []string{"src/generated"},
+		FilePatterns: []string{".*\\.d\\.ts"},
+		FileGlobs:    []string{"**/*.gen.ts"},
+	}, cfg.SourceFiles.Exclude)
+	assert.True(t, cfg.SourceFiles.UseGitignore)
+
+	filter := asLanguage(cfg).GetSrcFileFilter()
+	assert.Equal(t, lang.GetSrcFileFilter().FileGlobs, filter.FileGlobs)
+	assert.Equal(t, lang.GetSrcFileFilter().ExcludedDirectories, filter.ExcludedDirectories)
+	assert.Equal(t, lang.GetSrcFileFilter().ExcludedPatterns, filter.ExcludedPatterns)
+	assert.Equal(t, lang.GetSrcFileFilter().ExcludedGlobs, filter.ExcludedGlobs)
+	assert.True(t, filter.UseGitignore)
+}
+
+func Test_convert_language_with_no_exclusion_to_config(t *testing.T) {
+	cfg := asConfig(ALanguage())
+	assert.Nil(t, cfg.SourceFiles.Exclude)
+	assert.Nil(t, cfg.TestFiles.Exclude)
+}
+
 func Test_convert_language_test_filter_directories_to_config(t *testing.T) {
 	lang := ALanguage(
 		WithTestFiles(
@@ -221,3 +257,93 @@ func Test_save_and_load_all_language_configs(t *testing.T) {
 		}
 	}
 }
+
+func setupLanguageConfigFile(t *testing.T, name string, data string) string {
+	t.Helper()
+	appFS = afero.NewOsFs()
+	dir := t.TempDir()
+	initConfigDirPath(dir)
+	createConfigDir()
+	err := afero.WriteFile(appFS, utils.BuildYAMLFilePath(languageDirPath, name), []byte(data), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return utils.BuildYAMLFilePath(languageDirPath, name)
+}
+
+func Test_load_a_language_config_extending_a_built_in_language(t *testing.T) {
+	filePath := setupLanguageConfigFile(t, "java-it",
+		"extends: java\ntest-files:\n  directories: [ src/it ]\n")
+
+	cfg := loadConfig(utils.BuildYAMLFilename("java-it"))
+
+	builtIn := loadBuiltInLanguage("java.yml")
+	if assert.NotNil(t, cfg) {
+		assert.Equal(t, "java-it", cfg.Name)
+		assert.Equal(t, "java", cfg.Extends)
+		assert.Equal(t, builtIn.Toolchains, cfg.Toolchains)
+		assert.Equal(t, builtIn.SourceFiles, cfg.SourceFiles)
+		assert.Equal(t, []string{"src/it"}, cfg.TestFiles.Directories)
+		assert.Equal(t, builtIn.TestFiles.FilePatterns, cfg.TestFiles.FilePatterns)
+		assert.Equal(t, filePath, cfg.Origins.Get("test-files.directories"))
+		assert.Equal(t, "built-in java", cfg.Origins.Get("test-files.patterns"))
+		assert.Equal(t, "built-in java", cfg.Origins.Get("toolchains.default"))
+	}
+}
+
+func Test_save_and_load_a_language_config_extending_a_built_in_language(t *testing.T) {
+	filePath := setupLanguageConfigFile(t, "java-it",
+		"extends: java\ntest-files:\n  directories: [ src/it ]\n")
+	utils.SetSimpleTrace(io.Discard)
+	loadConfigs()
+	t.Cleanup(func() { delete(registered, "java-it") })
+
+	saveConfig("java-it")
+
+	data, err := os.ReadFile(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "extends: java\ntest-files:\n  directories:\n    - src/it\n", string(data))
+	cfg := loadConfig(utils.BuildYAMLFilename("java-it"))
+	if assert.NotNil(t, cfg) {
+		assert.Equal(t, "java", cfg.Extends)
+		assert.Equal(t, []string{"src/it"}, cfg.TestFiles.Directories)
+	}
+}
+
+func Test_load_a_language_config_extending_an_unknown_language(t *testing.T) {
+	setupLanguageConfigFile(t, "my-language", "extends: unknown-language\n")
+	assert.Nil(t, loadConfig(utils.BuildYAMLFilename("my-language")))
+}
+
+func Test_load_configs_skips_a_language_config_that_cannot_be_loaded(t *testing.T) {
+	setupLanguageConfigFile(t, "my-language", "extends: unknown-language\n")
+	utils.SetSimpleTrace(io.Discard)
+	assert.NotPanics(t, loadConfigs)
+	assert.False(t, isSupported("my-language"))
+	assert.NotPanics(t, ShowConfigs)
+}
+
+func Test_show_a_language_config_extending_a_built_in_language(t *testing.T) {
+	filePath := setupLanguageConfigFile(t, "java",
+		"extends: java\ntoolchains:\n  default: maven\n")
+	cfg := loadConfig(utils.BuildYAMLFilename("java"))
+	if !assert.NotNil(t, cfg) {
+		return
+	}
+	prefix := "- language.java"
+	builtIn := " (from built-in java)"
+	expected := []string{
+		fmt.Sprintf("%v.extends: java", prefix),
+		fmt.Sprintf("%v.toolchains.default: maven (from %v)", prefix, filePath),
+		fmt.Sprintf("%v.toolchains.compatible-with: %v%v", prefix, cfg.Toolchains.Compatible, builtIn),
+		fmt.Sprintf("%v.source-files.directories: %v%v", prefix, cfg.SourceFiles.Directories, builtIn),
+		fmt.Sprintf("%v.source-files.patterns: %v%v", prefix, cfg.SourceFiles.FilePatterns, builtIn),
+		fmt.Sprintf("%v.test-files.directories: %v%v", prefix, cfg.TestFiles.Directories, builtIn),
+		fmt.Sprintf("%v.test-files.patterns: %v%v", prefix, cfg.TestFiles.FilePatterns, builtIn),
+	}
+	utils.AssertSimpleTrace(t, expected,
+		func() {
+			cfg.show()
+		},
+	)
+}
@@ -0,0 +1,138 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package language
+
+import (
+	"fmt"
+	"github.com/spf13/afero"
+	"path/filepath"
+)
+
+type (
+	// Detection contains the result of language and toolchain detection for a base directory.
+	// - Language is the detected language name. It is empty when no language could be detected.
+	// - Toolchain is the detected toolchain name. It is empty when no specific toolchain could be
+	// detected, in which case the language's default toolchain should be used.
+	// - Reasons explains how the language and toolchain were detected.
+	Detection struct {
+		Language  string
+		Toolchain string
+		Reasons   []string
+	}
+
+	// projectMarker associates a file pattern found at the root of a project with a language
+	// and/or a toolchain. Either language or toolchain may be empty.
+	projectMarker struct {
+		pattern   string
+		language  string
+		toolchain string
+	}
+)
+
+// projectMarkers is the list of known project markers, from the most specific to the least specific one.
+// The first matching language marker gives the language. The first matching toolchain marker compatible
+// with this language gives the toolchain
+var projectMarkers = []projectMarker{
+	{pattern: "WORKSPACE", toolchain: "bazel"},
+	{pattern: "WORKSPACE.bazel", toolchain: "bazel"},
+	{pattern: "MODULE.bazel", toolchain: "bazel"},
+	{pattern: "go.mod", language: "go", toolchain: "go-tools"},
+	{pattern: "Cargo.toml", language: "rust", toolchain: "cargo"},
+	{pattern: "build.sbt", language: "scala", toolchain: "sbt"},
+	{pattern: "build.gradle.kts", language: "kotlin"},
+	{pattern: "gradlew", language: "java", toolchain: "gradle-wrapper"},
+	{pattern: "mvnw", language: "java", toolchain: "maven-wrapper"},
+	{pattern: "build.gradle", language: "java", toolchain: "gradle"},
+	{pattern: "build.gradle.kts", toolchain: "gradle"},
+	{pattern: "pom.xml", language: "java", toolchain: "maven"},
+	{pattern: "tsconfig.json", language: "typescript"},
+	{pattern: "vitest.config.*", toolchain: "vitest"},
+	{pattern: "yarn.lock", toolchain: "yarn"},
+	{pattern: "pnpm-lock.yaml", toolchain: "pnpm"},
+	{pattern: "package-lock.json", toolchain: "npm"},
+	{pattern: "package.json", language: "javascript"},
+	{pattern: "CMakeLists.txt", language: "cpp", toolchain: "cmake"},
+	{pattern: "mix.exs", language: "elixir", toolchain: "mix"},
+	{pattern: "*.csproj", language: "csharp", toolchain: "dotnet"},
+	{pattern: "*.sln", language: "csharp", toolchain: "dotnet"},
+	{pattern: "composer.json", language: "php", toolchain: "phpunit"},
+	{pattern: "pytest.ini", language: "python", toolchain: "pytest"},
+	{pattern: ".rspec", language: "ruby", toolchain: "rspec"},
+	{pattern: "Gemfile", language: "ruby"},
+	{pattern: "Rakefile", toolchain: "rake"},
+}
+
+// Detect tries to identify the language and toolchain used in the provided base directory.
+// It first looks at the name of the directory, then at project marker files found at its root
+// (go.mod, pom.xml, package.json, etc.). The toolchain is only detected from project marker files
+func Detect(baseDir string) (d Detection) {
+	// Warning (for tests only): filepath.Abs() does not work with MemMapFs on Windows
+	absDir, _ := filepath.Abs(baseDir)
+	dirName := filepath.Base(absDir)
+	if _, err := getRegisteredLanguage(dirName); err == nil {
+		d.Language = dirName
+		d.Reasons = append(d.Reasons, fmt.Sprintf("base directory name matches %s language", dirName))
+	}
+
+	matched := findProjectMarkers(baseDir)
+	if d.Language == "" {
+		for _, m := range matched {
+			if m.language != "" && isSupported(m.language) {
+				d.Language = m.language
+				d.Reasons = append(d.Reasons, fmt.Sprintf("found %s: %s language", m.pattern, m.language))
+				break
+			}
+		}
+	}
+	if d.Language == "" {
+		return d
+	}
+
+	lang, _ := getRegisteredLanguage(d.Language)
+	for _, m := range matched {
+		if m.toolchain != "" && lang.worksWithToolchain(m.toolchain) {
+			d.Toolchain = m.toolchain
+			d.Reasons = append(d.Reasons, fmt.Sprintf("found %s: %s toolchain", m.pattern, m.toolchain))
+			break
+		}
+	}
+	return d
+}
+
+// findProjectMarkers returns the project markers found at the root of the
+// provided directory, from the most specific to the least specific one
+func findProjectMarkers(baseDir string) (matched []projectMarker) {
+	entries, err := afero.ReadDir(appFS, baseDir)
+	if err != nil {
+		return nil
+	}
+	for _, m := range projectMarkers {
+		for _, entry := range entries {
+			if ok, _ := filepath.Match(m.pattern, entry.Name()); ok && !entry.IsDir() {
+				matched = append(matched, m)
+				break
+			}
+		}
+	}
+	return matched
+}
@@ -0,0 +1,196 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package language
+
+import (
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func setupProjectFiles(baseDir string, files ...string) {
+	appFS = afero.NewMemMapFs()
+	_ = appFS.MkdirAll(baseDir, os.ModeDir)
+	for _, file := range files {
+		_ = afero.WriteFile(appFS, filepath.Join(baseDir, file), []byte("some contents"), 0644)
+	}
+}
+
+func Test_detect_language_and_toolchain_from_project_files(t *testing.T) {
+	tests := []struct {
+		desc          string
+		files         []string
+		expectedLang  string
+		expectedTchn  string
+		expectedCause []string
+	}{
+		{
+			"no project file", nil, "", "", nil,
+		},
+		{
+			"go.mod", []string{"go.mod"}, "go", "go-tools",
+			[]string{"found go.mod: go language", "found go.mod: go-tools toolchain"},
+		},
+		{
+			"go.mod with bazel", []string{"go.mod", "WORKSPACE"}, "go", "bazel",
+			[]string{"found go.mod: go language", "found WORKSPACE: bazel toolchain"},
+		},
+		{
+			"Cargo.toml", []string{"Cargo.toml"}, "rust", "cargo",
+			[]string{"found Cargo.toml: rust language", "found Cargo.toml: cargo toolchain"},
+		},
+		{
+			"build.sbt", []string{"build.sbt"}, "scala", "sbt",
+			[]string{"found build.sbt: scala language", "found build.sbt: sbt toolchain"},
+		},
+		{
+			"pom.xml", []string{"pom.xml"}, "java", "maven",
+			[]string{"found pom.xml: java language", "found pom.xml: maven toolchain"},
+		},
+		{
+			"pom.xml with maven wrapper", []string{"pom.xml", "mvnw"}, "java", "maven-wrapper",
+			[]string{"found mvnw: java language", "found mvnw: maven-wrapper toolchain"},
+		},
+		{
+			"build.gradle", []string{"build.gradle"}, "java", "gradle",
+			[]string{"found build.gradle: java language", "found build.gradle: gradle toolchain"},
+		},
+		{
+			"build.gradle.kts with gradle wrapper", []string{"build.gradle.kts", "gradlew"}, "kotlin", "gradle-wrapper",
+			[]string{"found build.gradle.kts: kotlin language", "found gradlew: gradle-wrapper toolchain"},
+		},
+		{
+			"build.gradle.kts", []string{"build.gradle.kts"}, "kotlin", "gradle",
+			[]string{"found build.gradle.kts: kotlin language", "found build.gradle.kts: gradle toolchain"},
+		},
+		{
+			"package.json with yarn.lock", []string{"package.json", "yarn.lock"}, "javascript", "yarn",
+			[]string{"found package.json: javascript language", "found yarn.lock: yarn toolchain"},
+		},
+		{
+			"package.json with pnpm-lock.yaml", []string{"package.json", "pnpm-lock.yaml"}, "javascript", "pnpm",
+			[]string{"found package.json: javascript language", "found pnpm-lock.yaml: pnpm toolchain"},
+		},
+		{
+			"package.json with package-lock.json", []string{"package.json", "package-lock.json"}, "javascript", "npm",
+			[]string{"found package.json: javascript language", "found package-lock.json: npm toolchain"},
+		},
+		{
+			"vitest project", []string{"package.json", "package-lock.json", "vitest.config.ts", "tsconfig.json"},
+			"typescript", "vitest",
+			[]string{"found tsconfig.json: typescript language", "found vitest.config.*: vitest toolchain"},
+		},
+		{
+			"package.json without lockfile", []string{"package.json"}, "javascript", "",
+			[]string{"found package.json: javascript language"},
+		},
+		{
+			"typescript project", []string{"package.json", "yarn.lock", "tsconfig.json"}, "typescript", "yarn",
+			[]string{"found tsconfig.json: typescript language", "found yarn.lock: yarn toolchain"},
+		},
+		{
+			"CMakeLists.txt", []string{"CMakeLists.txt"}, "cpp", "cmake",
+			[]string{"found CMakeLists.txt: cpp language", "found CMakeLists.txt: cmake toolchain"},
+		},
+		{
+			"mix.exs", []string{"mix.exs"}, "elixir", "mix",
+			[]string{"found mix.exs: elixir language", "found mix.exs: mix toolchain"},
+		},
+		{
+			"csproj file", []string{"MyApp.csproj"}, "csharp", "dotnet",
+			[]string{"found *.csproj: csharp language", "found *.csproj: dotnet toolchain"},
+		},
+		{
+			"composer.json", []string{"composer.json"}, "php", "phpunit",
+			[]string{"found composer.json: php language", "found composer.json: phpunit toolchain"},
+		},
+		{
+			"pytest.ini", []string{"pytest.ini"}, "python", "pytest",
+			[]string{"found pytest.ini: python language", "found pytest.ini: pytest toolchain"},
+		},
+		{
+			"Gemfile with .rspec", []string{"Gemfile", ".rspec", "Rakefile"}, "ruby", "rspec",
+			[]string{"found .rspec: ruby language", "found .rspec: rspec toolchain"},
+		},
+		{
+			"Gemfile with Rakefile", []string{"Gemfile", "Rakefile"}, "ruby", "rake",
+			[]string{"found Gemfile: ruby language", "found Rakefile: rake toolchain"},
+		},
+		{
+			"bazel only", []string{"WORKSPACE"}, "", "", nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			baseDir := "my-project"
+			setupProjectFiles(baseDir, test.files...)
+			d := Detect(baseDir)
+			assert.Equal(t, test.expectedLang, d.Language)
+			assert.Equal(t, test.expectedTchn, d.Toolchain)
+			assert.Equal(t, test.expectedCause, d.Reasons)
+		})
+	}
+}
+
+func Test_detect_language_from_dir_name_takes_precedence_over_project_files(t *testing.T) {
+	baseDir := filepath.Join("some", "java")
+	setupProjectFiles(baseDir, "pom.xml", "go.mod")
+	d := Detect(baseDir)
+	assert.Equal(t, "java", d.Language)
+	assert.Equal(t, "maven", d.Toolchain)
+	assert.Equal(t, []string{
+		"base directory name matches java language",
+		"found pom.xml: maven toolchain",
+	}, d.Reasons)
+}
+
+func Test_detect_ignores_directories_matching_project_markers(t *testing.T) {
+	baseDir := "my-project"
+	setupProjectFiles(baseDir)
+	_ = appFS.MkdirAll(filepath.Join(baseDir, "go.mod"), os.ModeDir)
+	assert.Equal(t, Detection{}, Detect(baseDir))
+}
+
+func Test_get_language_uses_detected_toolchain(t *testing.T) {
+	baseDir := "my-project"
+	setupProjectFiles(baseDir, "pom.xml")
+	lang, err := GetLanguage("", baseDir)
+	assert.NoError(t, err)
+	assert.Equal(t, "java", lang.GetName())
+	tchn, err := lang.GetToolchain("")
+	assert.NoError(t, err)
+	assert.Equal(t, "maven", tchn.GetName())
+	lang.setDetectedToolchain("")
+}
+
+func Test_get_language_with_explicit_name_ignores_detected_toolchain(t *testing.T) {
+	baseDir := "my-project"
+	setupProjectFiles(baseDir, "pom.xml")
+	lang, err := GetLanguage("java", baseDir)
+	assert.NoError(t, err)
+	tchn, err := lang.GetToolchain("")
+	assert.NoError(t, err)
+	assert.Equal(t, "gradle-wrapper", tchn.GetName())
+}
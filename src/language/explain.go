@@ -0,0 +1,208 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package language
+
+import (
+	"fmt"
+	"github.com/murex/tcr/utils"
+	"path/filepath"
+)
+
+type (
+	// FilterExplanation explains how a FileTreeFilter classifies a file.
+	// - Directory is the filter directory containing the file. It is empty when
+	// the file is outside of all filter directories, or when the filter has no directory.
+	// - InFileTree indicates if the file is located in the filter's file tree.
+	// - MatchedBy describes the pattern or glob matching the file. It is empty when no pattern matched.
+	// - ExcludedBy describes the exclusion rule applying to the file, if any.
+	// - Matches indicates the final result of the filter for this file.
+	FilterExplanation struct {
+		Directory  string
+		InFileTree bool
+		MatchedBy  string
+		ExcludedBy string
+		Matches    bool
+	}
+
+	// FileExplanation explains how a file is classified for a language, and how
+	// TCR behaves when this file is changed.
+	FileExplanation struct {
+		Path           string
+		WatchedDir     string
+		Src            FilterExplanation
+		Test           FilterExplanation
+		IsSrcFile      bool
+		IsTestFile     bool
+		IsLanguageFile bool
+	}
+)
+
+// Explain returns the explanation of how the provided file path is classified for lang.
+// baseDir is the base directory from which lang directories are resolved
+func Explain(lang LangInterface, baseDir string, aPath string) FileExplanation {
+	absPath, _ := filepath.Abs(aPath)
+	e := FileExplanation{
+		Path:           absPath,
+		Src:            lang.GetSrcFileFilter().explain(absPath, baseDir),
+		Test:           lang.GetTestFileFilter().explain(absPath, baseDir),
+		IsSrcFile:      lang.IsSrcFile(absPath),
+		IsTestFile:     lang.IsTestFile(absPath),
+		IsLanguageFile: lang.IsLanguageFile(absPath),
+	}
+	for _, dir := range lang.DirsToWatch(baseDir) {
+		absDir, _ := filepath.Abs(dir)
+		if utils.IsSubPathOf(absPath, absDir) {
+			e.WatchedDir = absDir
+			break
+		}
+	}
+	return e
+}
+
+// IsWatched indicates if the file is located in one of the directories watched by TCR
+func (e FileExplanation) IsWatched() bool {
+	return e.WatchedDir != ""
+}
+
+// TriggersCycle indicates if saving this file triggers a TCR cycle
+func (e FileExplanation) TriggersCycle() bool {
+	return e.IsWatched() && e.IsLanguageFile
+}
+
+// RevertBehavior describes what TCR does with this file when build or tests fail
+func (e FileExplanation) RevertBehavior() string {
+	switch {
+	case e.IsTestFile:
+		return "test file: changes are kept when build or tests fail"
+	case e.IsSrcFile:
+		return "source file: changes are reverted when build or tests fail"
+	default:
+		return "not a language file: changes are never reverted"
+	}
+}
+
+// Show displays the explanation
+func (e FileExplanation) Show() {
+	utils.Trace(e.Path, ":")
+	if e.IsWatched() {
+		utils.TraceKeyValue("watched directory", e.WatchedDir)
+	} else {
+		utils.TraceKeyValue("watched directory", "none (changes are not detected by TCR)")
+	}
+	utils.TraceKeyValue("source files filter", e.Src)
+	utils.TraceKeyValue("test files filter", e.Test)
+	utils.TraceKeyValue("is source file", e.IsSrcFile)
+	utils.TraceKeyValue("is test file", e.IsTestFile)
+	utils.TraceKeyValue("is language file", e.IsLanguageFile)
+	utils.TraceKeyValue("triggers a TCR cycle when saved", e.TriggersCycle())
+	utils.TraceKeyValue("revert behavior", e.RevertBehavior())
+}
+
+// String returns a readable description of the filter explanation
+func (e FilterExplanation) String() string {
+	if !e.InFileTree {
+		return "not matching (outside of filter directories)"
+	}
+	location := "in base directory"
+	if e.Directory != "" {
+		location = "in directory " + e.Directory
+	}
+	switch {
+	case e.MatchedBy == "" && e.ExcludedBy != "":
+		return "not matching (" + location + ", " + e.ExcludedBy + ")"
+	case e.MatchedBy == "":
+		return "not matching (" + location + ", no matching pattern or glob)"
+	case e.ExcludedBy != "":
+		return "not matching (" + location + ", matched by " + e.MatchedBy + ", excluded by " + e.ExcludedBy + ")"
+	default:
+		return "matching (" + location + ", matched by " + e.MatchedBy + ")"
+	}
+}
+
+func (ftf FileTreeFilter) explain(absPath string, baseDir string) (e FilterExplanation) {
+	e.InFileTree = ftf.isInFileTree(absPath, baseDir)
+	for _, dir := range ftf.Directories {
+		filterAbsPath, _ := filepath.Abs(filepath.Join(baseDir, dir))
+		if utils.IsSubPathOf(absPath, filterAbsPath) {
+			e.Directory = dir
+			break
+		}
+	}
+	if !e.InFileTree {
+		return e
+	}
+
+	m, err := ftf.matcher()
+	if err != nil {
+		e.ExcludedBy = err.Error()
+		return e
+	}
+	relPath := relativeSlashedPath(absPath, baseDir)
+	e.MatchedBy = ftf.matchedBy(m, absPath, relPath)
+	if e.MatchedBy == "" {
+		return e
+	}
+	e.ExcludedBy = ftf.excludedBy(m, absPath, relPath, baseDir)
+	e.Matches = e.ExcludedBy == ""
+	return e
+}
+
+func (ftf FileTreeFilter) matchedBy(m *fileTreeMatcher, p string, relPath string) string {
+	if len(m.patterns) == 0 && len(m.globs) == 0 {
+		return "any file (no pattern defined)"
+	}
+	for i, re := range m.patterns {
+		if re.MatchString(p) {
+			return fmt.Sprintf("pattern %s", ftf.FilePatterns[i])
+		}
+	}
+	for i, gm := range m.globs {
+		if gm.matches(relPath) {
+			return fmt.Sprintf("glob %s", ftf.FileGlobs[i])
+		}
+	}
+	return ""
+}
+
+func (ftf FileTreeFilter) excludedBy(m *fileTreeMatcher, p string, relPath string, baseDir string) string {
+	for _, dir := range ftf.ExcludedDirectories {
+		excludedAbsPath, _ := filepath.Abs(filepath.Join(baseDir, dir))
+		if utils.IsSubPathOf(p, excludedAbsPath) {
+			return fmt.Sprintf("excluded directory %s", dir)
+		}
+	}
+	for i, re := range m.excludedPatterns {
+		if re.MatchString(p) {
+			return fmt.Sprintf("excluded pattern %s", ftf.ExcludedPatterns[i])
+		}
+	}
+	for i, gm := range m.excludedGlobs {
+		if gm.matches(relPath) {
+			return fmt.Sprintf("excluded glob %s", ftf.ExcludedGlobs[i])
+		}
+	}
+	if ftf.isGitIgnored(relPath, baseDir, false) {
+		return gitignoreFile
+	}
+	return ""
+}
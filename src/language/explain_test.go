@@ -0,0 +1,149 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package language
+
+import (
+	"github.com/murex/tcr/utils"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+)
+
+func explainTestLanguage(baseDir string) *Language {
+	return ALanguage(
+		WithBaseDir(baseDir),
+		WithSrcFiles(AFileTreeFilter(
+			WithDirectory("src"),
+			WithPattern("(?i)^.*\\.js$"),
+			WithExcludedDirectory("src/generated"),
+		)),
+		WithTestFiles(AFileTreeFilter(
+			WithDirectory("test"),
+			WithGlob("*.test.js"),
+		)),
+	)
+}
+
+func Test_explain_source_file(t *testing.T) {
+	baseDir := absPath("explain-base-dir")
+	e := Explain(explainTestLanguage(baseDir), baseDir, filepath.Join(baseDir, "src", "app.js"))
+	assert.Equal(t, filepath.Join(baseDir, "src"), e.WatchedDir)
+	assert.Equal(t, FilterExplanation{
+		Directory:  "src",
+		InFileTree: true,
+		MatchedBy:  "pattern (?i)^.*\\.js$",
+		Matches:    true,
+	}, e.Src)
+	assert.Equal(t, FilterExplanation{}, e.Test)
+	assert.True(t, e.IsSrcFile)
+	assert.False(t, e.IsTestFile)
+	assert.True(t, e.IsLanguageFile)
+	assert.True(t, e.TriggersCycle())
+	assert.Equal(t, "source file: changes are reverted when build or tests fail", e.RevertBehavior())
+}
+
+func Test_explain_test_file(t *testing.T) {
+	baseDir := absPath("explain-base-dir")
+	e := Explain(explainTestLanguage(baseDir), baseDir, filepath.Join(baseDir, "test", "app.test.js"))
+	assert.Equal(t, FilterExplanation{
+		Directory:  "test",
+		InFileTree: true,
+		MatchedBy:  "glob *.test.js",
+		Matches:    true,
+	}, e.Test)
+	assert.False(t, e.IsSrcFile)
+	assert.True(t, e.IsTestFile)
+	assert.True(t, e.TriggersCycle())
+	assert.Equal(t, "test file: changes are kept when build or tests fail", e.RevertBehavior())
+}
+
+func Test_explain_excluded_file(t *testing.T) {
+	baseDir := absPath("explain-base-dir")
+	e := Explain(explainTestLanguage(baseDir), baseDir, filepath.Join(baseDir, "src", "generated", "api.js"))
+	assert.Equal(t, FilterExplanation{
+		Directory:  "src",
+		InFileTree: true,
+		MatchedBy:  "pattern (?i)^.*\\.js$",
+		ExcludedBy: "excluded directory src/generated",
+	}, e.Src)
+	assert.False(t, e.IsLanguageFile)
+	assert.False(t, e.TriggersCycle())
+	assert.Equal(t, "not a language file: changes are never reverted", e.RevertBehavior())
+}
+
+func Test_explain_file_not_matching_any_pattern(t *testing.T) {
+	baseDir := absPath("explain-base-dir")
+	e := Explain(explainTestLanguage(baseDir), baseDir, filepath.Join(baseDir, "src", "README.md"))
+	assert.Equal(t, FilterExplanation{Directory: "src", InFileTree: true}, e.Src)
+	assert.True(t, e.IsWatched())
+	assert.False(t, e.TriggersCycle())
+}
+
+func Test_explain_file_outside_watched_directories(t *testing.T) {
+	baseDir := absPath("explain-base-dir")
+	e := Explain(explainTestLanguage(baseDir), baseDir, filepath.Join(baseDir, "app.js"))
+	assert.False(t, e.IsWatched())
+	assert.Equal(t, FilterExplanation{}, e.Src)
+	assert.Equal(t, FilterExplanation{}, e.Test)
+	assert.False(t, e.TriggersCycle())
+}
+
+func Test_filter_explanation_string(t *testing.T) {
+	tests := []struct {
+		desc     string
+		e        FilterExplanation
+		expected string
+	}{
+		{"outside file tree", FilterExplanation{},
+			"not matching (outside of filter directories)"},
+		{"no matching pattern", FilterExplanation{Directory: "src", InFileTree: true},
+			"not matching (in directory src, no matching pattern or glob)"},
+		{"excluded", FilterExplanation{Directory: "src", InFileTree: true, MatchedBy: "glob *.js", ExcludedBy: ".gitignore"},
+			"not matching (in directory src, matched by glob *.js, excluded by .gitignore)"},
+		{"matching in base directory", FilterExplanation{InFileTree: true, MatchedBy: "glob *.js", Matches: true},
+			"matching (in base directory, matched by glob *.js)"},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.e.String())
+		})
+	}
+}
+
+func Test_show_file_explanation(t *testing.T) {
+	baseDir := absPath("explain-base-dir")
+	filePath := filepath.Join(baseDir, "src", "app.js")
+	e := Explain(explainTestLanguage(baseDir), baseDir, filePath)
+	expected := []string{
+		filePath + ":",
+		"- watched directory: " + filepath.Join(baseDir, "src"),
+		"- source files filter: matching (in directory src, matched by pattern (?i)^.*\\.js$)",
+		"- test files filter: not matching (outside of filter directories)",
+		"- is source file: true",
+		"- is test file: false",
+		"- is language file: true",
+		"- triggers a TCR cycle when saved: true",
+		"- revert behavior: source file: changes are reverted when build or tests fail",
+	}
+	utils.AssertSimpleTrace(t, expected, e.Show)
+}
@@ -23,6 +23,7 @@ SOFTWARE.
 package language
 
 import (
+	"fmt"
 	"github.com/murex/tcr/utils"
 	"github.com/spf13/afero"
 	"os"
@@ -65,10 +66,37 @@ func (e *UnreachableDirectoryError) IsEmpty() bool {
 
 type (
 	// FileTreeFilter provides filtering mechanisms allowing to determine if a file or directory
-	// is related to a language
+	// is related to a language.
+	// - Directories, FilePatterns and FileGlobs define which files are included.
+	// FilePatterns are regular expressions matched against the file path. FileGlobs are glob
+	// patterns matched against the file path relative to the base directory (or against the
+	// file name when they contain no slash).
+	// - ExcludedDirectories, ExcludedPatterns and ExcludedGlobs define which files are excluded,
+	// even if they are included by the above settings.
+	// - When UseGitignore is set, files ignored by base directory's .gitignore file are also excluded.
 	FileTreeFilter struct {
-		Directories  []string
-		FilePatterns []string
+		Directories         []string
+		FilePatterns        []string
+		FileGlobs           []string
+		ExcludedDirectories []string
+		ExcludedPatterns    []string
+		ExcludedGlobs       []string
+		UseGitignore        bool
+		compiled            *compiledFilter
+	}
+
+	// compiledFilter contains the result of compiling a FileTreeFilter's patterns and globs
+	compiledFilter struct {
+		matcher *fileTreeMatcher
+		err     error
+	}
+
+	// fileTreeMatcher contains the compiled patterns of a FileTreeFilter
+	fileTreeMatcher struct {
+		patterns         []*regexp.Regexp
+		globs            []globMatcher
+		excludedPatterns []*regexp.Regexp
+		excludedGlobs    []globMatcher
 	}
 )
 
@@ -98,26 +126,160 @@ func (ftf FileTreeFilter) isInFileTree(aPath string, baseDir string) bool {
 	return false
 }
 
+func (ftf FileTreeFilter) isInExcludedDir(absPath string, baseDir string) bool {
+	for _, dir := range ftf.ExcludedDirectories {
+		excludedAbsPath, _ := filepath.Abs(filepath.Join(baseDir, dir))
+		if utils.IsSubPathOf(absPath, excludedAbsPath) {
+			return true
+		}
+	}
+	return false
+}
+
 func (ftf FileTreeFilter) matches(p string, baseDir string) bool {
 	if p == "" {
 		return false
 	}
-	if ftf.isInFileTree(p, baseDir) {
-		// If no pattern is set, any file matches as long as it's in the file tree
-		if ftf.FilePatterns == nil || len(ftf.FilePatterns) == 0 {
+	m, err := ftf.matcher()
+	if err != nil {
+		return false
+	}
+	if !ftf.isInFileTree(p, baseDir) {
+		return false
+	}
+	relPath := relativeSlashedPath(p, baseDir)
+	if !m.includes(p, relPath) {
+		return false
+	}
+	absPath, _ := filepath.Abs(p)
+	if ftf.isInExcludedDir(absPath, baseDir) || m.excludes(p, relPath) {
+		return false
+	}
+	return !ftf.isGitIgnored(relPath, baseDir, false)
+}
+
+// isExcludedDir indicates if the provided directory and all its contents are excluded from the file tree
+func (ftf FileTreeFilter) isExcludedDir(p string, baseDir string) bool {
+	absPath, _ := filepath.Abs(p)
+	return ftf.isInExcludedDir(absPath, baseDir) ||
+		ftf.isGitIgnored(relativeSlashedPath(p, baseDir), baseDir, true)
+}
+
+func (ftf FileTreeFilter) isGitIgnored(relPath string, baseDir string, isDir bool) bool {
+	if !ftf.UseGitignore || relPath == "." {
+		return false
+	}
+	return loadGitignore(baseDir).ignores(relPath, isDir)
+}
+
+func relativeSlashedPath(p string, baseDir string) string {
+	absPath, _ := filepath.Abs(p)
+	absBaseDir, _ := filepath.Abs(baseDir)
+	relPath, err := filepath.Rel(absBaseDir, absPath)
+	if err != nil {
+		return filepath.ToSlash(p)
+	}
+	return filepath.ToSlash(relPath)
+}
+
+// check verifies that all patterns and globs of the filter are valid
+func (ftf FileTreeFilter) check() error {
+	_, err := ftf.matcher()
+	return err
+}
+
+// withMatcher returns a copy of the filter with its patterns and globs compiled once
+// and for all, so that they do not need to be compiled again each time a file is matched
+func (ftf FileTreeFilter) withMatcher() FileTreeFilter {
+	m, err := ftf.compile()
+	ftf.compiled = &compiledFilter{matcher: m, err: err}
+	return ftf
+}
+
+// matcher returns the compiled matcher for this filter. Filters that were not
+// compiled beforehand through withMatcher() are compiled on the fly
+func (ftf FileTreeFilter) matcher() (*fileTreeMatcher, error) {
+	if ftf.compiled != nil {
+		return ftf.compiled.matcher, ftf.compiled.err
+	}
+	return ftf.compile()
+}
+
+func (ftf FileTreeFilter) compile() (m *fileTreeMatcher, err error) {
+	m = &fileTreeMatcher{}
+	if m.patterns, err = compilePatterns(ftf.FilePatterns); err != nil {
+		return nil, err
+	}
+	if m.globs, err = compileGlobs(ftf.FileGlobs); err != nil {
+		return nil, err
+	}
+	if m.excludedPatterns, err = compilePatterns(ftf.ExcludedPatterns); err != nil {
+		return nil, err
+	}
+	if m.excludedGlobs, err = compileGlobs(ftf.ExcludedGlobs); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func compilePatterns(patterns []string) (compiled []*regexp.Regexp, err error) {
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid file pattern %s: %w", pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+func compileGlobs(globs []string) (compiled []globMatcher, err error) {
+	for _, glob := range globs {
+		gm, err := compileGlob(glob)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, gm)
+	}
+	return compiled, nil
+}
+
+// includes indicates if the file matches one of the inclusion patterns or globs.
+// If no pattern or glob is set, any file matches
+func (m *fileTreeMatcher) includes(p string, relPath string) bool {
+	if len(m.patterns) == 0 && len(m.globs) == 0 {
+		return true
+	}
+	return matchesAnyPattern(m.patterns, p) || matchesAnyGlob(m.globs, relPath)
+}
+
+// excludes indicates if the file matches one of the exclusion patterns or globs
+func (m *fileTreeMatcher) excludes(p string, relPath string) bool {
+	return matchesAnyPattern(m.excludedPatterns, p) || matchesAnyGlob(m.excludedGlobs, relPath)
+}
+
+func matchesAnyPattern(patterns []*regexp.Regexp, p string) bool {
+	for _, re := range patterns {
+		if re.MatchString(p) {
 			return true
 		}
-		for _, filter := range ftf.FilePatterns {
-			re := regexp.MustCompile(filter)
-			if re.MatchString(p) {
-				return true
-			}
+	}
+	return false
+}
+
+func matchesAnyGlob(globs []globMatcher, relPath string) bool {
+	for _, gm := range globs {
+		if gm.matches(relPath) {
+			return true
 		}
 	}
 	return false
 }
 
 func (ftf FileTreeFilter) findAllMatchingFiles(baseDir string) (files []string, err error) {
+	if ftf.compiled == nil {
+		ftf = ftf.withMatcher()
+	}
 	dirErr := UnreachableDirectoryError{}
 
 	for _, dir := range ftf.Directories {
@@ -142,6 +304,9 @@ func (ftf FileTreeFilter) findMatchingFilesInDir(baseDir string, dir string) (fi
 				return err
 			}
 			if fi.IsDir() {
+				if ftf.isExcludedDir(path, baseDir) {
+					return filepath.SkipDir
+				}
 				return nil
 			}
 			if ftf.matches(path, baseDir) {
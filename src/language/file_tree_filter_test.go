@@ -113,6 +113,97 @@ func Test_find_all_matching_files(t *testing.T) {
 	assert.NotContains(t, files, nonMatchingDir)
 }
 
+func Test_file_tree_filter_with_glob(t *testing.T) {
+	filter := AFileTreeFilter(WithGlob("**/*.ext"))
+	assert.True(t, filter.matches("base.ext", ""))
+	assert.True(t, filter.matches(filepath.Join("some_dir", "base.ext"), ""))
+	assert.False(t, filter.matches("base.other_ext", ""))
+}
+
+func Test_file_tree_filter_with_pattern_and_glob(t *testing.T) {
+	filter := AFileTreeFilter(WithPattern(".*\\.ext1"), WithGlob("*.ext2"))
+	assert.True(t, filter.matches("base.ext1", ""))
+	assert.True(t, filter.matches("base.ext2", ""))
+	assert.False(t, filter.matches("base.ext3", ""))
+}
+
+func Test_file_tree_filter_with_excluded_directory(t *testing.T) {
+	filter := AFileTreeFilter(WithDirectory("src"), WithOpenPattern(), WithExcludedDirectory("src/generated"))
+	assert.True(t, filter.matches(filepath.Join("src", "base.ext"), ""))
+	assert.False(t, filter.matches(filepath.Join("src", "generated", "base.ext"), ""))
+	assert.False(t, filter.matches(filepath.Join("src", "generated", "sub", "base.ext"), ""))
+}
+
+func Test_file_tree_filter_with_excluded_pattern(t *testing.T) {
+	filter := AFileTreeFilter(WithPattern(".*\\.ts"), WithExcludedPattern(".*\\.d\\.ts"))
+	assert.True(t, filter.matches("base.ts", ""))
+	assert.False(t, filter.matches("base.d.ts", ""))
+}
+
+func Test_file_tree_filter_with_excluded_glob(t *testing.T) {
+	filter := AFileTreeFilter(WithGlob("*.go"), WithExcludedGlob("**/*_gen.go"))
+	assert.True(t, filter.matches(filepath.Join("pkg", "base.go"), ""))
+	assert.False(t, filter.matches(filepath.Join("pkg", "base_gen.go"), ""))
+}
+
+func Test_file_tree_filter_with_invalid_pattern(t *testing.T) {
+	filter := AFileTreeFilter(WithPattern("(unclosed"))
+	assert.Error(t, filter.check())
+	assert.False(t, filter.matches("base.ext", ""))
+}
+
+func Test_file_tree_filter_with_invalid_excluded_glob(t *testing.T) {
+	filter := AFileTreeFilter(WithExcludedGlob("file[z-a].txt"))
+	assert.Error(t, filter.check())
+}
+
+func Test_file_tree_filter_matcher_is_compiled_once(t *testing.T) {
+	filter := AFileTreeFilter(WithGlob("*.ext")).withMatcher()
+	m1, err := filter.matcher()
+	assert.NoError(t, err)
+	m2, _ := filter.matcher()
+	assert.Same(t, m1, m2)
+	assert.True(t, filter.matches("base.ext", ""))
+}
+
+func Test_file_tree_filter_with_gitignore(t *testing.T) {
+	baseDir := absPath("gitignore-base-dir")
+	setupProjectFiles(baseDir)
+	_ = afero.WriteFile(appFS, filepath.Join(baseDir, gitignoreFile), []byte("node_modules/\n*.log\n"), 0644)
+
+	filter := AFileTreeFilter(WithOpenPattern(), WithGitignore())
+	assert.True(t, filter.matches(filepath.Join(baseDir, "src", "index.js"), baseDir))
+	assert.False(t, filter.matches(filepath.Join(baseDir, "node_modules", "lib", "index.js"), baseDir))
+	assert.False(t, filter.matches(filepath.Join(baseDir, "src", "debug.log"), baseDir))
+
+	filter = AFileTreeFilter(WithOpenPattern())
+	assert.True(t, filter.matches(filepath.Join(baseDir, "node_modules", "lib", "index.js"), baseDir))
+}
+
+func Test_find_all_matching_files_skips_excluded_directories(t *testing.T) {
+	appFS = afero.NewMemMapFs()
+	baseDir := filepath.Join("base-dir")
+	srcDir := filepath.Join(baseDir, "src")
+	included := filepath.Join(srcDir, "file.ext")
+	_ = afero.WriteFile(appFS, included, []byte("some contents"), 0644)
+	excluded := filepath.Join(srcDir, "vendor", "file.ext")
+	_ = afero.WriteFile(appFS, excluded, []byte("some contents"), 0644)
+	ignored := filepath.Join(srcDir, "node_modules", "file.ext")
+	_ = afero.WriteFile(appFS, ignored, []byte("some contents"), 0644)
+	_ = afero.WriteFile(appFS, filepath.Join(baseDir, gitignoreFile), []byte("node_modules/\n"), 0644)
+
+	filter := AFileTreeFilter(WithDirectory("src"), WithGlob("*.ext"),
+		WithExcludedDirectory(filepath.Join("src", "vendor")), WithGitignore())
+	files, err := filter.findAllMatchingFiles(baseDir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{included}, files)
+}
+
+func absPath(p string) string {
+	abs, _ := filepath.Abs(p)
+	return abs
+}
+
 func Test_find_all_matching_files_with_wrong_base_dir(t *testing.T) {
 	appFS = afero.NewMemMapFs()
 	baseDir := filepath.Join("base-dir")
@@ -88,3 +88,38 @@ func WithNoPattern() func(filter *FileTreeFilter) {
 		filter.FilePatterns = nil
 	}
 }
+
+// WithGlob adds the provided glob pattern to the list of glob patterns recognized by the created FileTreeFilter
+func WithGlob(glob string) func(filter *FileTreeFilter) {
+	return func(filter *FileTreeFilter) {
+		filter.FileGlobs = append(filter.FileGlobs, glob)
+	}
+}
+
+// WithExcludedDirectory adds the provided dirName to the list of directories excluded by the created FileTreeFilter
+func WithExcludedDirectory(dirName string) func(filter *FileTreeFilter) {
+	return func(filter *FileTreeFilter) {
+		filter.ExcludedDirectories = append(filter.ExcludedDirectories, dirName)
+	}
+}
+
+// WithExcludedPattern adds the provided pattern to the list of filename patterns excluded by the created FileTreeFilter
+func WithExcludedPattern(pattern string) func(filter *FileTreeFilter) {
+	return func(filter *FileTreeFilter) {
+		filter.ExcludedPatterns = append(filter.ExcludedPatterns, pattern)
+	}
+}
+
+// WithExcludedGlob adds the provided glob pattern to the list of glob patterns excluded by the created FileTreeFilter
+func WithExcludedGlob(glob string) func(filter *FileTreeFilter) {
+	return func(filter *FileTreeFilter) {
+		filter.ExcludedGlobs = append(filter.ExcludedGlobs, glob)
+	}
+}
+
+// WithGitignore enables .gitignore awareness in the created FileTreeFilter
+func WithGitignore() func(filter *FileTreeFilter) {
+	return func(filter *FileTreeFilter) {
+		filter.UseGitignore = true
+	}
+}
@@ -0,0 +1,172 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package language
+
+import (
+	"fmt"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/spf13/afero"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const gitignoreFile = ".gitignore"
+
+type (
+	// globMatcher is a compiled glob pattern.
+	// When the glob contains no slash, it is matched against the file's base name.
+	// Otherwise, it is matched against the file path relative to the base directory
+	globMatcher struct {
+		re       *regexp.Regexp
+		nameOnly bool
+	}
+
+	// gitignoreRules contains the rules found in a .gitignore file, along with the
+	// modification time and size of the file when these rules were loaded
+	gitignoreRules struct {
+		matcher gitignore.Matcher
+		modTime time.Time
+		size    int64
+	}
+)
+
+// gitignores keeps track of .gitignore rules already loaded, per base directory.
+// Rules are loaded again when the .gitignore file changes
+var gitignores sync.Map
+
+// compileGlob converts a glob pattern into a globMatcher. Supported wildcards are:
+// - "*" matching any sequence of characters except "/"
+// - "**" matching any sequence of characters including "/"
+// - "?" matching any single character except "/"
+// - "[...]" matching a character class ("[!...]" for a negated class)
+func compileGlob(glob string) (globMatcher, error) {
+	slashed := toSlashedGlob(glob)
+	re, err := regexp.Compile(globToRegexp(strings.TrimPrefix(slashed, "/")))
+	if err != nil {
+		return globMatcher{}, fmt.Errorf("invalid glob pattern %s: %w", glob, err)
+	}
+	return globMatcher{re: re, nameOnly: !strings.Contains(slashed, "/")}, nil
+}
+
+// matches indicates if the provided slashed relative path matches the glob
+func (gm globMatcher) matches(relPath string) bool {
+	if gm.nameOnly {
+		return gm.re.MatchString(path.Base(relPath))
+	}
+	return gm.re.MatchString(relPath)
+}
+
+func toSlashedGlob(glob string) string {
+	return strings.ReplaceAll(glob, "\\", "/")
+}
+
+func globToRegexp(glob string) string {
+	var sb strings.Builder
+	_, _ = sb.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch {
+		case c == '*' && strings.HasPrefix(glob[i:], "**/"):
+			_, _ = sb.WriteString("(?:.*/)?")
+			i += 2
+		case c == '*' && strings.HasPrefix(glob[i:], "**"):
+			_, _ = sb.WriteString(".*")
+			i++
+		case c == '*':
+			_, _ = sb.WriteString("[^/]*")
+		case c == '?':
+			_, _ = sb.WriteString("[^/]")
+		case c == '[' && strings.IndexByte(glob[i:], ']') > 1:
+			end := i + strings.IndexByte(glob[i:], ']')
+			class := glob[i+1 : end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			_, _ = sb.WriteString("[" + class + "]")
+			i = end
+		default:
+			_, _ = sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	_, _ = sb.WriteString("$")
+	return sb.String()
+}
+
+// parseGitignore parses the contents of a .gitignore file
+func parseGitignore(contents string) (g gitignoreRules) {
+	var patterns []gitignore.Pattern
+	for _, line := range strings.Split(contents, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.HasPrefix(line, "#") || strings.TrimSpace(line) == "" {
+			continue
+		}
+		patterns = append(patterns, gitignore.ParsePattern(line, nil))
+	}
+	g.matcher = gitignore.NewMatcher(patterns)
+	return g
+}
+
+// ignores indicates if the provided slashed relative path is ignored by .gitignore rules.
+// As with git, a file cannot be re-included if one of its parent directories is ignored
+func (g gitignoreRules) ignores(relPath string, isDir bool) bool {
+	if g.matcher == nil {
+		return false
+	}
+	parts := strings.Split(relPath, "/")
+	for i := 1; i < len(parts); i++ {
+		if g.matcher.Match(parts[:i], true) {
+			return true
+		}
+	}
+	return g.matcher.Match(parts, isDir)
+}
+
+// loadGitignore returns the rules of the .gitignore file located in baseDir.
+// Rules are loaded again when the file's modification time or size changed since
+// they were last loaded. An empty set of rules is returned when there is no .gitignore file
+func loadGitignore(baseDir string) gitignoreRules {
+	filePath := filepath.Join(baseDir, gitignoreFile)
+	info, err := appFS.Stat(filePath)
+	if err != nil {
+		return gitignoreRules{}
+	}
+	key, _ := filepath.Abs(baseDir)
+	if cached, found := gitignores.Load(key); found {
+		g := cached.(gitignoreRules)
+		if g.modTime.Equal(info.ModTime()) && g.size == info.Size() {
+			return g
+		}
+	}
+	contents, err := afero.ReadFile(appFS, filePath)
+	if err != nil {
+		return gitignoreRules{}
+	}
+	g := parseGitignore(string(contents))
+	g.modTime, g.size = info.ModTime(), info.Size()
+	gitignores.Store(key, g)
+	return g
+}
@@ -0,0 +1,116 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package language
+
+import (
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+)
+
+func Test_glob_matching(t *testing.T) {
+	tests := []struct {
+		glob     string
+		path     string
+		expected bool
+	}{
+		{"*.go", "main.go", true},
+		{"*.go", "some/dir/main.go", true},
+		{"*.go", "main.go.swp", false},
+		{"src/*.go", "src/main.go", true},
+		{"src/*.go", "src/sub/main.go", false},
+		{"src/**/*.go", "src/main.go", true},
+		{"src/**/*.go", "src/sub/dir/main.go", true},
+		{"src/**", "src/sub/dir/main.go", true},
+		{"**/generated/*.ts", "generated/api.ts", true},
+		{"**/generated/*.ts", "src/generated/api.ts", true},
+		{"/src/*.go", "src/main.go", true},
+		{"file?.txt", "file1.txt", true},
+		{"file?.txt", "file10.txt", false},
+		{"file[0-9].txt", "file5.txt", true},
+		{"file[!0-9].txt", "file5.txt", false},
+		{"file[!0-9].txt", "fileA.txt", true},
+		{"a+b.txt", "a+b.txt", true},
+		{"a+b.txt", "aab.txt", false},
+		{"src\\*.go", "src/main.go", true},
+	}
+	for _, test := range tests {
+		t.Run(test.glob+" "+test.path, func(t *testing.T) {
+			gm, err := compileGlob(test.glob)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, gm.matches(test.path))
+		})
+	}
+}
+
+func Test_gitignore_rules(t *testing.T) {
+	g := parseGitignore(`
+# comment line
+node_modules/
+*.log
+!important.log
+/build
+docs/**/*.tmp
+`)
+	tests := []struct {
+		path     string
+		isDir    bool
+		expected bool
+	}{
+		{"node_modules", true, true},
+		{"node_modules/lib/index.js", false, true},
+		{"sub/node_modules/index.js", false, true},
+		{"node_modules", false, false},
+		{"debug.log", false, true},
+		{"sub/debug.log", false, true},
+		{"important.log", false, false},
+		{"build/out.js", false, true},
+		{"sub/build/out.js", false, false},
+		{"docs/a/b/c.tmp", false, true},
+		{"docs/c.tmp", false, true},
+		{"src/main.js", false, false},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			assert.Equal(t, test.expected, g.ignores(test.path, test.isDir))
+		})
+	}
+}
+
+func Test_gitignore_rules_are_empty_when_no_gitignore_file(t *testing.T) {
+	setupProjectFiles("no-gitignore")
+	assert.Equal(t, gitignoreRules{}, loadGitignore("no-gitignore"))
+}
+
+func Test_gitignore_rules_are_reloaded_when_gitignore_file_changes(t *testing.T) {
+	baseDir := absPath("changing-gitignore")
+	setupProjectFiles(baseDir)
+	gitignorePath := filepath.Join(baseDir, gitignoreFile)
+	_ = afero.WriteFile(appFS, gitignorePath, []byte("*.log\n"), 0644)
+	assert.True(t, loadGitignore(baseDir).ignores("debug.log", false))
+	assert.False(t, loadGitignore(baseDir).ignores("out.tmp", false))
+
+	_ = afero.WriteFile(appFS, gitignorePath, []byte("*.log\n*.tmp\n"), 0644)
+	assert.True(t, loadGitignore(baseDir).ignores("out.tmp", false))
+}
@@ -44,6 +44,9 @@ type (
 		srcFileFilter  FileTreeFilter
 		testFileFilter FileTreeFilter
 		baseDir        string
+		// detectedToolchain is the toolchain detected from project files, if any.
+		// When set, it takes precedence over the language's default toolchain
+		detectedToolchain string
 	}
 
 	// LangInterface provides the interface for interacting with a language
@@ -62,7 +65,10 @@ type (
 		checkName() error
 		checkCompatibleToolchains() error
 		checkDefaultToolchain() error
+		checkFileTreeFilters() error
 		setBaseDir(dir string)
+		setDetectedToolchain(toolchainName string)
+		clone() LangInterface
 		worksWithToolchain(toolchainName string) bool
 	}
 )
@@ -72,8 +78,8 @@ func New(name string, toolchains Toolchains, srcFiles FileTreeFilter, testFiles
 	return &Language{
 		name:           name,
 		toolchains:     toolchains,
-		srcFileFilter:  srcFiles,
-		testFileFilter: testFiles,
+		srcFileFilter:  srcFiles.withMatcher(),
+		testFileFilter: testFiles.withMatcher(),
 	}
 }
 
@@ -106,6 +112,16 @@ func (lang *Language) checkDefaultToolchain() error {
 	return nil
 }
 
+func (lang *Language) checkFileTreeFilters() error {
+	if err := lang.srcFileFilter.check(); err != nil {
+		return fmt.Errorf("language source files: %w", err)
+	}
+	if err := lang.testFileFilter.check(); err != nil {
+		return fmt.Errorf("language test files: %w", err)
+	}
+	return nil
+}
+
 // GetSrcFileFilter provides the language's list of filters for source files
 func (lang *Language) GetSrcFileFilter() FileTreeFilter {
 	return lang.srcFileFilter
@@ -162,7 +178,8 @@ func (lang *Language) GetToolchains() Toolchains {
 // GetToolchain returns the toolchain instance for this language.
 // - If toolchainName is provided and is compatible with this language, it will be returned.
 // - If toolchainName is provided but is not compatible with this language, an error is returned.
-// - If toolchainName is not provided, the language's default toolchain is returned.
+// - If toolchainName is not provided, the toolchain detected from project files is returned if any,
+// otherwise the language's default toolchain is returned.
 func (lang *Language) GetToolchain(toolchainName string) (tchn toolchain.TchnInterface, err error) {
 	// We first retrieve the toolchain
 	if toolchainName != "" {
@@ -172,8 +189,8 @@ func (lang *Language) GetToolchain(toolchainName string) (tchn toolchain.TchnInt
 			return nil, err
 		}
 	} else {
-		// If no toolchain is specified, we use the default toolchain for this language
-		tchn, err = toolchain.Get(lang.GetToolchains().Default)
+		// If no toolchain is specified, we use the detected or default toolchain for this language
+		tchn, err = toolchain.Get(lang.defaultToolchainName())
 		if err != nil {
 			return nil, err
 		}
@@ -187,6 +204,14 @@ func (lang *Language) GetToolchain(toolchainName string) (tchn toolchain.TchnInt
 	return tchn, nil
 }
 
+// defaultToolchainName returns the name of the toolchain to be used when none is specified
+func (lang *Language) defaultToolchainName() string {
+	if lang.detectedToolchain != "" {
+		return lang.detectedToolchain
+	}
+	return lang.GetToolchains().Default
+}
+
 func (lang *Language) verifyCompatibility(tchn toolchain.TchnInterface) (bool, error) {
 	if tchn == nil {
 		return false, errors.New("toolchain is unknown")
@@ -247,3 +272,12 @@ func (lang *Language) setBaseDir(dir string) {
 	// Warning (for tests only): filepath.Abs() does not work with MemMapFs on Windows
 	lang.baseDir, _ = filepath.Abs(dir)
 }
+
+func (lang *Language) clone() LangInterface {
+	c := *lang
+	return &c
+}
+
+func (lang *Language) setDetectedToolchain(toolchainName string) {
+	lang.detectedToolchain = toolchainName
+}
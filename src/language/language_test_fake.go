@@ -140,10 +140,23 @@ func (fl *FakeLanguage) checkDefaultToolchain() error {
 	return fl.lang.checkDefaultToolchain()
 }
 
+func (fl *FakeLanguage) checkFileTreeFilters() error {
+	return fl.lang.checkFileTreeFilters()
+}
+
 func (fl *FakeLanguage) setBaseDir(dir string) {
 	fl.lang.setBaseDir(dir)
 }
 
+func (fl *FakeLanguage) clone() LangInterface {
+	c := *fl
+	return &c
+}
+
+func (fl *FakeLanguage) setDetectedToolchain(toolchainName string) {
+	fl.lang.setDetectedToolchain(toolchainName)
+}
+
 func (fl *FakeLanguage) worksWithToolchain(toolchainName string) bool {
 	return fl.lang.worksWithToolchain(toolchainName)
 }
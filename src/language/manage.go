@@ -0,0 +1,189 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package language
+
+import (
+	"errors"
+	"fmt"
+	"github.com/murex/tcr/toolchain"
+	"github.com/murex/tcr/utils"
+	"github.com/spf13/afero"
+	"slices"
+)
+
+// AddOptions contains the settings of a language created through Add.
+// - Template is the name of an existing language used as a starting point. Optional.
+// - Toolchains replaces the template's list of compatible toolchains.
+// - DefaultToolchain replaces the template's default toolchain. When not provided and the template's
+// default toolchain is not part of the compatible toolchains, the first compatible toolchain is used.
+// - SrcDirs, SrcPatterns, TestDirs and TestPatterns replace the template's source and test files filters.
+// Settings left empty are taken from the template
+type AddOptions struct {
+	Template         string
+	Toolchains       []string
+	DefaultToolchain string
+	SrcDirs          []string
+	SrcPatterns      []string
+	TestDirs         []string
+	TestPatterns     []string
+}
+
+// maxListedFiles is the maximum number of matching files listed by Try for each kind of file
+const maxListedFiles = 10
+
+const builtInOrigin = "built-in"
+
+// ShowList shows the list of available languages, together with where each one is defined
+func ShowList() {
+	utils.Trace("Available languages:")
+	for _, name := range Names() {
+		utils.TraceKeyValue(name, definitionOrigin(name))
+	}
+}
+
+// definitionOrigin tells where the language with the provided name is defined
+func definitionOrigin(name string) string {
+	filename := utils.BuildYAMLFilePath(languageDirPath, name)
+	if exists, _ := afero.Exists(appFS, filename); !exists {
+		return builtInOrigin
+	}
+	if isBuiltIn(name) {
+		return builtInOrigin + ", customized in " + filename
+	}
+	return filename
+}
+
+// ShowConfig shows the configuration of the language with the provided name
+func ShowConfig(name string) error {
+	lang, err := Get(name)
+	if err != nil {
+		return err
+	}
+	origin := definitionOrigin(lang.GetName())
+	utils.Trace("Language ", lang.GetName(), " (", origin, "):")
+	if origin != builtInOrigin {
+		if cfg, err := readConfig(utils.BuildYAMLFilename(lang.GetName())); err == nil {
+			cfg.show()
+			return nil
+		}
+	}
+	asConfig(lang).show()
+	return nil
+}
+
+// Add creates a new language with the provided name and options, and saves its configuration
+// in language configuration directory
+func Add(name string, opts AddOptions) error {
+	if name == "" {
+		return errors.New("language name not provided")
+	}
+	if _, err := Get(name); err == nil {
+		return fmt.Errorf("language already exists: %s", name)
+	}
+	var cfg configYAML
+	if opts.Template != "" {
+		tmpl, err := Get(opts.Template)
+		if err != nil {
+			return err
+		}
+		cfg = asConfig(tmpl)
+	}
+	cfg.Name = name
+	overrideToolchains(&cfg.Toolchains, opts.Toolchains, opts.DefaultToolchain)
+	overrideFilter(&cfg.SourceFiles, opts.SrcDirs, opts.SrcPatterns)
+	overrideFilter(&cfg.TestFiles, opts.TestDirs, opts.TestPatterns)
+	for _, tchn := range cfg.Toolchains.Compatible {
+		if _, err := toolchain.Get(tchn); err != nil {
+			return fmt.Errorf("invalid configuration for language %s: %w", name, err)
+		}
+	}
+	if err := Register(asLanguage(cfg)); err != nil {
+		return fmt.Errorf("invalid configuration for language %s: %w", name, err)
+	}
+	createConfigDir()
+	filename := utils.BuildYAMLFilePath(languageDirPath, name)
+	utils.Trace("Creating language configuration: ", filename)
+	utils.SaveToYAMLFile(appFS, cfg, filename)
+	return nil
+}
+
+func overrideToolchains(cfg *toolchainConfigYAML, compatible []string, defaultToolchain string) {
+	if compatible != nil {
+		cfg.Compatible = compatible
+	}
+	if defaultToolchain != "" {
+		cfg.Default = defaultToolchain
+	} else if !slices.Contains(cfg.Compatible, cfg.Default) && len(cfg.Compatible) > 0 {
+		cfg.Default = cfg.Compatible[0]
+	}
+}
+
+func overrideFilter(cfg *fileTreeFilterConfigYAML, dirs []string, patterns []string) {
+	if dirs != nil {
+		cfg.Directories = dirs
+	}
+	if patterns != nil {
+		cfg.FilePatterns = patterns
+	}
+}
+
+// Try applies the source and test files filters of the language with the provided name to
+// the provided base directory, and reports the matching files.
+// It returns an error if no source file or no test file is found
+func Try(name string, baseDir string) error {
+	if _, err := Get(name); err != nil {
+		return err
+	}
+	lang, err := GetLanguage(name, baseDir)
+	if err != nil {
+		return err
+	}
+	srcFiles, err := lang.AllSrcFiles()
+	if err != nil {
+		return err
+	}
+	testFiles, err := lang.AllTestFiles()
+	if err != nil {
+		return err
+	}
+	traceMatchingFiles("source", srcFiles)
+	traceMatchingFiles("test", testFiles)
+	switch {
+	case len(srcFiles) == 0:
+		return fmt.Errorf("no %s source file found in %s", lang.GetName(), baseDir)
+	case len(testFiles) == 0:
+		return fmt.Errorf("no %s test file found in %s", lang.GetName(), baseDir)
+	}
+	return nil
+}
+
+func traceMatchingFiles(kind string, files []string) {
+	utils.Trace("Found ", len(files), " ", kind, " files")
+	for i, file := range files {
+		if i == maxListedFiles {
+			utils.Trace("- ... (", len(files)-maxListedFiles, " more)")
+			break
+		}
+		utils.Trace("- ", file)
+	}
+}
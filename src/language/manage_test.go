@@ -0,0 +1,131 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package language
+
+import (
+	"bytes"
+	"github.com/murex/tcr/utils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"path/filepath"
+	"testing"
+)
+
+func Test_show_language_list(t *testing.T) {
+	setupLanguageConfigDir(t)
+	var output bytes.Buffer
+	utils.SetSimpleTrace(&output)
+	ShowList()
+	assert.Contains(t, output.String(), "Available languages:")
+	assert.Contains(t, output.String(), "- java: built-in\n")
+}
+
+func Test_show_single_language_config(t *testing.T) {
+	setupLanguageConfigDir(t)
+	var output bytes.Buffer
+	utils.SetSimpleTrace(&output)
+	assert.NoError(t, ShowConfig("java"))
+	assert.Contains(t, output.String(), "Language java (built-in):")
+	assert.Contains(t, output.String(), "- language.java.toolchains.default: gradle-wrapper")
+}
+
+func Test_show_unknown_language_config(t *testing.T) {
+	setupLanguageConfigDir(t)
+	assert.Error(t, ShowConfig("unknown"))
+}
+
+func Test_add_language_from_template(t *testing.T) {
+	setupLanguageConfigDir(t)
+	utils.SetSimpleTrace(io.Discard)
+	t.Cleanup(func() { delete(registered, "groovy") })
+	assert.NoError(t, Add("groovy", AddOptions{
+		Template:     "java",
+		Toolchains:   []string{"gradle", "maven"},
+		SrcPatterns:  []string{`(?i)^.*\.groovy$`},
+		TestPatterns: []string{`(?i)^.*Test\.groovy$`},
+	}))
+
+	lang, err := Get("groovy")
+	assert.NoError(t, err)
+	assert.Equal(t, Toolchains{Default: "gradle", Compatible: []string{"gradle", "maven"}}, lang.GetToolchains())
+	assert.Equal(t, []string{`(?i)^.*\.groovy$`}, lang.GetSrcFileFilter().FilePatterns)
+	assert.Equal(t, asConfig(getBuiltIn("java")).SourceFiles.Directories, lang.GetSrcFileFilter().Directories)
+	exists, _ := afero.Exists(appFS, utils.BuildYAMLFilePath(languageDirPath, "groovy"))
+	assert.True(t, exists)
+}
+
+func Test_add_language_errors(t *testing.T) {
+	testFlags := []struct {
+		desc string
+		name string
+		opts AddOptions
+	}{
+		{"no name", "", AddOptions{Template: "java"}},
+		{"existing language", "java", AddOptions{Template: "kotlin"}},
+		{"unknown template", "my-language", AddOptions{Template: "unknown"}},
+		{"unknown toolchain", "my-language", AddOptions{Template: "java", Toolchains: []string{"unknown"}}},
+		{"incompatible default toolchain", "my-language",
+			AddOptions{Template: "java", Toolchains: []string{"maven"}, DefaultToolchain: "gradle"}},
+		{"no toolchain", "my-language", AddOptions{SrcDirs: []string{"src"}}},
+	}
+	for _, tt := range testFlags {
+		t.Run(tt.desc, func(t *testing.T) {
+			setupLanguageConfigDir(t)
+			assert.Error(t, Add(tt.name, tt.opts))
+			assert.False(t, isSupported("my-language"))
+		})
+	}
+}
+
+func Test_try_language(t *testing.T) {
+	testFlags := []struct {
+		desc        string
+		files       []string
+		expectError bool
+	}{
+		{"with source and test files", []string{"main.go", "main_test.go"}, false},
+		{"without test files", []string{"main.go"}, true},
+		{"without source files", []string{"main_test.go"}, true},
+	}
+	for _, tt := range testFlags {
+		t.Run(tt.desc, func(t *testing.T) {
+			setupLanguageConfigDir(t)
+			utils.SetSimpleTrace(io.Discard)
+			baseDir, _ := filepath.Abs(filepath.FromSlash("/project"))
+			for _, file := range tt.files {
+				_ = afero.WriteFile(appFS, filepath.Join(baseDir, file), []byte("package main"), 0644)
+			}
+			err := Try("go", baseDir)
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_try_unknown_language(t *testing.T) {
+	assert.Error(t, Try("unknown", "."))
+}
@@ -46,6 +46,9 @@ func Register(lang LangInterface) error {
 	if err := lang.checkDefaultToolchain(); err != nil {
 		return err
 	}
+	if err := lang.checkFileTreeFilters(); err != nil {
+		return err
+	}
 	registered[strings.ToLower(lang.GetName())] = lang
 	return nil
 }
@@ -109,16 +112,36 @@ func addBuiltIn(lang LangInterface) error {
 }
 
 // GetLanguage returns the language to be used in current session. If no value is provided
-// for language (e.g. empty string), we try to detect the language based on the directory name.
+// for language (e.g. empty string), we try to detect the language based on the directory name
+// and on project files found in baseDir (cf. Detect). When detection also identifies a toolchain,
+// this toolchain is used instead of the language's default toolchain.
 // Both name and baseDir are case-insensitive
 func GetLanguage(name string, baseDir string) (lang LangInterface, err error) {
+	return getLanguage(name, baseDir, false)
+}
+
+// GetLanguageInstance works as GetLanguage, but returns a copy of the language rather than
+// the registered language itself. This allows using the same language with several base directories
+func GetLanguageInstance(name string, baseDir string) (lang LangInterface, err error) {
+	return getLanguage(name, baseDir, true)
+}
+
+func getLanguage(name string, baseDir string, ownInstance bool) (lang LangInterface, err error) {
+	detectedToolchain := ""
 	if name != "" {
 		lang, err = getRegisteredLanguage(name)
+	} else if d := Detect(baseDir); d.Language != "" {
+		lang, err = getRegisteredLanguage(d.Language)
+		detectedToolchain = d.Toolchain
 	} else {
 		lang, err = detectLanguageFromDirName(baseDir)
 	}
 	if lang != nil {
+		if ownInstance {
+			lang = lang.clone()
+		}
 		lang.setBaseDir(baseDir)
+		lang.setDetectedToolchain(detectedToolchain)
 	}
 	return lang, err
 }
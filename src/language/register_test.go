@@ -89,6 +89,13 @@ func Test_cannot_register_a_language_with_no_default_toolchain(t *testing.T) {
 	assert.False(t, isSupported(name))
 }
 
+func Test_cannot_register_a_language_with_invalid_file_pattern(t *testing.T) {
+	const name = "invalid-file-pattern"
+	assert.Error(t, Register(ALanguage(WithName(name),
+		WithTestFiles(AFileTreeFilter(WithPattern("(unclosed"))))))
+	assert.False(t, isSupported(name))
+}
+
 func Test_cannot_register_a_language_with_default_toolchain_not_compatible(t *testing.T) {
 	const name = "default-toolchain-not-compatible"
 	assert.Error(t, Register(ALanguage(
@@ -0,0 +1,92 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package language
+
+import (
+	"github.com/murex/tcr/schema"
+	"github.com/murex/tcr/utils"
+	"github.com/spf13/afero"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ValidateConfigFiles checks all language configuration files, and returns the issues found
+func ValidateConfigFiles() (issues schema.Issues) {
+	for _, entry := range GetConfigFileList() {
+		issues = append(issues, ValidateConfigFile(entry)...)
+	}
+	return issues
+}
+
+// ValidateConfigFile checks the provided language configuration file against the language
+// configuration schema, then verifies that the resulting language definition is consistent
+func ValidateConfigFile(yamlFilename string) schema.Issues {
+	filePath := filepath.Join(languageDirPath, yamlFilename)
+	data, err := afero.ReadFile(appFS, filePath)
+	if err != nil {
+		return schema.Issues{{File: filePath, Message: err.Error()}}
+	}
+	doc, issues := schema.ValidateData(schema.Language, filePath, data)
+	if len(issues) > 0 {
+		return issues
+	}
+	cfg, err := readConfig(yamlFilename)
+	if err != nil {
+		return schema.Issues{doc.IssueAt("extends", err.Error(), "built-in languages: "+strings.Join(builtInNames(), ", "))}
+	}
+	return checkConfig(doc, asLanguage(*cfg))
+}
+
+// checkConfig performs the checks that cannot be expressed through the language configuration schema
+func checkConfig(doc *schema.Document, lang *Language) (issues schema.Issues) {
+	if err := lang.checkCompatibleToolchains(); err != nil {
+		issues = append(issues, doc.IssueAt("toolchains.compatible-with", err.Error(),
+			"list the toolchains that can be used with this language"))
+	} else if err = lang.checkDefaultToolchain(); err != nil {
+		issues = append(issues, doc.IssueAt("toolchains.default", err.Error(),
+			"use one of: "+strings.Join(lang.toolchains.Compatible, ", ")))
+	}
+	if err := lang.srcFileFilter.check(); err != nil {
+		issues = append(issues, doc.IssueAt("source-files", err.Error(), "check patterns and globs syntax"))
+	}
+	if err := lang.testFileFilter.check(); err != nil {
+		issues = append(issues, doc.IssueAt("test-files", err.Error(), "check patterns and globs syntax"))
+	}
+	return issues
+}
+
+func builtInNames() []string {
+	names := make([]string, 0, len(builtIn))
+	for name := range builtIn {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func traceIssues(issues schema.Issues) {
+	for _, issue := range issues {
+		utils.Trace(issue.String())
+	}
+}
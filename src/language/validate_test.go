@@ -0,0 +1,102 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package language
+
+import (
+	"github.com/murex/tcr/schema"
+	"github.com/stretchr/testify/assert"
+	"path"
+	"testing"
+)
+
+func Test_built_in_languages_match_language_schema(t *testing.T) {
+	entries, _ := builtInFS.ReadDir(builtInDir)
+	for _, entry := range entries {
+		t.Run(entry.Name(), func(t *testing.T) {
+			data, err := builtInFS.ReadFile(path.Join(builtInDir, entry.Name()))
+			assert.NoError(t, err)
+			_, issues := schema.ValidateData(schema.Language, entry.Name(), data)
+			assert.Empty(t, issues)
+		})
+	}
+}
+
+func Test_validate_language_config_file(t *testing.T) {
+	testFlags := []struct {
+		desc        string
+		data        string
+		expectedKey string
+		expectedLn  int
+	}{
+		{
+			"unknown key",
+			"extends: java\ntest-files:\n  directory: [ src/it ]\n",
+			"test-files.directory", 3,
+		},
+		{
+			"invalid regular expression",
+			"extends: java\ntest-files:\n  patterns: [ '(?i^.*\\.java$' ]\n",
+			"test-files.patterns.0", 3,
+		},
+		{
+			"default toolchain not compatible",
+			"extends: java\ntoolchains:\n  default: npm\n",
+			"toolchains.default", 3,
+		},
+		{
+			"default toolchain not compatible when inherited",
+			"extends: java\ntoolchains:\n  compatible-with: [ maven ]\n",
+			"toolchains.default", 2,
+		},
+		{
+			"unknown extended language",
+			"toolchains:\n  default: make\nextends: unknown\n",
+			"extends", 3,
+		},
+	}
+	for _, tt := range testFlags {
+		t.Run(tt.desc, func(t *testing.T) {
+			setupLanguageConfigFile(t, "my-language", tt.data)
+			issues := ValidateConfigFile("my-language.yml")
+			if assert.Len(t, issues, 1) {
+				assert.Equal(t, tt.expectedKey, issues[0].Key)
+				assert.Equal(t, tt.expectedLn, issues[0].Line)
+				assert.NotEmpty(t, issues[0].Hint)
+			}
+		})
+	}
+}
+
+func Test_validate_valid_language_config_file(t *testing.T) {
+	setupLanguageConfigFile(t, "java", "extends: java\ntest-files:\n  directories: [ src/it ]\n")
+	assert.Empty(t, ValidateConfigFiles())
+}
+
+func Test_invalid_language_config_file_is_not_loaded(t *testing.T) {
+	setupLanguageConfigFile(t, "my-language", "extends: java\ntoolchains:\n  default: npm\n")
+	t.Cleanup(func() {
+		delete(registered, "my-language")
+	})
+	loadConfigs()
+	assert.False(t, isSupported("my-language"))
+}
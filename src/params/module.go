@@ -0,0 +1,58 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package params
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Module describes one module of a polyglot project, e.g. "go:go-tools@backend".
+// When Language or Toolchain are empty, they are detected from the module's subdirectory
+type Module struct {
+	Language  string
+	Toolchain string
+	SubDir    string
+}
+
+const (
+	// moduleSeparator separates the language and toolchain from the module's subdirectory
+	moduleSeparator = "@"
+	// toolchainSeparator separates the language from the toolchain
+	toolchainSeparator = ":"
+)
+
+// ParseModule parses the provided module specification.
+// Expected format is "[language][:toolchain]@subdirectory"
+func ParseModule(spec string) (m Module, err error) {
+	definition, subDir, found := strings.Cut(spec, moduleSeparator)
+	if !found || strings.TrimSpace(subDir) == "" {
+		return m, fmt.Errorf("invalid module \"%s\": expected format is [language][:toolchain]@subdirectory", spec)
+	}
+	langName, tchnName, _ := strings.Cut(definition, toolchainSeparator)
+	return Module{
+		Language:  strings.TrimSpace(langName),
+		Toolchain: strings.TrimSpace(tchnName),
+		SubDir:    strings.TrimSpace(subDir),
+	}, nil
+}
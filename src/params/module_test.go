@@ -0,0 +1,50 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package params
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_parse_module(t *testing.T) {
+	tests := []struct {
+		spec          string
+		expected      Module
+		expectedError bool
+	}{
+		{"go:go-tools@backend", Module{"go", "go-tools", "backend"}, false},
+		{"typescript@frontend", Module{"typescript", "", "frontend"}, false},
+		{"@frontend/web", Module{"", "", "frontend/web"}, false},
+		{" java : gradle @ services/api ", Module{"java", "gradle", "services/api"}, false},
+		{"go:go-tools", Module{}, true},
+		{"go@", Module{}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.spec, func(t *testing.T) {
+			m, err := ParseModule(test.spec)
+			assert.Equal(t, test.expectedError, err != nil)
+			assert.Equal(t, test.expected, m)
+		})
+	}
+}
@@ -34,6 +34,7 @@ type Params struct {
 	WorkDir         string
 	Language        string
 	Toolchain       string
+	Modules         []string
 	MobTurnDuration time.Duration
 	AutoPush        bool
 	CommitFailures  bool
@@ -42,4 +43,9 @@ type Params struct {
 	VCS             string
 	MessageSuffix   string
 	Trace           string
+	CheckFormat     string
+	CheckStrict     bool
+	CheckSelect     []string
+	CheckSkip       []string
+	CheckFix        bool
 }
@@ -37,6 +37,7 @@ func AParamSet(builders ...func(params *Params)) *Params {
 		WorkDir:         "",
 		Language:        "",
 		Toolchain:       "",
+		Modules:         nil,
 		MobTurnDuration: 0,
 		AutoPush:        false,
 		PollingPeriod:   0,
@@ -85,6 +86,13 @@ func WithToolchain(name string) func(params *Params) {
 	}
 }
 
+// WithModules sets the provided modules as the modules of a polyglot project
+func WithModules(modules ...string) func(params *Params) {
+	return func(params *Params) {
+		params.Modules = modules
+	}
+}
+
 // WithPollingPeriod sets the provided value as the VCS polling period
 func WithPollingPeriod(period time.Duration) func(params *Params) {
 	return func(params *Params) {
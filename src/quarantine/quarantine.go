@@ -0,0 +1,173 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package quarantine
+
+import (
+	"errors"
+	"fmt"
+	"github.com/murex/tcr/schema"
+	"github.com/murex/tcr/utils"
+	"github.com/spf13/afero"
+	"os"
+	"sort"
+	"strings"
+)
+
+const (
+	quarantineFileBase = "quarantine"
+)
+
+var (
+	configDirPath string
+	quarantined   = make(map[string]bool)
+	// loadErr is set when the quarantine file exists but cannot be loaded
+	loadErr error
+)
+
+// configYAML defines the structure of the quarantine configuration file
+type configYAML struct {
+	Tests []string `yaml:"tests"`
+}
+
+// InitConfig initializes the list of quarantined tests from the quarantine file
+// found in the provided configuration directory
+func InitConfig(dirPath string) {
+	configDirPath = dirPath
+	quarantined = make(map[string]bool)
+	loadErr = loadConfig()
+	if loadErr != nil {
+		utils.Trace(loadErr)
+	}
+}
+
+// GetConfigFilePath returns the path to the quarantine file
+func GetConfigFilePath() string {
+	return utils.BuildYAMLFilePath(configDirPath, quarantineFileBase)
+}
+
+func loadConfig() error {
+	if _, err := appFS.Stat(GetConfigFilePath()); err != nil {
+		// No quarantine file means that no test is quarantined
+		return nil
+	}
+	if issues := ValidateConfigFile(); len(issues) > 0 {
+		for _, issue := range issues {
+			utils.Trace(issue.String())
+		}
+		return errors.New("invalid quarantine file: " + GetConfigFilePath())
+	}
+	var cfg configYAML
+	err := utils.LoadFromYAMLFile(os.DirFS(configDirPath), utils.BuildYAMLFilename(quarantineFileBase), &cfg)
+	if err != nil {
+		return fmt.Errorf("error in %s: %w", GetConfigFilePath(), err)
+	}
+	Add(cfg.Tests...)
+	return nil
+}
+
+// ValidateConfigFile checks the quarantine file against the quarantine schema, and returns
+// the issues found. A missing quarantine file is valid
+func ValidateConfigFile() schema.Issues {
+	data, err := afero.ReadFile(appFS, GetConfigFilePath())
+	if err != nil {
+		return nil
+	}
+	_, issues := schema.ValidateData(schema.Quarantine, GetConfigFilePath(), data)
+	return issues
+}
+
+// SaveConfig saves the list of quarantined tests into the quarantine file.
+// An existing quarantine file that could not be loaded is never overwritten
+func SaveConfig() error {
+	if loadErr != nil {
+		return fmt.Errorf("quarantine file not saved, fix it first: %w", loadErr)
+	}
+	utils.Trace("Saving quarantined tests: ", GetConfigFilePath())
+	utils.SaveToYAMLFile(appFS, configYAML{Tests: List()}, GetConfigFilePath())
+	return nil
+}
+
+// ShowConfig shows the list of quarantined tests
+func ShowConfig() {
+	utils.Trace("Quarantined tests:")
+	tests := List()
+	if len(tests) == 0 {
+		utils.Trace("- none")
+	}
+	for _, test := range tests {
+		utils.Trace("- ", test)
+	}
+}
+
+// Add adds the provided test identifiers to the list of quarantined tests
+func Add(testIDs ...string) {
+	for _, id := range testIDs {
+		if id = strings.TrimSpace(id); id != "" {
+			quarantined[id] = true
+		}
+	}
+}
+
+// Remove removes the provided test identifiers from the list of quarantined tests.
+// Returns an error listing the test identifiers that were not quarantined
+func Remove(testIDs ...string) error {
+	var notFound []string
+	for _, id := range testIDs {
+		if !IsQuarantined(id) {
+			notFound = append(notFound, id)
+			continue
+		}
+		delete(quarantined, id)
+	}
+	if len(notFound) > 0 {
+		return errors.New("test(s) not in quarantine: " + strings.Join(notFound, ", "))
+	}
+	return nil
+}
+
+// List returns the list of quarantined test identifiers sorted alphabetically
+func List() []string {
+	var testIDs []string
+	for id := range quarantined {
+		testIDs = append(testIDs, id)
+	}
+	sort.Strings(testIDs)
+	return testIDs
+}
+
+// IsQuarantined indicates if the provided test identifier is quarantined
+func IsQuarantined(testID string) bool {
+	return quarantined[testID]
+}
+
+// Split splits the provided test identifiers between quarantined ones and others
+func Split(testIDs []string) (inQuarantine []string, others []string) {
+	for _, id := range testIDs {
+		if IsQuarantined(id) {
+			inQuarantine = append(inQuarantine, id)
+		} else {
+			others = append(others, id)
+		}
+	}
+	return inQuarantine, others
+}
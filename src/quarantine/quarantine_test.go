@@ -0,0 +1,132 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package quarantine
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func resetQuarantine() {
+	quarantined = make(map[string]bool)
+}
+
+func Test_no_test_is_quarantined_by_default(t *testing.T) {
+	resetQuarantine()
+	assert.Empty(t, List())
+	assert.False(t, IsQuarantined("some-test"))
+}
+
+func Test_add_tests_to_quarantine(t *testing.T) {
+	resetQuarantine()
+	Add("test-b", "test-a", " ", "test-b")
+	assert.Equal(t, []string{"test-a", "test-b"}, List())
+	assert.True(t, IsQuarantined("test-a"))
+}
+
+func Test_remove_tests_from_quarantine(t *testing.T) {
+	resetQuarantine()
+	Add("test-a", "test-b")
+	assert.NoError(t, Remove("test-a"))
+	assert.Equal(t, []string{"test-b"}, List())
+}
+
+func Test_remove_tests_not_in_quarantine(t *testing.T) {
+	resetQuarantine()
+	Add("test-a")
+	err := Remove("test-a", "test-x", "test-y")
+	assert.EqualError(t, err, "test(s) not in quarantine: test-x, test-y")
+	assert.Empty(t, List())
+}
+
+func Test_split_quarantined_tests_from_others(t *testing.T) {
+	resetQuarantine()
+	Add("test-a", "test-c")
+	inQuarantine, others := Split([]string{"test-a", "test-b", "test-c", "test-d"})
+	assert.Equal(t, []string{"test-a", "test-c"}, inQuarantine)
+	assert.Equal(t, []string{"test-b", "test-d"}, others)
+}
+
+func Test_init_config_with_no_quarantine_file(t *testing.T) {
+	dir := t.TempDir()
+	Add("leftover")
+	InitConfig(dir)
+	assert.Empty(t, List())
+}
+
+func Test_save_and_load_quarantine_file(t *testing.T) {
+	dir := t.TempDir()
+	InitConfig(dir)
+	Add("test-a", "test-b")
+	assert.NoError(t, SaveConfig())
+	assert.FileExists(t, filepath.Join(dir, "quarantine.yml"))
+
+	resetQuarantine()
+	InitConfig(dir)
+	assert.Equal(t, []string{"test-a", "test-b"}, List())
+}
+
+func Test_load_invalid_quarantine_file(t *testing.T) {
+	dir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(dir, "quarantine.yml"), []byte("tests: [unterminated"), 0600)
+	InitConfig(dir)
+	assert.Empty(t, List())
+}
+
+func Test_save_does_not_overwrite_a_quarantine_file_that_cannot_be_loaded(t *testing.T) {
+	dir := t.TempDir()
+	const data = "test: [ a.b ]\n"
+	_ = os.WriteFile(filepath.Join(dir, "quarantine.yml"), []byte(data), 0600)
+	InitConfig(dir)
+	Add("test-a")
+	assert.Error(t, SaveConfig())
+	content, _ := os.ReadFile(filepath.Join(dir, "quarantine.yml"))
+	assert.Equal(t, data, string(content))
+}
+
+func Test_validate_quarantine_file(t *testing.T) {
+	testFlags := []struct {
+		desc           string
+		data           string
+		expectedIssues int
+	}{
+		{"valid file", "tests: [ a.b, c.d ]\n", 0},
+		{"unknown key", "test: [ a.b ]\n", 1},
+		{"syntax error", "tests: [unterminated", 1},
+	}
+	for _, tt := range testFlags {
+		t.Run(tt.desc, func(t *testing.T) {
+			dir := t.TempDir()
+			_ = os.WriteFile(filepath.Join(dir, "quarantine.yml"), []byte(tt.data), 0600)
+			InitConfig(dir)
+			assert.Len(t, ValidateConfigFile(), tt.expectedIssues)
+		})
+	}
+}
+
+func Test_validate_missing_quarantine_file(t *testing.T) {
+	InitConfig(t.TempDir())
+	assert.Empty(t, ValidateConfigFile())
+}
@@ -0,0 +1,313 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package schema
+
+import (
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Document is a YAML configuration file being validated
+type Document struct {
+	file string
+	root *yaml.Node
+}
+
+var yamlErrorLine = regexp.MustCompile(`^yaml: line (\d+): `)
+
+// Parse parses the YAML data read from the provided file. Syntax errors are reported as issues
+func Parse(file string, data []byte) (*Document, Issues) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		line := 1
+		if m := yamlErrorLine.FindStringSubmatch(err.Error()); m != nil {
+			line, _ = strconv.Atoi(m[1])
+		}
+		return nil, Issues{{
+			File:    file,
+			Line:    line,
+			Message: strings.TrimPrefix(yamlErrorLine.ReplaceAllString(err.Error(), ""), "yaml: "),
+			Hint:    "check the YAML syntax, in particular indentation and quoting",
+		}}
+	}
+	d := &Document{file: file}
+	if len(doc.Content) > 0 {
+		d.root = doc.Content[0]
+	}
+	return d, nil
+}
+
+// ValidateData parses the YAML data read from the provided file, and validates it
+// against the schema with the provided name
+func ValidateData(name string, file string, data []byte) (*Document, Issues) {
+	d, issues := Parse(file, data)
+	if issues != nil {
+		return nil, issues
+	}
+	s, err := Get(name)
+	if err != nil {
+		return d, Issues{d.IssueAt("", err.Error(), "")}
+	}
+	return d, d.Validate(s)
+}
+
+// Validate checks the document against the provided schema
+func (d *Document) Validate(s *Schema) (issues Issues) {
+	if d.root != nil {
+		d.validate(d.root, s, s, "", &issues)
+	}
+	return issues
+}
+
+// IssueAt creates an issue located at the provided key of the document (ex: "toolchains.default").
+// When the key is not present in the document, the issue is located at its closest parent key
+func (d *Document) IssueAt(key string, message string, hint string) Issue {
+	return Issue{File: d.file, Line: d.Line(key), Key: key, Message: message, Hint: hint}
+}
+
+// Line returns the line where the provided key is defined in the document, or the line of
+// its closest parent key when the key is not present in the document
+func (d *Document) Line(key string) int {
+	line := 1
+	node := d.root
+	if node == nil || key == "" {
+		return line
+	}
+	for _, part := range strings.Split(key, ".") {
+		next, keyNode := child(node, part)
+		if next == nil {
+			break
+		}
+		line, node = keyNode.Line, next
+	}
+	return line
+}
+
+// child returns the value and key nodes for the provided key of a mapping or index of a sequence
+func child(node *yaml.Node, key string) (value *yaml.Node, keyNode *yaml.Node) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				return node.Content[i+1], node.Content[i]
+			}
+		}
+	case yaml.SequenceNode:
+		if index, err := strconv.Atoi(key); err == nil && index >= 0 && index < len(node.Content) {
+			return node.Content[index], node.Content[index]
+		}
+	}
+	return nil, nil
+}
+
+func (d *Document) validate(node *yaml.Node, s *Schema, root *Schema, key string, issues *Issues) {
+	for node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	s = s.resolve(root)
+	if node.Tag == "!!null" {
+		// An empty value is equivalent to a missing one
+		return
+	}
+	switch s.Type {
+	case "object":
+		if node.Kind != yaml.MappingNode {
+			d.addTypeIssue(issues, node, key, "a mapping", "")
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyNode, valueNode := node.Content[i], node.Content[i+1]
+			childKey := joinKey(key, keyNode.Value)
+			property, found := s.Properties[keyNode.Value]
+			if !found {
+				if property = s.additionalProperties(); property == nil {
+					*issues = append(*issues, Issue{
+						File: d.file, Line: keyNode.Line, Key: childKey,
+						Message: "unknown key",
+						Hint:    suggest(keyNode.Value, propertyNames(s), "allowed keys"),
+					})
+					continue
+				}
+			}
+			d.validate(valueNode, property, root, childKey, issues)
+		}
+	case "array":
+		if node.Kind != yaml.SequenceNode {
+			d.addTypeIssue(issues, node, key, "a list", "use [ value ] for a single-item list")
+			return
+		}
+		if s.Items != nil {
+			for i, item := range node.Content {
+				d.validate(item, s.Items, root, joinKey(key, strconv.Itoa(i)), issues)
+			}
+		}
+	case "string", "boolean", "integer":
+		if node.Kind != yaml.ScalarNode {
+			d.addTypeIssue(issues, node, key, "a single value", "")
+			return
+		}
+		if message, hint := checkScalar(node, s); message != "" {
+			*issues = append(*issues, Issue{File: d.file, Line: node.Line, Key: key, Message: message, Hint: hint})
+		}
+	}
+}
+
+func (d *Document) addTypeIssue(issues *Issues, node *yaml.Node, key string, expected string, hint string) {
+	*issues = append(*issues, Issue{
+		File:    d.file,
+		Line:    node.Line,
+		Key:     key,
+		Message: fmt.Sprintf("expected %s, got %s", expected, describeNode(node)),
+		Hint:    hint,
+	})
+}
+
+// checkScalar checks a scalar value against the provided schema. It returns
+// a message and a hint describing the problem, or empty strings when the value is valid
+func checkScalar(node *yaml.Node, s *Schema) (message string, hint string) {
+	switch s.Type {
+	case "boolean":
+		if node.Tag != "!!bool" {
+			return fmt.Sprintf("expected a boolean, got %q", node.Value), "use true or false"
+		}
+	case "integer":
+		if node.Tag != "!!int" {
+			return fmt.Sprintf("expected an integer, got %q", node.Value), "use a whole number"
+		}
+		if message, hint = checkRange(node.Value, s); message != "" {
+			return message, hint
+		}
+	}
+	if len(s.Enum) > 0 && !contains(s.Enum, node.Value) {
+		return fmt.Sprintf("unsupported value %q", node.Value), suggest(node.Value, s.Enum, "allowed values")
+	}
+	switch s.Format {
+	case "regex":
+		if _, err := regexp.Compile(node.Value); err != nil {
+			return fmt.Sprintf("invalid regular expression %q: %v", node.Value, err),
+				`patterns use Go regular expression syntax, for instance '(?i)^.*\.java$'`
+		}
+	case "duration":
+		if _, err := time.ParseDuration(node.Value); err != nil {
+			return fmt.Sprintf("invalid duration %q", node.Value), "use a duration such as 90s, 5m or 1h30m"
+		}
+	}
+	return "", ""
+}
+
+func checkRange(value string, s *Schema) (message string, hint string) {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Sprintf("expected an integer, got %q", value), "use a whole number"
+	}
+	if (s.Minimum != nil && n < *s.Minimum) || (s.Maximum != nil && n > *s.Maximum) {
+		switch {
+		case s.Minimum != nil && s.Maximum != nil:
+			hint = fmt.Sprintf("use a value between %d and %d", *s.Minimum, *s.Maximum)
+		case s.Minimum != nil:
+			hint = fmt.Sprintf("use a value greater than or equal to %d", *s.Minimum)
+		default:
+			hint = fmt.Sprintf("use a value less than or equal to %d", *s.Maximum)
+		}
+		return fmt.Sprintf("value %d is out of range", n), hint
+	}
+	return "", ""
+}
+
+func describeNode(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "a mapping"
+	case yaml.SequenceNode:
+		return "a list"
+	default:
+		return fmt.Sprintf("%q", node.Value)
+	}
+}
+
+func propertyNames(s *Schema) []string {
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// suggest returns a hint pointing to the closest candidate to value when there is one,
+// or listing all candidates otherwise
+func suggest(value string, candidates []string, description string) string {
+	best, bestDistance := "", -1
+	for _, candidate := range candidates {
+		distance := editDistance(strings.ToLower(value), strings.ToLower(candidate))
+		if distance <= len(candidate)/3+1 && (bestDistance < 0 || distance < bestDistance) {
+			best, bestDistance = candidate, distance
+		}
+	}
+	if best != "" {
+		return fmt.Sprintf("did you mean %q?", best)
+	}
+	return description + ": " + strings.Join(candidates, ", ")
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a string, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func joinKey(prefix string, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
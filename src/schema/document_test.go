@@ -0,0 +1,198 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package schema
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const testSchema = `{
+  "type": "object",
+  "additionalProperties": false,
+  "properties": {
+    "name": { "type": "string" },
+    "enabled": { "type": "boolean" },
+    "level": { "type": "integer", "minimum": 1, "maximum": 5 },
+    "color": { "type": "string", "enum": ["red", "green", "blue"] },
+    "pattern": { "type": "string", "format": "regex" },
+    "delay": { "type": "string", "format": "duration" },
+    "items": { "type": "array", "items": { "$ref": "#/$defs/item" } },
+    "labels": { "type": "object", "additionalProperties": { "type": "string" } }
+  },
+  "$defs": {
+    "item": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": { "value": { "type": "string" } }
+    }
+  }
+}`
+
+func validateTestData(t *testing.T, data string) Issues {
+	t.Helper()
+	var s Schema
+	if err := json.Unmarshal([]byte(testSchema), &s); err != nil {
+		t.Fatal(err)
+	}
+	d, issues := Parse("test.yml", []byte(data))
+	if issues != nil {
+		return issues
+	}
+	return d.Validate(&s)
+}
+
+func Test_validate_document(t *testing.T) {
+	testFlags := []struct {
+		desc     string
+		data     string
+		expected Issues
+	}{
+		{
+			"empty document",
+			"",
+			nil,
+		},
+		{
+			"valid document",
+			"name: x\nenabled: true\nlevel: 3\ncolor: red\npattern: '^a.*$'\ndelay: 5m\n" +
+				"items:\n  - value: a\nlabels:\n  any-key: any-value\n",
+			nil,
+		},
+		{
+			"empty values are ignored",
+			"name:\nitems:\n",
+			nil,
+		},
+		{
+			"unknown key close to an existing one",
+			"nmae: x\n",
+			Issues{{File: "test.yml", Line: 1, Key: "nmae", Message: "unknown key", Hint: `did you mean "name"?`}},
+		},
+		{
+			"unknown key far from existing ones",
+			"something: x\n",
+			Issues{{File: "test.yml", Line: 1, Key: "something", Message: "unknown key",
+				Hint: "allowed keys: color, delay, enabled, items, labels, level, name, pattern"}},
+		},
+		{
+			"unknown key in a referenced definition",
+			"items:\n  - value: a\n  - valeu: b\n",
+			Issues{{File: "test.yml", Line: 3, Key: "items.1.valeu", Message: "unknown key", Hint: `did you mean "value"?`}},
+		},
+		{
+			"wrong boolean",
+			"enabled: maybe\n",
+			Issues{{File: "test.yml", Line: 1, Key: "enabled", Message: `expected a boolean, got "maybe"`, Hint: "use true or false"}},
+		},
+		{
+			"wrong integer",
+			"level: high\n",
+			Issues{{File: "test.yml", Line: 1, Key: "level", Message: `expected an integer, got "high"`, Hint: "use a whole number"}},
+		},
+		{
+			"integer out of range",
+			"level: 9\n",
+			Issues{{File: "test.yml", Line: 1, Key: "level", Message: "value 9 is out of range", Hint: "use a value between 1 and 5"}},
+		},
+		{
+			"unsupported enum value",
+			"color: yellow\n",
+			Issues{{File: "test.yml", Line: 1, Key: "color", Message: `unsupported value "yellow"`, Hint: "allowed values: red, green, blue"}},
+		},
+		{
+			"invalid regular expression",
+			"pattern: '(a'\n",
+			Issues{{File: "test.yml", Line: 1, Key: "pattern",
+				Message: "invalid regular expression \"(a\": error parsing regexp: missing closing ): `(a`",
+				Hint:    `patterns use Go regular expression syntax, for instance '(?i)^.*\.java$'`}},
+		},
+		{
+			"invalid duration",
+			"delay: soon\n",
+			Issues{{File: "test.yml", Line: 1, Key: "delay", Message: `invalid duration "soon"`, Hint: "use a duration such as 90s, 5m or 1h30m"}},
+		},
+		{
+			"scalar instead of a list",
+			"items: a\n",
+			Issues{{File: "test.yml", Line: 1, Key: "items", Message: `expected a list, got "a"`, Hint: "use [ value ] for a single-item list"}},
+		},
+		{
+			"list instead of a scalar",
+			"name: [a, b]\n",
+			Issues{{File: "test.yml", Line: 1, Key: "name", Message: "expected a single value, got a list"}},
+		},
+		{
+			"wrong type for additional properties",
+			"labels:\n  key: [a]\n",
+			Issues{{File: "test.yml", Line: 2, Key: "labels.key", Message: "expected a single value, got a list"}},
+		},
+		{
+			"syntax error",
+			"name: [a\n",
+			Issues{{File: "test.yml", Line: 1, Message: "did not find expected ',' or ']'",
+				Hint: "check the YAML syntax, in particular indentation and quoting"}},
+		},
+	}
+	for _, tt := range testFlags {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, validateTestData(t, tt.data))
+		})
+	}
+}
+
+func Test_document_line_of_a_key(t *testing.T) {
+	d, _ := Parse("test.yml", []byte("name: x\nitems:\n  - value: a\n  - value: b\n"))
+	testFlags := []struct {
+		key      string
+		expected int
+	}{
+		{"", 1},
+		{"name", 1},
+		{"items", 2},
+		{"items.1", 4},
+		{"items.1.value", 4},
+		{"items.1.unknown", 4},
+		{"unknown", 1},
+	}
+	for _, tt := range testFlags {
+		t.Run(tt.key, func(t *testing.T) {
+			assert.Equal(t, tt.expected, d.Line(tt.key))
+		})
+	}
+}
+
+func Test_issue_at_a_key(t *testing.T) {
+	d, _ := Parse("test.yml", []byte("name: x\nitems:\n  - value: a\n"))
+	assert.Equal(t,
+		Issue{File: "test.yml", Line: 2, Key: "items", Message: "some message", Hint: "some hint"},
+		d.IssueAt("items", "some message", "some hint"))
+}
+
+func Test_validate_data_with_a_published_schema(t *testing.T) {
+	_, issues := ValidateData(Quarantine, "quarantine.yml", []byte("tests: [ a.b, c.d ]\n"))
+	assert.Empty(t, issues)
+	_, issues = ValidateData(Quarantine, "quarantine.yml", []byte("test: [ a.b ]\n"))
+	assert.Len(t, issues, 1)
+}
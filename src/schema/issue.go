@@ -0,0 +1,64 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package schema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Issue is a problem found while validating a configuration file
+type Issue struct {
+	File    string
+	Line    int
+	Key     string
+	Message string
+	Hint    string
+}
+
+// String returns a human-readable description of the issue, in the form
+// "file:line: key: message (hint)"
+func (i Issue) String() string {
+	var sb strings.Builder
+	_, _ = fmt.Fprintf(&sb, "%s:%d: ", i.File, i.Line)
+	if i.Key != "" {
+		_, _ = fmt.Fprintf(&sb, "%s: ", i.Key)
+	}
+	sb.WriteString(i.Message)
+	if i.Hint != "" {
+		_, _ = fmt.Fprintf(&sb, " (%s)", i.Hint)
+	}
+	return sb.String()
+}
+
+// Issues is a list of issues
+type Issues []Issue
+
+// Error returns all issues, one per line. This allows using Issues as an error
+func (issues Issues) Error() string {
+	lines := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		lines = append(lines, issue.String())
+	}
+	return strings.Join(lines, "\n")
+}
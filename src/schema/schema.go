@@ -0,0 +1,133 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package schema
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+)
+
+// Names of the published schemas
+const (
+	Config     = "config"
+	Language   = "language"
+	Toolchain  = "toolchain"
+	Quarantine = "quarantine"
+)
+
+const schemaFileSuffix = ".schema.json"
+
+//go:embed *.schema.json
+var schemaFS embed.FS
+
+var (
+	loaded     = make(map[string]*Schema)
+	loadedLock sync.Mutex
+)
+
+// Schema is a JSON Schema definition. Only the subset of JSON Schema keywords used
+// by TCR configuration files is supported
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties json.RawMessage    `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Minimum              *int               `json:"minimum,omitempty"`
+	Maximum              *int               `json:"maximum,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// Names returns the names of the published schemas
+func Names() []string {
+	return []string{Config, Language, Toolchain, Quarantine}
+}
+
+// Raw returns the JSON contents of the schema with the provided name
+func Raw(name string) ([]byte, error) {
+	data, err := schemaFS.ReadFile(name + schemaFileSuffix)
+	if err != nil {
+		return nil, fmt.Errorf("no such schema: %s", name)
+	}
+	return data, nil
+}
+
+// Get returns the schema with the provided name
+func Get(name string) (*Schema, error) {
+	loadedLock.Lock()
+	defer loadedLock.Unlock()
+	if s, found := loaded[name]; found {
+		return s, nil
+	}
+	data, err := Raw(name)
+	if err != nil {
+		return nil, err
+	}
+	var s Schema
+	if err = json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("invalid schema %s: %w", name, err)
+	}
+	loaded[name] = &s
+	return &s, nil
+}
+
+// resolve returns the definition referenced by s, if any. Only local references
+// to the root schema's definitions are supported (ex: "#/$defs/command")
+func (s *Schema) resolve(root *Schema) *Schema {
+	for s.Ref != "" {
+		def, found := root.Defs[path.Base(s.Ref)]
+		if !found || !strings.HasPrefix(s.Ref, "#/$defs/") {
+			return &Schema{}
+		}
+		s = def
+	}
+	return s
+}
+
+// additionalProperties returns the schema applying to properties not listed in s.Properties,
+// or nil if such properties are not allowed
+func (s *Schema) additionalProperties() *Schema {
+	if len(s.AdditionalProperties) == 0 {
+		return &Schema{}
+	}
+	var allowed bool
+	if json.Unmarshal(s.AdditionalProperties, &allowed) == nil {
+		if allowed {
+			return &Schema{}
+		}
+		return nil
+	}
+	var additional Schema
+	if json.Unmarshal(s.AdditionalProperties, &additional) != nil {
+		return &Schema{}
+	}
+	return &additional
+}
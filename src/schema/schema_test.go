@@ -0,0 +1,114 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package schema
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_all_published_schemas_can_be_loaded(t *testing.T) {
+	for _, name := range Names() {
+		t.Run(name, func(t *testing.T) {
+			s, err := Get(name)
+			assert.NoError(t, err)
+			if assert.NotNil(t, s) {
+				assert.Equal(t, "object", s.Type)
+				assert.NotEmpty(t, s.Title)
+			}
+		})
+	}
+}
+
+func Test_published_schemas_are_valid_json(t *testing.T) {
+	for _, name := range Names() {
+		t.Run(name, func(t *testing.T) {
+			data, err := Raw(name)
+			assert.NoError(t, err)
+			assert.True(t, json.Valid(data))
+		})
+	}
+}
+
+func Test_get_unknown_schema(t *testing.T) {
+	_, err := Get("unknown")
+	assert.Error(t, err)
+}
+
+func Test_all_schema_references_can_be_resolved(t *testing.T) {
+	var checkRefs func(t *testing.T, root *Schema, s *Schema)
+	checkRefs = func(t *testing.T, root *Schema, s *Schema) {
+		if s == nil {
+			return
+		}
+		if s.Ref != "" {
+			assert.Contains(t, root.Defs, s.Ref[len("#/$defs/"):], "unresolved reference %s", s.Ref)
+		}
+		for _, p := range s.Properties {
+			checkRefs(t, root, p)
+		}
+		for _, d := range s.Defs {
+			checkRefs(t, root, d)
+		}
+		checkRefs(t, root, s.Items)
+	}
+	for _, name := range Names() {
+		t.Run(name, func(t *testing.T) {
+			s, _ := Get(name)
+			checkRefs(t, s, s)
+		})
+	}
+}
+
+func Test_issue_string(t *testing.T) {
+	testFlags := []struct {
+		desc     string
+		issue    Issue
+		expected string
+	}{
+		{
+			"with key and hint",
+			Issue{File: "f.yml", Line: 3, Key: "a.b", Message: "unknown key", Hint: "did you mean \"c\"?"},
+			"f.yml:3: a.b: unknown key (did you mean \"c\"?)",
+		},
+		{
+			"without key nor hint",
+			Issue{File: "f.yml", Line: 1, Message: "some problem"},
+			"f.yml:1: some problem",
+		},
+	}
+	for _, tt := range testFlags {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.issue.String())
+		})
+	}
+}
+
+func Test_issues_as_error(t *testing.T) {
+	issues := Issues{
+		{File: "f.yml", Line: 1, Message: "first"},
+		{File: "f.yml", Line: 2, Message: "second"},
+	}
+	assert.EqualError(t, issues, "f.yml:1: first\nf.yml:2: second")
+}
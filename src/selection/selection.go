@@ -0,0 +1,169 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package selection
+
+import (
+	"errors"
+	"fmt"
+	"github.com/codeskyblue/go-sh"
+	"github.com/murex/tcr/language"
+	"github.com/murex/tcr/toolchain"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+var (
+	// testSuffixes are the suffixes added to a source file name to form its test file name
+	testSuffixes = []string{"Test", "Tests", "IT", "Spec", "_test", "_spec", "-test", "-spec", ".test", ".spec"}
+	// testPrefixes are the prefixes added to a source file name to form its test file name
+	testPrefixes = []string{"test_", "Test"}
+)
+
+// AffectedTests maps the provided changed files to the test targets affected by these changes,
+// following the provided mapping strategy. Changed files are expected to be absolute paths.
+// Returned test targets are relative to the work directory
+func AffectedTests(
+	s *toolchain.TestSelection,
+	lang language.LangInterface,
+	workDir string,
+	changedFiles []string,
+) ([]string, error) {
+	if s == nil {
+		return nil, errors.New("test selection is not configured")
+	}
+	switch s.Mapping {
+	case toolchain.NamingMapping:
+		return byNaming(lang, workDir, changedFiles)
+	case toolchain.PackageMapping:
+		return byPackage(lang, workDir, changedFiles), nil
+	case toolchain.ScriptMapping:
+		return byScript(s.Script, workDir, changedFiles)
+	default:
+		return nil, fmt.Errorf("unsupported test mapping: %q", s.Mapping)
+	}
+}
+
+// byNaming selects changed test files, and test files whose name is the name of a changed
+// source file with a test prefix or suffix (such as Hello.java and HelloTest.java,
+// hello.go and hello_test.go, or hello.py and test_hello.py)
+func byNaming(lang language.LangInterface, workDir string, changedFiles []string) ([]string, error) {
+	var stems []string
+	targets := make(map[string]bool)
+	for _, file := range changedFiles {
+		if lang.IsTestFile(file) {
+			targets[relativePath(workDir, file)] = true
+		} else if lang.IsSrcFile(file) {
+			stems = append(stems, fileStem(file))
+		}
+	}
+	if len(stems) > 0 {
+		testFiles, err := lang.AllTestFiles()
+		if err != nil {
+			return nil, err
+		}
+		for _, testFile := range testFiles {
+			if matchesAnyStem(fileStem(testFile), stems) {
+				targets[relativePath(workDir, testFile)] = true
+			}
+		}
+	}
+	return sortedKeys(targets), nil
+}
+
+// byPackage selects the directories containing changed source and test files
+func byPackage(lang language.LangInterface, workDir string, changedFiles []string) []string {
+	targets := make(map[string]bool)
+	for _, file := range changedFiles {
+		if lang.IsLanguageFile(file) {
+			dir := relativePath(workDir, filepath.Dir(file))
+			if dir != "." && !filepath.IsAbs(dir) && !strings.HasPrefix(dir, "..") {
+				dir = "." + string(filepath.Separator) + dir
+			}
+			targets[dir] = true
+		}
+	}
+	return sortedKeys(targets)
+}
+
+// byScript delegates selection to a user-supplied script. The script is called from the work directory
+// with the changed files (relative to the work directory) as arguments. It is expected to print out
+// one test target per line
+func byScript(script string, workDir string, changedFiles []string) ([]string, error) {
+	var args []string
+	for _, file := range changedFiles {
+		args = append(args, relativePath(workDir, file))
+	}
+	session := sh.NewSession().SetDir(workDir)
+	output, err := session.Command(script, args).Output()
+	if err != nil {
+		return nil, fmt.Errorf("test selection script failed: %w", err)
+	}
+	targets := make(map[string]bool)
+	for _, line := range strings.Split(string(output), "\n") {
+		if target := strings.TrimSpace(line); target != "" {
+			targets[target] = true
+		}
+	}
+	return sortedKeys(targets), nil
+}
+
+func fileStem(path string) string {
+	base := filepath.Base(path)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// matchesAnyStem indicates if the provided test file stem is made of one of the provided
+// source file stems and a test affix. Matching the whole stem prevents hello.go from
+// selecting hello_world_test.go
+func matchesAnyStem(testStem string, stems []string) bool {
+	for _, stem := range stems {
+		for _, suffix := range testSuffixes {
+			if testStem == stem+suffix {
+				return true
+			}
+		}
+		for _, prefix := range testPrefixes {
+			if testStem == prefix+stem {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func relativePath(workDir string, path string) string {
+	rel, err := filepath.Rel(workDir, path)
+	if err != nil {
+		return path
+	}
+	return rel
+}
+
+func sortedKeys(m map[string]bool) (keys []string) {
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,170 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package selection
+
+import (
+	"github.com/murex/tcr/language"
+	"github.com/murex/tcr/toolchain"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func setupGoProject(t *testing.T) (dir string, lang language.LangInterface) {
+	t.Helper()
+	dir = t.TempDir()
+	for _, file := range []string{
+		"main.go",
+		"main_test.go",
+		filepath.Join("pkg", "hello.go"),
+		filepath.Join("pkg", "hello_test.go"),
+		filepath.Join("pkg", "hello_world.go"),
+		filepath.Join("pkg", "hello_world_test.go"),
+		filepath.Join("pkg", "say_hello_test.go"),
+		filepath.Join("pkg", "other_test.go"),
+		filepath.Join("pkg", "README.md"),
+	} {
+		path := filepath.Join(dir, file)
+		_ = os.MkdirAll(filepath.Dir(path), 0750)
+		_ = os.WriteFile(path, []byte{}, 0600)
+	}
+	lang, err := language.GetLanguage("go", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir, lang
+}
+
+func Test_affected_tests_with_no_test_selection(t *testing.T) {
+	_, err := AffectedTests(nil, nil, "", nil)
+	assert.Error(t, err)
+}
+
+func Test_affected_tests_with_unsupported_mapping(t *testing.T) {
+	_, err := AffectedTests(toolchain.NewTestSelection("unknown", "", 0, nil), nil, "", nil)
+	assert.Error(t, err)
+}
+
+func Test_affected_tests_by_naming(t *testing.T) {
+	dir, lang := setupGoProject(t)
+	s := toolchain.NewTestSelection(toolchain.NamingMapping, "", 0, nil)
+	tests := []struct {
+		desc     string
+		changed  []string
+		expected []string
+	}{
+		{"no changed file", nil, nil},
+		{"changed test file", []string{"pkg/other_test.go"}, []string{filepath.Join("pkg", "other_test.go")}},
+		{"changed source file", []string{"pkg/hello.go"}, []string{filepath.Join("pkg", "hello_test.go")}},
+		{"changed source file with name prefixed by another", []string{"pkg/hello_world.go"}, []string{
+			filepath.Join("pkg", "hello_world_test.go"),
+		}},
+		{"changed source and test files", []string{"main.go", "pkg/other_test.go"}, []string{
+			"main_test.go",
+			filepath.Join("pkg", "other_test.go"),
+		}},
+		{"changed non-language file", []string{"pkg/README.md"}, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			targets, err := AffectedTests(s, lang, dir, absPaths(dir, test.changed))
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, targets)
+		})
+	}
+}
+
+func Test_test_file_stem_matching(t *testing.T) {
+	tests := []struct {
+		testStem string
+		expected bool
+	}{
+		{"HelloTest", true},
+		{"hello_test", true},
+		{"test_hello", true},
+		{"hello.spec", true},
+		{"hello", false},
+		{"HelloWorldTest", false},
+		{"hello_world_test", false},
+		{"say_hello_test", false},
+		{"test_hello_world", false},
+	}
+	for _, test := range tests {
+		t.Run(test.testStem, func(t *testing.T) {
+			assert.Equal(t, test.expected, matchesAnyStem(test.testStem, []string{"Hello", "hello"}))
+		})
+	}
+}
+
+func Test_affected_tests_by_package(t *testing.T) {
+	dir, lang := setupGoProject(t)
+	s := toolchain.NewTestSelection(toolchain.PackageMapping, "", 0, nil)
+	tests := []struct {
+		desc     string
+		changed  []string
+		expected []string
+	}{
+		{"no changed file", nil, nil},
+		{"changed file in root directory", []string{"main.go"}, []string{"."}},
+		{"changed files in sub-directory", []string{"pkg/hello.go", "pkg/other_test.go"},
+			[]string{"." + string(filepath.Separator) + "pkg"}},
+		{"changed non-language file", []string{"pkg/README.md"}, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			targets, err := AffectedTests(s, lang, dir, absPaths(dir, test.changed))
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, targets)
+		})
+	}
+}
+
+func Test_affected_tests_by_script(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script test is not supported on Windows")
+	}
+	dir, lang := setupGoProject(t)
+	script := filepath.Join(dir, "select.sh")
+	_ = os.WriteFile(script, []byte("#!/bin/sh\nfor f in \"$@\"; do echo \"target-$f\"; done\necho\n"), 0700) //nolint:gosec
+
+	s := toolchain.NewTestSelection(toolchain.ScriptMapping, script, 0, nil)
+	targets, err := AffectedTests(s, lang, dir, absPaths(dir, []string{"pkg/hello.go", "main.go"}))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"target-main.go", "target-" + filepath.Join("pkg", "hello.go")}, targets)
+}
+
+func Test_affected_tests_by_failing_script(t *testing.T) {
+	dir, lang := setupGoProject(t)
+	s := toolchain.NewTestSelection(toolchain.ScriptMapping, filepath.Join(dir, "missing-script"), 0, nil)
+	_, err := AffectedTests(s, lang, dir, absPaths(dir, []string{"main.go"}))
+	assert.Error(t, err)
+}
+
+func absPaths(dir string, files []string) (paths []string) {
+	for _, file := range files {
+		paths = append(paths, filepath.Join(dir, filepath.FromSlash(file)))
+	}
+	return paths
+}
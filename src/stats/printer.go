@@ -47,6 +47,8 @@ func Print(branch string, tcrEvents events.TcrEvents) {
 	printStatEvolution("Failing tests count", tcrEvents.FailingTestsEvolution())
 	printStatEvolution("Skipped tests count", tcrEvents.SkippedTestsEvolution())
 	printStatEvolution("Test execution duration", tcrEvents.TestDurationEvolution())
+	printStatEvolution("Line coverage (%)", tcrEvents.LineCoverageEvolution())
+	printStatValueAndRatio("Slow test failures", tcrEvents.SlowTestFailures())
 }
 
 func printStatEvolution(name string, stat events.ValueEvolution) {
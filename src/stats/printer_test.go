@@ -173,6 +173,8 @@ func Test_print_all_stats(t *testing.T) {
 				events.WithTestsFailed(1),
 				events.WithTestsSkipped(3),
 				events.WithTestsDuration(1*time.Second),
+				events.WithLineCoverage(6, 10),
+				events.WithSlowTestsStatus(events.StatusPass),
 			)),
 		),
 		*events.ADatedTcrEvent(
@@ -185,6 +187,8 @@ func Test_print_all_stats(t *testing.T) {
 				events.WithTestsFailed(2),
 				events.WithTestsSkipped(1),
 				events.WithTestsDuration(2*time.Second),
+				events.WithLineCoverage(8, 10),
+				events.WithSlowTestsStatus(events.StatusFail),
 			)),
 		),
 	}
@@ -205,6 +209,8 @@ func Test_print_all_stats(t *testing.T) {
 		"- Failing tests count:       1 --> 2",
 		"- Skipped tests count:       5 --> 1",
 		"- Test execution duration:   500ms --> 2s",
+		"- Line coverage (%):         60 --> 80",
+		"- Slow test failures:        1 (50%)",
 	}
 	sniffer := report.NewSniffer()
 	Print(branch, inputEvents)
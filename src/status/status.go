@@ -35,6 +35,7 @@ var (
 	ConfigError = NewStatus(3) // Error in configuration or parameters
 	VCSError    = NewStatus(4) // VCS error
 	OtherError  = NewStatus(5) // Any other error
+	InfraError  = NewStatus(6) // Build or test infrastructure error, changes were neither committed nor reverted
 )
 
 var currentState Status
@@ -56,3 +56,8 @@ func Test_return_code_on_miscellaneous_error(t *testing.T) {
 	RecordState(OtherError)
 	assert.Equal(t, 5, GetReturnCode())
 }
+
+func Test_return_code_on_infrastructure_error(t *testing.T) {
+	RecordState(InfraError)
+	assert.Equal(t, 6, GetReturnCode())
+}
@@ -0,0 +1,57 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// This program simulates a test command with failing tests. It writes an xUnit report
+// where each test name provided on the command line is a failed test, and exits with status 1.
+// Usage: go run failing_tests.go <report-dir> <test-name>...
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Println("usage: failing_tests <report-dir> <test-name>...")
+		os.Exit(2)
+	}
+	reportDir, testNames := os.Args[1], os.Args[2:]
+	var report strings.Builder
+	_, _ = fmt.Fprintf(&report, "<testsuite name=\"failing\" tests=\"%d\" failures=\"%d\">\n",
+		len(testNames), len(testNames))
+	for _, name := range testNames {
+		_, _ = fmt.Fprintf(&report, "  <testcase name=\"%s\"><failure message=\"failed\"/></testcase>\n", name)
+	}
+	report.WriteString("</testsuite>\n")
+	if err := os.MkdirAll(reportDir, 0o755); err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+	if err := os.WriteFile(filepath.Join(reportDir, "failing.xml"), []byte(report.String()), 0o644); err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+	os.Exit(1)
+}
@@ -24,6 +24,7 @@ package toolchain
 
 import (
 	"embed"
+	"errors"
 	"github.com/murex/tcr/utils"
 	"path"
 )
@@ -55,6 +56,16 @@ func loadBuiltInToolchains() {
 	}
 }
 
+// loadBuiltInToolchainData returns the raw YAML definition of the built-in toolchain with the provided name.
+// It is used by toolchain configurations extending a built-in toolchain
+func loadBuiltInToolchainData(name string) (data []byte, origin string, err error) {
+	data, err = builtInFS.ReadFile(path.Join(builtInDir, utils.BuildYAMLFilename(name)))
+	if err != nil {
+		return nil, "", errors.New("cannot extend " + name + ": no such built-in toolchain")
+	}
+	return data, "built-in " + name, nil
+}
+
 func loadBuiltInToolchain(yamlFilename string) *configYAML {
 	var toolchainCfg configYAML
 
@@ -24,12 +24,14 @@ package toolchain
 
 import (
 	"errors"
+	"fmt"
 	"github.com/codeskyblue/go-sh"
 	"github.com/murex/tcr/report"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 )
 
@@ -44,11 +46,29 @@ type (
 	// It contains 2 filters (Os and Arch) allowing to restrict it to specific OS(s)/Architecture(s).
 	// - Path is the path to the command to be run.
 	// - Arguments is the arguments to be passed to the command when executed.
+	// - WorkDir is optional. When set, the command is launched from this directory instead of the
+	// toolchain's work directory. Relative paths are relative to the toolchain's work directory.
+	// - Env is optional. It contains additional environment variables set when running the command.
+	// - ExitCodes is optional. It maps the command's exit codes to command statuses.
 	Command struct {
 		Os        []OsName
 		Arch      []ArchName
 		Path      string
 		Arguments []string
+		WorkDir   string
+		Env       map[string]string
+		ExitCodes ExitCodeMapping
+	}
+
+	// ExitCodeMapping maps command exit codes to command statuses. Exit codes that are not
+	// mapped are considered as passing when 0, and as failing otherwise.
+	// - Pass contains the exit codes mapped to CommandStatusPass.
+	// - TestFailure contains the exit codes mapped to CommandStatusFail.
+	// - InfraError contains the exit codes mapped to CommandStatusError.
+	ExitCodeMapping struct {
+		Pass        []int
+		TestFailure []int
+		InfraError  []int
 	}
 
 	// CommandStatus is the result status of a Command execution
@@ -56,15 +76,23 @@ type (
 
 	// CommandResult contains the result from running a Command
 	// - Status
+	// - Steps contains per-step results when the toolchain has additional steps for this phase
 	CommandResult struct {
 		Status CommandStatus
 		Output string
+		Steps  []StepResult
 	}
 )
 
-// Failed indicates is a Command failed
+// Failed indicates is a Command failed, either because of a test failure or of an infrastructure error
 func (r CommandResult) Failed() bool {
-	return r.Status == CommandStatusFail
+	return r.Status == CommandStatusFail || r.Status == CommandStatusError
+}
+
+// InfraError indicates if a Command failed because of an infrastructure error
+// (command not found, missing dependency, crash of the test runner, etc.)
+func (r CommandResult) InfraError() bool {
+	return r.Status == CommandStatusError
 }
 
 // Passed indicates is a Command passed
@@ -76,6 +104,7 @@ func (r CommandResult) Passed() bool {
 const (
 	CommandStatusPass    CommandStatus = "pass"
 	CommandStatusFail    CommandStatus = "fail"
+	CommandStatusError   CommandStatus = "error"
 	CommandStatusUnknown CommandStatus = "unknown"
 )
 
@@ -130,29 +159,111 @@ func (command Command) runsWithArch(archName ArchName) bool {
 }
 
 func (command Command) run() (result CommandResult) {
-	result = CommandResult{Status: CommandStatusUnknown, Output: ""}
 	report.PostText(command.asCommandLine())
+	result = command.runQuietly()
+	if result.Output != "" {
+		report.PostText(result.Output)
+	}
+	return result
+}
+
+// runQuietly runs the command without reporting its command line and output
+func (command Command) runQuietly() (result CommandResult) {
+	result = CommandResult{Status: CommandStatusUnknown, Output: ""}
 
-	session := sh.NewSession().SetDir(GetWorkDir())
+	session := sh.NewSession().SetDir(command.WorkDirPath())
+	for key, value := range command.Env {
+		session.SetEnv(key, value)
+	}
 	outputBytes, err := session.Command(command.Path, command.Arguments).CombinedOutput()
 
-	if err == nil {
-		result.Status = CommandStatusPass
-	} else {
-		result.Status = CommandStatusFail
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		result.Status = command.ExitCodes.statusFor(0)
+	case errors.As(err, &exitErr):
+		result.Status = command.ExitCodes.statusFor(exitErr.ExitCode())
+	default:
+		// The command could not be launched
+		result.Status = CommandStatusError
+		outputBytes = append(outputBytes, []byte(err.Error())...)
 	}
 
 	if outputBytes != nil {
 		result.Output = string(outputBytes)
-		report.PostText(result.Output)
 	}
 	return result
 }
 
+// WorkDirPath returns the absolute path to the directory from which the command is launched
+func (command Command) WorkDirPath() string {
+	if command.WorkDir == "" {
+		return GetWorkDir()
+	}
+	if filepath.IsAbs(command.WorkDir) {
+		return filepath.Clean(command.WorkDir)
+	}
+	return filepath.Join(GetWorkDir(), command.WorkDir)
+}
+
+// EnvAsList returns the command's additional environment variables as a sorted list of KEY=value entries
+func (command Command) EnvAsList() (env []string) {
+	for key, value := range command.Env {
+		env = append(env, key+"="+value)
+	}
+	sort.Strings(env)
+	return env
+}
+
+func (m ExitCodeMapping) statusFor(exitCode int) CommandStatus {
+	switch {
+	case containsExitCode(m.Pass, exitCode):
+		return CommandStatusPass
+	case containsExitCode(m.InfraError, exitCode):
+		return CommandStatusError
+	case containsExitCode(m.TestFailure, exitCode):
+		return CommandStatusFail
+	case exitCode == 0:
+		return CommandStatusPass
+	default:
+		return CommandStatusFail
+	}
+}
+
+func (m ExitCodeMapping) check() error {
+	mapped := make(map[int]bool)
+	for _, codes := range [][]int{m.Pass, m.TestFailure, m.InfraError} {
+		for _, code := range codes {
+			if mapped[code] {
+				return fmt.Errorf("exit code %d is mapped more than once", code)
+			}
+			mapped[code] = true
+		}
+	}
+	return nil
+}
+
+// IsSet indicates if at least one exit code is mapped
+func (m ExitCodeMapping) IsSet() bool {
+	return len(m.Pass)+len(m.TestFailure)+len(m.InfraError) > 0
+}
+
+func containsExitCode(codes []int, exitCode int) bool {
+	for _, code := range codes {
+		if code == exitCode {
+			return true
+		}
+	}
+	return false
+}
+
 func (command Command) check() error {
 	if err := command.checkPath(); err != nil {
 		return err
 	}
+	if err := command.ExitCodes.check(); err != nil {
+		return err
+	}
 	if err := command.checkOsTable(); err != nil {
 		return err
 	}
@@ -203,6 +314,12 @@ func findCommand(commands []Command, osName OsName, archName ArchName) *Command
 	return nil
 }
 
+// FindCompatibleCommand returns the first command in the provided table that can run
+// on the local machine. Returns nil if there is none
+func FindCompatibleCommand(commands []Command) *Command {
+	return findCompatibleCommand(commands)
+}
+
 func findCompatibleCommand(commands []Command) *Command {
 	for _, command := range commands {
 		if command.runsOnLocalMachine() {
@@ -212,6 +329,33 @@ func findCompatibleCommand(commands []Command) *Command {
 	return nil
 }
 
+// compatibleCommandPath returns the path of the first command in the provided table
+// that can run on the local machine. Returns an empty string if there is none
+func compatibleCommandPath(commands []Command) string {
+	if command := findCompatibleCommand(commands); command != nil {
+		return command.Path
+	}
+	return ""
+}
+
+// compatibleCommandArgs returns the arguments of the first command in the provided table
+// that can run on the local machine. Returns nil if there is none
+func compatibleCommandArgs(commands []Command) []string {
+	if command := findCompatibleCommand(commands); command != nil {
+		return command.Arguments
+	}
+	return nil
+}
+
+// compatibleCommandLine returns the command line of the first command in the provided table
+// that can run on the local machine. Returns an empty string if there is none
+func compatibleCommandLine(commands []Command) string {
+	if command := findCompatibleCommand(commands); command != nil {
+		return command.asCommandLine()
+	}
+	return ""
+}
+
 func adjustCommandPath(cmdPath string) string {
 	// If this is an absolute path, we return it after cleaning it up
 	if filepath.IsAbs(cmdPath) {
@@ -25,6 +25,8 @@ package toolchain
 import (
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -137,19 +139,105 @@ func Test_a_valid_command_should_have_path_os_and_arch_non_empty(t *testing.T) {
 func Test_command_result_outcome(t *testing.T) {
 
 	testFlags := []struct {
-		status         CommandStatus
-		expectedPassed bool
-		expectedFailed bool
+		status             CommandStatus
+		expectedPassed     bool
+		expectedFailed     bool
+		expectedInfraError bool
 	}{
-		{"pass", true, false},
-		{"fail", false, true},
-		{"unknown", false, false},
+		{"pass", true, false, false},
+		{"fail", false, true, false},
+		{"error", false, true, true},
+		{"unknown", false, false, false},
 	}
 	for _, tt := range testFlags {
 		t.Run(fmt.Sprint(tt.status, "_status"), func(t *testing.T) {
 			result := CommandResult{Status: tt.status}
 			assert.Equal(t, tt.expectedPassed, result.Passed())
 			assert.Equal(t, tt.expectedFailed, result.Failed())
+			assert.Equal(t, tt.expectedInfraError, result.InfraError())
 		})
 	}
 }
+
+func Test_command_work_dir_path(t *testing.T) {
+	dir, _ := filepath.Abs(".")
+	_ = SetWorkDir(dir)
+	absDir, _ := filepath.Abs("/some/dir")
+	tests := []struct {
+		desc     string
+		workDir  string
+		expected string
+	}{
+		{"not set", "", dir},
+		{"relative path", "sub/dir", filepath.Join(dir, "sub", "dir")},
+		{"absolute path", absDir, absDir},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, ACommand(WithWorkDir(test.workDir)).WorkDirPath())
+		})
+	}
+}
+
+func Test_command_env_as_list(t *testing.T) {
+	assert.Nil(t, ACommand().EnvAsList())
+	cmd := ACommand(WithEnv("VAR_B", "b"), WithEnv("VAR_A", "a"))
+	assert.Equal(t, []string{"VAR_A=a", "VAR_B=b"}, cmd.EnvAsList())
+}
+
+func Test_run_command_with_env_and_work_dir(t *testing.T) {
+	dir, _ := filepath.Abs(".")
+	_ = SetWorkDir(filepath.Dir(dir))
+	cmd := ACommand(WithPath("go"), WithArgs([]string{"env", "GOFLAGS", "GOMOD"}),
+		WithWorkDir(filepath.Base(dir)), WithEnv("GOFLAGS", "-mod=mod"))
+	result := cmd.runQuietly()
+	assert.True(t, result.Passed())
+	lines := strings.Split(strings.TrimSpace(result.Output), "\n")
+	assert.Equal(t, "-mod=mod", strings.TrimSpace(lines[0]))
+	assert.Equal(t, filepath.Join(filepath.Dir(dir), "go.mod"), strings.TrimSpace(lines[1]))
+}
+
+func Test_exit_code_mapping(t *testing.T) {
+	mapping := ExitCodeMapping{Pass: []int{5}, TestFailure: []int{1}, InfraError: []int{2, 3}}
+	tests := []struct {
+		exitCode int
+		mapping  ExitCodeMapping
+		expected CommandStatus
+	}{
+		{0, ExitCodeMapping{}, CommandStatusPass},
+		{1, ExitCodeMapping{}, CommandStatusFail},
+		{2, ExitCodeMapping{}, CommandStatusFail},
+		{0, mapping, CommandStatusPass},
+		{1, mapping, CommandStatusFail},
+		{2, mapping, CommandStatusError},
+		{3, mapping, CommandStatusError},
+		{4, mapping, CommandStatusFail},
+		{5, mapping, CommandStatusPass},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprint(test.exitCode, "_", test.mapping.IsSet()), func(t *testing.T) {
+			assert.Equal(t, test.expected, test.mapping.statusFor(test.exitCode))
+		})
+	}
+}
+
+func Test_an_exit_code_cannot_be_mapped_more_than_once(t *testing.T) {
+	assert.NoError(t, ExitCodeMapping{Pass: []int{0}, InfraError: []int{2}}.check())
+	assert.Error(t, ExitCodeMapping{TestFailure: []int{1}, InfraError: []int{1}}.check())
+	assert.Error(t, ACommand(WithExitCodes(ExitCodeMapping{Pass: []int{0, 0}})).check())
+}
+
+func Test_run_command_with_exit_code_mapping(t *testing.T) {
+	dir, _ := filepath.Abs(".")
+	_ = SetWorkDir(dir)
+	// "go unknown-command" exits with code 2
+	cmd := ACommand(WithPath("go"), WithArgs([]string{"unknown-command"}))
+	assert.Equal(t, CommandStatusFail, cmd.runQuietly().Status)
+	WithExitCodes(ExitCodeMapping{InfraError: []int{2}})(cmd)
+	assert.Equal(t, CommandStatusError, cmd.runQuietly().Status)
+}
+
+func Test_run_command_that_cannot_be_launched(t *testing.T) {
+	cmd := ACommand(WithPath("unknown-command-path"))
+	assert.Equal(t, CommandStatusError, cmd.runQuietly().Status)
+}
@@ -68,3 +68,23 @@ func WithPath(path string) func(command *Command) {
 func WithArgs(args []string) func(command *Command) {
 	return func(command *Command) { command.Arguments = args }
 }
+
+// WithWorkDir sets the work directory for the created command
+func WithWorkDir(dir string) func(command *Command) {
+	return func(command *Command) { command.WorkDir = dir }
+}
+
+// WithEnv sets an environment variable for the created command
+func WithEnv(key string, value string) func(command *Command) {
+	return func(command *Command) {
+		if command.Env == nil {
+			command.Env = make(map[string]string)
+		}
+		command.Env[key] = value
+	}
+}
+
+// WithExitCodes sets the exit code mapping for the created command
+func WithExitCodes(mapping ExitCodeMapping) func(command *Command) {
+	return func(command *Command) { command.ExitCodes = mapping }
+}
@@ -23,9 +23,12 @@ SOFTWARE.
 package toolchain
 
 import (
+	"github.com/murex/tcr/coverage"
 	"github.com/murex/tcr/utils"
+	"github.com/spf13/afero"
 	"os"
 	"path/filepath"
+	"sort"
 )
 
 const (
@@ -39,18 +42,60 @@ var (
 type (
 	// commandConfigYAML defines the structure of a toolchain configuration.
 	commandConfigYAML struct {
-		Os        []string `yaml:"os,flow"`
-		Arch      []string `yaml:"arch,flow"`
-		Command   string   `yaml:"command"`
-		Arguments []string `yaml:"arguments,flow"`
+		Os        []string             `yaml:"os,flow"`
+		Arch      []string             `yaml:"arch,flow"`
+		Command   string               `yaml:"command"`
+		Arguments []string             `yaml:"arguments,flow"`
+		WorkDir   string               `yaml:"work-dir,omitempty"`
+		Env       map[string]string    `yaml:"env,omitempty"`
+		ExitCodes *exitCodesConfigYAML `yaml:"exit-codes,omitempty"`
+	}
+
+	// exitCodesConfigYAML defines the structure of a toolchain command exit codes mapping.
+	exitCodesConfigYAML struct {
+		Pass        []int `yaml:"pass,flow,omitempty"`
+		TestFailure []int `yaml:"test-failure,flow,omitempty"`
+		InfraError  []int `yaml:"infra-error,flow,omitempty"`
+	}
+
+	// coverageReportConfigYAML defines the structure of a toolchain coverage report configuration.
+	coverageReportConfigYAML struct {
+		Format    string `yaml:"format"`
+		Path      string `yaml:"path"`
+		Threshold int    `yaml:"threshold,omitempty"`
+	}
+
+	// testSelectionConfigYAML defines the structure of a toolchain test selection configuration.
+	testSelectionConfigYAML struct {
+		Mapping      string              `yaml:"mapping"`
+		Script       string              `yaml:"script,omitempty"`
+		FullRunEvery int                 `yaml:"full-run-every,omitempty"`
+		TestCommand  []commandConfigYAML `yaml:"test"`
+	}
+
+	// stepConfigYAML defines the structure of a toolchain build or test step configuration.
+	// FailsCycle is optional and defaults to true.
+	stepConfigYAML struct {
+		Name       string              `yaml:"name"`
+		FailsCycle *bool               `yaml:"fails-cycle,omitempty"`
+		Commands   []commandConfigYAML `yaml:"commands"`
 	}
 
 	// configYAML defines the structure of a toolchain configuration.
+	// Extends and Origins are set only when the configuration extends a built-in toolchain
 	configYAML struct {
-		Name          string              `yaml:"-"`
-		BuildCommand  []commandConfigYAML `yaml:"build"`
-		TestCommand   []commandConfigYAML `yaml:"test"`
-		TestResultDir string              `yaml:"test-result-dir"`
+		Name              string                    `yaml:"-"`
+		Extends           string                    `yaml:"-"`
+		Origins           utils.YAMLOrigins         `yaml:"-"`
+		BuildCommand      []commandConfigYAML       `yaml:"build"`
+		TestCommand       []commandConfigYAML       `yaml:"test"`
+		SlowTestCommand   []commandConfigYAML       `yaml:"slow-test,omitempty"`
+		TestResultDir     string                    `yaml:"test-result-dir"`
+		CoverageReport    *coverageReportConfigYAML `yaml:"coverage-report,omitempty"`
+		TestSelection     *testSelectionConfigYAML  `yaml:"test-selection,omitempty"`
+		BuildSteps        []stepConfigYAML          `yaml:"build-steps,omitempty"`
+		TestSteps         []stepConfigYAML          `yaml:"test-steps,omitempty"`
+		DetectInfraErrors bool                      `yaml:"detect-infra-errors,omitempty"`
 	}
 )
 
@@ -71,9 +116,25 @@ func SaveConfigs() {
 	}
 }
 
+// saveConfig saves the configuration of the toolchain with the provided name. When the existing
+// configuration file extends a built-in toolchain, only the values differing from the built-in toolchain
+// are saved, so that the file keeps extending it
 func saveConfig(name string) {
 	tchn, _ := Get(name)
-	utils.SaveToYAMLFile(appFS, asConfig(tchn), utils.BuildYAMLFilePath(toolchainDirPath, name))
+	filePath := utils.BuildYAMLFilePath(toolchainDirPath, name)
+	if exists, _ := afero.Exists(appFS, filePath); exists {
+		existing, err := readConfig(utils.BuildYAMLFilename(name))
+		if err == nil && existing.Extends != "" {
+			layer, err := utils.BuildYAMLLayer(asConfig(tchn), existing.Extends, loadBuiltInToolchainData)
+			if err != nil {
+				utils.Trace("Error while saving ", filePath, ": ", err)
+				return
+			}
+			utils.SaveToYAMLFile(appFS, layer, filePath)
+			return
+		}
+	}
+	utils.SaveToYAMLFile(appFS, asConfig(tchn), filePath)
 }
 
 // GetConfigFileList returns the list of toolchain configuration files found in toolchain directory
@@ -85,7 +146,16 @@ func loadConfigs() {
 	utils.Trace("Loading toolchains configuration")
 	// Loop on all YAML files in toolchain directory
 	for _, entry := range GetConfigFileList() {
-		err := Register(asToolchain(*loadConfig(entry)))
+		if issues := ValidateConfigFile(entry); len(issues) > 0 {
+			traceIssues(issues)
+			continue
+		}
+		cfg := loadConfig(entry)
+		if cfg == nil {
+			// loadConfig already traced the reason why the configuration could not be loaded
+			continue
+		}
+		err := Register(asToolchain(*cfg))
 		if err != nil {
 			utils.Trace("Error in ", entry, ": ", err)
 		}
@@ -93,23 +163,73 @@ func loadConfigs() {
 }
 
 func loadConfig(yamlFilename string) *configYAML {
-	var toolchainCfg configYAML
-	err := utils.LoadFromYAMLFile(os.DirFS(toolchainDirPath), yamlFilename, &toolchainCfg)
+	toolchainCfg, err := readConfig(yamlFilename)
 	if err != nil {
 		utils.Trace("Error in ", yamlFilename, ": ", err)
 		return nil
 	}
+	return toolchainCfg
+}
+
+func readConfig(yamlFilename string) (*configYAML, error) {
+	var toolchainCfg configYAML
+	var err error
+	toolchainCfg.Extends, toolchainCfg.Origins, err = utils.LoadFromLayeredYAMLFile(
+		os.DirFS(toolchainDirPath), yamlFilename, filepath.Join(toolchainDirPath, yamlFilename),
+		loadBuiltInToolchainData, &toolchainCfg)
+	if err != nil {
+		return nil, err
+	}
 	toolchainCfg.Name = utils.ExtractNameFromYAMLFilename(yamlFilename)
-	return &toolchainCfg
+	return &toolchainCfg, nil
 }
 
 func asToolchain(toolchainCfg configYAML) *Toolchain {
-	return New(
+	tchn := New(
 		toolchainCfg.Name,
 		asCommandTable(toolchainCfg.BuildCommand),
 		asCommandTable(toolchainCfg.TestCommand),
 		toolchainCfg.TestResultDir,
 	)
+	tchn.slowTestCommands = asCommandTable(toolchainCfg.SlowTestCommand)
+	tchn.coverageReport = asCoverageReport(toolchainCfg.CoverageReport)
+	tchn.testSelection = asTestSelection(toolchainCfg.TestSelection)
+	tchn.buildSteps = asStepTable(toolchainCfg.BuildSteps)
+	tchn.testSteps = asStepTable(toolchainCfg.TestSteps)
+	tchn.detectInfraErrors = toolchainCfg.DetectInfraErrors
+	return tchn
+}
+
+func asStepTable(stepsCfg []stepConfigYAML) []Step {
+	var res []Step
+	for _, stepCfg := range stepsCfg {
+		failsCycle := stepCfg.FailsCycle == nil || *stepCfg.FailsCycle
+		res = append(res, *NewStep(stepCfg.Name, failsCycle, asCommandTable(stepCfg.Commands)))
+	}
+	return res
+}
+
+func asTestSelection(selectionCfg *testSelectionConfigYAML) *TestSelection {
+	if selectionCfg == nil {
+		return nil
+	}
+	return NewTestSelection(
+		TestMapping(selectionCfg.Mapping),
+		selectionCfg.Script,
+		selectionCfg.FullRunEvery,
+		asCommandTable(selectionCfg.TestCommand),
+	)
+}
+
+func asCoverageReport(coverageCfg *coverageReportConfigYAML) *CoverageReport {
+	if coverageCfg == nil {
+		return nil
+	}
+	return &CoverageReport{
+		Format:    coverage.Format(coverageCfg.Format),
+		Path:      coverageCfg.Path,
+		Threshold: coverageCfg.Threshold,
+	}
 }
 
 func asCommandTable(commandsCfg []commandConfigYAML) []Command {
@@ -126,6 +246,20 @@ func asCommand(commandCfg commandConfigYAML) Command {
 		Arch:      asArchTable(commandCfg.Arch),
 		Path:      commandCfg.Command,
 		Arguments: commandCfg.Arguments,
+		WorkDir:   commandCfg.WorkDir,
+		Env:       commandCfg.Env,
+		ExitCodes: asExitCodeMapping(commandCfg.ExitCodes),
+	}
+}
+
+func asExitCodeMapping(exitCodesCfg *exitCodesConfigYAML) ExitCodeMapping {
+	if exitCodesCfg == nil {
+		return ExitCodeMapping{}
+	}
+	return ExitCodeMapping{
+		Pass:        exitCodesCfg.Pass,
+		TestFailure: exitCodesCfg.TestFailure,
+		InfraError:  exitCodesCfg.InfraError,
 	}
 }
 
@@ -157,10 +291,52 @@ func ResetConfigs() {
 
 func asConfig(tchn TchnInterface) configYAML {
 	return configYAML{
-		Name:          tchn.GetName(),
-		BuildCommand:  asCommandConfigTable(tchn.GetBuildCommands()),
-		TestCommand:   asCommandConfigTable(tchn.GetTestCommands()),
-		TestResultDir: tchn.GetTestResultDir(),
+		Name:              tchn.GetName(),
+		BuildCommand:      asCommandConfigTable(tchn.GetBuildCommands()),
+		TestCommand:       asCommandConfigTable(tchn.GetTestCommands()),
+		SlowTestCommand:   asCommandConfigTable(tchn.GetSlowTestCommands()),
+		TestResultDir:     tchn.GetTestResultDir(),
+		CoverageReport:    asCoverageReportConfig(tchn.GetCoverageReport()),
+		TestSelection:     asTestSelectionConfig(tchn.GetTestSelection()),
+		BuildSteps:        asStepConfigTable(tchn.GetBuildSteps()),
+		TestSteps:         asStepConfigTable(tchn.GetTestSteps()),
+		DetectInfraErrors: tchn.DetectsInfraErrors(),
+	}
+}
+
+func asStepConfigTable(steps []Step) []stepConfigYAML {
+	var res []stepConfigYAML
+	for _, step := range steps {
+		failsCycle := step.FailsCycle
+		res = append(res, stepConfigYAML{
+			Name:       step.Name,
+			FailsCycle: &failsCycle,
+			Commands:   asCommandConfigTable(step.GetCommands()),
+		})
+	}
+	return res
+}
+
+func asTestSelectionConfig(selection *TestSelection) *testSelectionConfigYAML {
+	if selection == nil {
+		return nil
+	}
+	return &testSelectionConfigYAML{
+		Mapping:      string(selection.Mapping),
+		Script:       selection.Script,
+		FullRunEvery: selection.FullRunEvery,
+		TestCommand:  asCommandConfigTable(selection.GetTestCommands()),
+	}
+}
+
+func asCoverageReportConfig(coverageReport *CoverageReport) *coverageReportConfigYAML {
+	if coverageReport == nil {
+		return nil
+	}
+	return &coverageReportConfigYAML{
+		Format:    string(coverageReport.Format),
+		Path:      coverageReport.Path,
+		Threshold: coverageReport.Threshold,
 	}
 }
 
@@ -178,6 +354,20 @@ func asCommandConfig(command Command) commandConfigYAML {
 		Arch:      asArchTableConfig(command.Arch),
 		Command:   command.Path,
 		Arguments: command.Arguments,
+		WorkDir:   command.WorkDir,
+		Env:       command.Env,
+		ExitCodes: asExitCodesConfig(command.ExitCodes),
+	}
+}
+
+func asExitCodesConfig(exitCodes ExitCodeMapping) *exitCodesConfigYAML {
+	if !exitCodes.IsSet() {
+		return nil
+	}
+	return &exitCodesConfigYAML{
+		Pass:        exitCodes.Pass,
+		TestFailure: exitCodes.TestFailure,
+		InfraError:  exitCodes.InfraError,
 	}
 }
 
@@ -218,24 +408,80 @@ func ShowConfigs() {
 		utils.Trace("- none (will use built-in toolchains)")
 	}
 	for _, entry := range entries {
-		loadConfig(entry).show()
+		if cfg := loadConfig(entry); cfg != nil {
+			cfg.show()
+		}
 	}
 }
 
+// show traces the toolchain configuration. When the configuration extends a built-in toolchain,
+// the origin of each value is traced as well
 func (t configYAML) show() {
 	prefix := "toolchain." + t.Name
+	trace := utils.TraceKeyValue
+	if t.Extends != "" {
+		utils.TraceKeyValue(prefix+".extends", t.Extends)
+		trace = t.Origins.Tracer(prefix + ".")
+	}
 	for _, cmd := range t.BuildCommand {
-		cmd.show(prefix + ".build")
+		cmd.show(prefix+".build", trace)
 	}
 	for _, cmd := range t.TestCommand {
-		cmd.show(prefix + ".test")
+		cmd.show(prefix+".test", trace)
+	}
+	for _, cmd := range t.SlowTestCommand {
+		cmd.show(prefix+".slow-test", trace)
+	}
+	trace(prefix+".test-result-dir", t.TestResultDir)
+	if t.CoverageReport != nil {
+		trace(prefix+".coverage-report.format", t.CoverageReport.Format)
+		trace(prefix+".coverage-report.path", t.CoverageReport.Path)
+		trace(prefix+".coverage-report.threshold", t.CoverageReport.Threshold)
+	}
+	if t.TestSelection != nil {
+		trace(prefix+".test-selection.mapping", t.TestSelection.Mapping)
+		trace(prefix+".test-selection.script", t.TestSelection.Script)
+		trace(prefix+".test-selection.full-run-every", t.TestSelection.FullRunEvery)
+		for _, cmd := range t.TestSelection.TestCommand {
+			cmd.show(prefix+".test-selection.test", trace)
+		}
+	}
+	showSteps(prefix+".build-steps", t.BuildSteps, trace)
+	showSteps(prefix+".test-steps", t.TestSteps, trace)
+	if t.DetectInfraErrors {
+		trace(prefix+".detect-infra-errors", t.DetectInfraErrors)
 	}
-	utils.TraceKeyValue(prefix+".test-result-dir", t.TestResultDir)
 }
 
-func (c commandConfigYAML) show(prefix string) {
-	utils.TraceKeyValue(prefix+".os", c.Os)
-	utils.TraceKeyValue(prefix+".arch", c.Arch)
-	utils.TraceKeyValue(prefix+".command", c.Command)
-	utils.TraceKeyValue(prefix+".args", c.Arguments)
+func showSteps(prefix string, steps []stepConfigYAML, trace func(key string, value any)) {
+	for _, step := range steps {
+		stepPrefix := prefix + "." + step.Name
+		trace(stepPrefix+".fails-cycle", step.FailsCycle == nil || *step.FailsCycle)
+		for _, cmd := range step.Commands {
+			cmd.show(stepPrefix, trace)
+		}
+	}
+}
+
+func (c commandConfigYAML) show(prefix string, trace func(key string, value any)) {
+	trace(prefix+".os", c.Os)
+	trace(prefix+".arch", c.Arch)
+	trace(prefix+".command", c.Command)
+	trace(prefix+".args", c.Arguments)
+	if c.WorkDir != "" {
+		trace(prefix+".work-dir", c.WorkDir)
+	}
+	keys := make([]string, 0, len(c.Env))
+	for key := range c.Env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		trace(prefix+".env."+key, c.Env[key])
+	}
+	if c.ExitCodes != nil {
+		trace(prefix+".exit-codes.pass", c.ExitCodes.Pass)
+		trace(prefix+".exit-codes.test-failure", c.ExitCodes.TestFailure)
+		trace(prefix+".exit-codes.infra-error", c.ExitCodes.InfraError)
+	}
 }
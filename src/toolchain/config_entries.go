@@ -0,0 +1,101 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package toolchain
+
+import (
+	"errors"
+	"fmt"
+	"github.com/murex/tcr/utils"
+)
+
+// GetConfigValue returns the value of the entry at the provided path (ex: "build.0.command")
+// in the configuration of the toolchain with the provided name
+func GetConfigValue(name string, path string) (any, error) {
+	entries, err := effectiveConfigEntries(name)
+	if err != nil {
+		return nil, err
+	}
+	return utils.GetYAMLEntry(entries, path)
+}
+
+func effectiveConfigEntries(name string) (map[string]any, error) {
+	tchn, err := Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return utils.AsYAMLEntries(asConfig(tchn))
+}
+
+// SetConfigValue sets the value of the entry at the provided path in the configuration file of
+// the toolchain with the provided name. When there is no such file yet for a built-in toolchain,
+// the file is created as an extension of the built-in toolchain
+func SetConfigValue(name string, path string, value string) error {
+	parsed, err := utils.ParseYAMLValue(value)
+	if err != nil {
+		return err
+	}
+	effective, err := effectiveConfigEntries(name)
+	if err != nil {
+		return err
+	}
+	return updateConfigEntries(name, func(entries map[string]any) error {
+		if err := utils.InheritYAMLList(entries, effective, path); err != nil {
+			return err
+		}
+		return utils.SetYAMLEntry(entries, path, parsed)
+	})
+}
+
+// UnsetConfigValue removes the entry at the provided path from the configuration file of
+// the toolchain with the provided name
+func UnsetConfigValue(name string, path string) error {
+	return updateConfigEntries(name, func(entries map[string]any) error {
+		return utils.UnsetYAMLEntry(entries, path)
+	})
+}
+
+func updateConfigEntries(name string, update func(entries map[string]any) error) error {
+	if _, err := Get(name); err != nil {
+		return err
+	}
+	filename := utils.BuildYAMLFilePath(toolchainDirPath, name)
+	entries, err := utils.LoadYAMLEntries(appFS, filename)
+	if err != nil {
+		return fmt.Errorf("cannot load %s: %w", filename, err)
+	}
+	if len(entries) == 0 && isBuiltIn(name) {
+		entries["extends"] = name
+	}
+	if err = update(entries); err != nil {
+		if errors.Is(err, utils.ErrYAMLEntryNotFound) {
+			return fmt.Errorf("toolchain.%s.%w in %s", name, err, filename)
+		}
+		return fmt.Errorf("toolchain.%s.%w", name, err)
+	}
+	if err = utils.CheckYAMLEntries(entries, &configYAML{}); err != nil {
+		return fmt.Errorf("invalid configuration for toolchain %s: %w", name, err)
+	}
+	createConfigDir()
+	utils.SaveToYAMLFile(appFS, entries, filename)
+	return nil
+}
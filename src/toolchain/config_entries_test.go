@@ -0,0 +1,90 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package toolchain
+
+import (
+	"github.com/murex/tcr/utils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func setupToolchainConfigDir(t *testing.T) {
+	t.Helper()
+	appFS = afero.NewMemMapFs()
+	initConfigDirPath(t.TempDir())
+}
+
+func Test_get_toolchain_config_value(t *testing.T) {
+	setupToolchainConfigDir(t)
+	value, err := GetConfigValue("maven", "test-result-dir")
+	assert.NoError(t, err)
+	assert.Equal(t, "target/surefire-reports", value)
+}
+
+func Test_get_toolchain_config_value_with_unknown_key(t *testing.T) {
+	setupToolchainConfigDir(t)
+	_, err := GetConfigValue("maven", "unknown")
+	assert.ErrorIs(t, err, utils.ErrYAMLEntryNotFound)
+}
+
+func Test_set_toolchain_config_value_in_a_list_item(t *testing.T) {
+	setupToolchainConfigDir(t)
+	assert.NoError(t, SetConfigValue("maven", "build.0.arguments", "[package]"))
+
+	entries, _ := utils.LoadYAMLEntries(appFS, utils.BuildYAMLFilePath(toolchainDirPath, "maven"))
+	assert.Equal(t, "maven", entries["extends"])
+	arguments, _ := utils.GetYAMLEntry(entries, "build.0.arguments")
+	assert.Equal(t, []any{"package"}, arguments)
+	command, _ := utils.GetYAMLEntry(entries, "build.0.command")
+	assert.NotEmpty(t, command)
+}
+
+func Test_set_toolchain_config_value_with_invalid_value(t *testing.T) {
+	testFlags := []struct {
+		desc  string
+		path  string
+		value string
+	}{
+		{"unknown key", "unknown", "value"},
+		{"string instead of a boolean", "detect-infra-errors", "maybe"},
+		{"missing list item", "build.9.command", "make"},
+	}
+	for _, tt := range testFlags {
+		t.Run(tt.desc, func(t *testing.T) {
+			setupToolchainConfigDir(t)
+			assert.Error(t, SetConfigValue("maven", tt.path, tt.value))
+			exists, _ := afero.Exists(appFS, utils.BuildYAMLFilePath(toolchainDirPath, "maven"))
+			assert.False(t, exists)
+		})
+	}
+}
+
+func Test_unset_toolchain_config_value(t *testing.T) {
+	setupToolchainConfigDir(t)
+	_ = SetConfigValue("maven", "detect-infra-errors", "true")
+
+	assert.NoError(t, UnsetConfigValue("maven", "detect-infra-errors"))
+	entries, _ := utils.LoadYAMLEntries(appFS, utils.BuildYAMLFilePath(toolchainDirPath, "maven"))
+	assert.Equal(t, map[string]any{"extends": "maven"}, entries)
+}
@@ -24,9 +24,11 @@ package toolchain
 
 import (
 	"fmt"
+	"github.com/murex/tcr/coverage"
 	"github.com/murex/tcr/utils"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"io"
 	"os"
 	"testing"
 )
@@ -66,6 +68,126 @@ func Test_convert_toolchain_command_arguments_to_config(t *testing.T) {
 	assert.Equal(t, fmt.Sprint(cfg.Arguments), fmt.Sprint(asCommand(cfg).Arguments))
 }
 
+func Test_convert_toolchain_command_work_dir_and_env_to_config(t *testing.T) {
+	cmd := ACommand(WithWorkDir("some/dir"), WithEnv("SOME_VAR", "some-value"))
+	cfg := asCommandConfig(*cmd)
+	assert.Equal(t, "some/dir", cfg.WorkDir)
+	assert.Equal(t, map[string]string{"SOME_VAR": "some-value"}, cfg.Env)
+	assert.Equal(t, *cmd, asCommand(cfg))
+}
+
+func Test_show_toolchain_command_config_with_work_dir_and_env(t *testing.T) {
+	cfg := asCommandConfig(*ACommand(WithWorkDir("some/dir"),
+		WithEnv("VAR_B", "b"), WithEnv("VAR_A", "a")))
+	expected := []string{
+		fmt.Sprintf("- cmd.os: %v", cfg.Os),
+		fmt.Sprintf("- cmd.arch: %v", cfg.Arch),
+		fmt.Sprintf("- cmd.command: %v", cfg.Command),
+		fmt.Sprintf("- cmd.args: %v", cfg.Arguments),
+		"- cmd.work-dir: some/dir",
+		"- cmd.env.VAR_A: a",
+		"- cmd.env.VAR_B: b",
+	}
+	utils.AssertSimpleTrace(t, expected,
+		func() {
+			cfg.show("cmd", utils.TraceKeyValue)
+		},
+	)
+}
+
+func Test_convert_toolchain_steps_to_config(t *testing.T) {
+	tchn := AToolchain(
+		WithBuildStep(NewStep("lint", false, []Command{*ACommand(WithPath("lint-cmd"))})),
+		WithTestStep(NewStep("contract", true, []Command{*ACommand(WithPath("contract-cmd"))})),
+	)
+	cfg := asConfig(tchn)
+	assert.Equal(t, "lint", cfg.BuildSteps[0].Name)
+	assert.False(t, *cfg.BuildSteps[0].FailsCycle)
+	assert.Equal(t, "contract-cmd", cfg.TestSteps[0].Commands[0].Command)
+	assert.Equal(t, tchn.GetBuildSteps(), asToolchain(cfg).GetBuildSteps())
+	assert.Equal(t, tchn.GetTestSteps(), asToolchain(cfg).GetTestSteps())
+}
+
+func Test_step_fails_cycle_by_default(t *testing.T) {
+	steps := asStepTable([]stepConfigYAML{{Name: "lint", Commands: []commandConfigYAML{{Command: "lint-cmd"}}}})
+	assert.True(t, steps[0].FailsCycle)
+}
+
+func Test_show_toolchain_steps_config(t *testing.T) {
+	cfg := asConfig(AToolchain(
+		WithBuildStep(NewStep("lint", false, []Command{*ACommand(WithPath("lint-cmd"))}))))
+	cmd := cfg.BuildSteps[0].Commands[0]
+	expected := []string{
+		"- steps.lint.fails-cycle: false",
+		fmt.Sprintf("- steps.lint.os: %v", cmd.Os),
+		fmt.Sprintf("- steps.lint.arch: %v", cmd.Arch),
+		"- steps.lint.command: lint-cmd",
+		"- steps.lint.args: []",
+	}
+	utils.AssertSimpleTrace(t, expected,
+		func() {
+			showSteps("steps", cfg.BuildSteps, utils.TraceKeyValue)
+		},
+	)
+}
+
+func Test_convert_toolchain_exit_codes_to_config(t *testing.T) {
+	cmd := ACommand(WithExitCodes(ExitCodeMapping{Pass: []int{0}, TestFailure: []int{1}, InfraError: []int{2}}))
+	cfg := asCommandConfig(*cmd)
+	assert.Equal(t, []int{2}, cfg.ExitCodes.InfraError)
+	assert.Equal(t, *cmd, asCommand(cfg))
+	assert.Nil(t, asCommandConfig(*ACommand()).ExitCodes)
+}
+
+func Test_convert_toolchain_infra_error_detection_to_config(t *testing.T) {
+	tchn := AToolchain(WithInfraErrorDetection())
+	cfg := asConfig(tchn)
+	assert.True(t, cfg.DetectInfraErrors)
+	assert.True(t, asToolchain(cfg).DetectsInfraErrors())
+}
+
+func Test_convert_toolchain_coverage_report_to_config(t *testing.T) {
+	tests := []struct {
+		desc           string
+		coverageReport *CoverageReport
+	}{
+		{"no coverage report", nil},
+		{"with coverage report", &CoverageReport{Format: coverage.FormatLCOV, Path: "lcov.info", Threshold: 80}},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			tchn := AToolchain(WithCoverageReport(test.coverageReport))
+			cfg := asConfig(tchn)
+			assert.Equal(t, test.coverageReport, asToolchain(cfg).GetCoverageReport())
+		})
+	}
+}
+
+func Test_convert_toolchain_slow_test_commands_to_config(t *testing.T) {
+	tchn := AToolchain(WithSlowTestCommand(ACommand(WithPath("slow-test-command"))))
+	cfg := asConfig(tchn)
+	assert.Equal(t, "slow-test-command", cfg.SlowTestCommand[0].Command)
+	assert.Equal(t, tchn.GetSlowTestCommands(), asToolchain(cfg).GetSlowTestCommands())
+}
+
+func Test_convert_toolchain_test_selection_to_config(t *testing.T) {
+	tests := []struct {
+		desc      string
+		selection *TestSelection
+	}{
+		{"no test selection", nil},
+		{"with test selection", NewTestSelection(ScriptMapping, "./select.sh", 5,
+			[]Command{*ACommand(WithArgs([]string{TestTargetsPlaceholder}))})},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			tchn := AToolchain(WithTestSelection(test.selection))
+			cfg := asConfig(tchn)
+			assert.Equal(t, test.selection, asToolchain(cfg).GetTestSelection())
+		})
+	}
+}
+
 func Test_show_toolchain_configs_with_no_saved_config(t *testing.T) {
 	expected := []string{
 		"Configured toolchains:",
@@ -117,6 +239,34 @@ func Test_show_toolchain_config(t *testing.T) {
 	)
 }
 
+func Test_show_toolchain_config_with_coverage_report(t *testing.T) {
+	tchn := AToolchain(WithCoverageReport(
+		&CoverageReport{Format: coverage.FormatCobertura, Path: "coverage.xml", Threshold: 75}))
+	cfg := asConfig(tchn)
+	prefix := "- toolchain." + cfg.Name
+	buildCmd := cfg.BuildCommand[0]
+	testCmd := cfg.TestCommand[0]
+	expected := []string{
+		fmt.Sprintf("%v.build.os: %v", prefix, buildCmd.Os),
+		fmt.Sprintf("%v.build.arch: %v", prefix, buildCmd.Arch),
+		fmt.Sprintf("%v.build.command: %v", prefix, buildCmd.Command),
+		fmt.Sprintf("%v.build.args: %v", prefix, buildCmd.Arguments),
+		fmt.Sprintf("%v.test.os: %v", prefix, testCmd.Os),
+		fmt.Sprintf("%v.test.arch: %v", prefix, testCmd.Arch),
+		fmt.Sprintf("%v.test.command: %v", prefix, testCmd.Command),
+		fmt.Sprintf("%v.test.args: %v", prefix, testCmd.Arguments),
+		fmt.Sprintf("%v.test-result-dir: %v", prefix, tchn.GetTestResultDir()),
+		fmt.Sprintf("%v.coverage-report.format: cobertura", prefix),
+		fmt.Sprintf("%v.coverage-report.path: coverage.xml", prefix),
+		fmt.Sprintf("%v.coverage-report.threshold: 75", prefix),
+	}
+	utils.AssertSimpleTrace(t, expected,
+		func() {
+			cfg.show()
+		},
+	)
+}
+
 func Test_save_and_load_a_toolchain_config(t *testing.T) {
 	const name = "my-toolchain"
 	tchn := AToolchain(WithName(name))
@@ -192,3 +342,127 @@ func Test_save_and_load_all_toolchain_configs(t *testing.T) {
 		}
 	}
 }
+
+func Test_show_toolchain_config_with_test_selection(t *testing.T) {
+	tchn := AToolchain(WithTestSelection(NewTestSelection(PackageMapping, "", 10,
+		[]Command{*ACommand(WithPath("selected-test-cmd"), WithArgs([]string{TestTargetsPlaceholder}))})))
+	cfg := asConfig(tchn)
+	prefix := "- toolchain." + cfg.Name
+	buildCmd := cfg.BuildCommand[0]
+	testCmd := cfg.TestCommand[0]
+	selectedCmd := cfg.TestSelection.TestCommand[0]
+	expected := []string{
+		fmt.Sprintf("%v.build.os: %v", prefix, buildCmd.Os),
+		fmt.Sprintf("%v.build.arch: %v", prefix, buildCmd.Arch),
+		fmt.Sprintf("%v.build.command: %v", prefix, buildCmd.Command),
+		fmt.Sprintf("%v.build.args: %v", prefix, buildCmd.Arguments),
+		fmt.Sprintf("%v.test.os: %v", prefix, testCmd.Os),
+		fmt.Sprintf("%v.test.arch: %v", prefix, testCmd.Arch),
+		fmt.Sprintf("%v.test.command: %v", prefix, testCmd.Command),
+		fmt.Sprintf("%v.test.args: %v", prefix, testCmd.Arguments),
+		fmt.Sprintf("%v.test-result-dir: %v", prefix, tchn.GetTestResultDir()),
+		fmt.Sprintf("%v.test-selection.mapping: package", prefix),
+		fmt.Sprintf("%v.test-selection.script: ", prefix),
+		fmt.Sprintf("%v.test-selection.full-run-every: 10", prefix),
+		fmt.Sprintf("%v.test-selection.test.os: %v", prefix, selectedCmd.Os),
+		fmt.Sprintf("%v.test-selection.test.arch: %v", prefix, selectedCmd.Arch),
+		fmt.Sprintf("%v.test-selection.test.command: selected-test-cmd", prefix),
+		fmt.Sprintf("%v.test-selection.test.args: [{{.TestTargets}}]", prefix),
+	}
+	utils.AssertSimpleTrace(t, expected,
+		func() {
+			cfg.show()
+		},
+	)
+}
+
+func setupToolchainConfigFile(t *testing.T, name string, data string) string {
+	t.Helper()
+	appFS = afero.NewOsFs()
+	dir := t.TempDir()
+	initConfigDirPath(dir)
+	createConfigDir()
+	err := afero.WriteFile(appFS, utils.BuildYAMLFilePath(toolchainDirPath, name), []byte(data), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return utils.BuildYAMLFilePath(toolchainDirPath, name)
+}
+
+func Test_load_a_toolchain_config_extending_a_built_in_toolchain(t *testing.T) {
+	filePath := setupToolchainConfigFile(t, "go-tools",
+		"extends: go-tools\ntest-result-dir: _test_results\ndetect-infra-errors: true\n")
+
+	cfg := loadConfig(utils.BuildYAMLFilename("go-tools"))
+
+	builtIn := loadBuiltInToolchain("go-tools.yml")
+	if assert.NotNil(t, cfg) {
+		assert.Equal(t, "go-tools", cfg.Extends)
+		assert.Equal(t, builtIn.BuildCommand, cfg.BuildCommand)
+		assert.Equal(t, builtIn.TestCommand, cfg.TestCommand)
+		assert.Equal(t, "_test_results", cfg.TestResultDir)
+		assert.True(t, cfg.DetectInfraErrors)
+		assert.Equal(t, filePath, cfg.Origins.Get("test-result-dir"))
+		assert.Equal(t, "built-in go-tools", cfg.Origins.Get("build.command"))
+	}
+}
+
+func Test_save_and_load_a_toolchain_config_extending_a_built_in_toolchain(t *testing.T) {
+	filePath := setupToolchainConfigFile(t, "go-tools",
+		"extends: go-tools\ntest-result-dir: _test_results\n")
+	utils.SetSimpleTrace(io.Discard)
+	loadConfigs()
+	t.Cleanup(func() { Reset("go-tools") })
+
+	saveConfig("go-tools")
+
+	data, err := os.ReadFile(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "extends: go-tools\ntest-result-dir: _test_results\n", string(data))
+	cfg := loadConfig(utils.BuildYAMLFilename("go-tools"))
+	if assert.NotNil(t, cfg) {
+		assert.Equal(t, "go-tools", cfg.Extends)
+		assert.Equal(t, "_test_results", cfg.TestResultDir)
+	}
+}
+
+func Test_load_a_toolchain_config_extending_an_unknown_toolchain(t *testing.T) {
+	setupToolchainConfigFile(t, "my-toolchain", "extends: unknown-toolchain\n")
+	assert.Nil(t, loadConfig(utils.BuildYAMLFilename("my-toolchain")))
+}
+
+func Test_load_configs_skips_a_toolchain_config_that_cannot_be_loaded(t *testing.T) {
+	setupToolchainConfigFile(t, "my-toolchain", "extends: unknown-toolchain\n")
+	utils.SetSimpleTrace(io.Discard)
+	assert.NotPanics(t, loadConfigs)
+	assert.False(t, isSupported("my-toolchain"))
+	assert.NotPanics(t, ShowConfigs)
+}
+
+func Test_show_a_toolchain_config_extending_a_built_in_toolchain(t *testing.T) {
+	filePath := setupToolchainConfigFile(t, "go-tools",
+		"extends: go-tools\ntest-result-dir: _test_results\n")
+	cfg := loadConfig(utils.BuildYAMLFilename("go-tools"))
+	if !assert.NotNil(t, cfg) {
+		return
+	}
+	prefix := "- toolchain.go-tools"
+	builtIn := " (from built-in go-tools)"
+	expected := []string{
+		fmt.Sprintf("%v.extends: go-tools", prefix),
+		fmt.Sprintf("%v.build.os: %v%v", prefix, cfg.BuildCommand[0].Os, builtIn),
+		fmt.Sprintf("%v.build.arch: %v%v", prefix, cfg.BuildCommand[0].Arch, builtIn),
+		fmt.Sprintf("%v.build.command: %v%v", prefix, cfg.BuildCommand[0].Command, builtIn),
+		fmt.Sprintf("%v.build.args: %v%v", prefix, cfg.BuildCommand[0].Arguments, builtIn),
+		fmt.Sprintf("%v.test.os: %v%v", prefix, cfg.TestCommand[0].Os, builtIn),
+		fmt.Sprintf("%v.test.arch: %v%v", prefix, cfg.TestCommand[0].Arch, builtIn),
+		fmt.Sprintf("%v.test.command: %v%v", prefix, cfg.TestCommand[0].Command, builtIn),
+		fmt.Sprintf("%v.test.args: %v%v", prefix, cfg.TestCommand[0].Arguments, builtIn),
+		fmt.Sprintf("%v.test-result-dir: _test_results (from %v)", prefix, filePath),
+	}
+	utils.AssertSimpleTrace(t, expected,
+		func() {
+			cfg.show()
+		},
+	)
+}
@@ -0,0 +1,82 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package toolchain
+
+import (
+	"errors"
+	"github.com/murex/tcr/coverage"
+	"path/filepath"
+)
+
+// CoverageReport describes where a toolchain's code coverage report can be found.
+// - Format is the format of the coverage report file.
+// - Path is the path to the coverage report file, relative to the work directory.
+// - Threshold is the minimum percentage of covered lines required on changed source files
+// for tests to be considered as passing. 0 means that coverage is recorded but not enforced.
+type CoverageReport struct {
+	Format    coverage.Format
+	Path      string
+	Threshold int
+}
+
+func (c CoverageReport) check() error {
+	if err := coverage.CheckFormat(c.Format); err != nil {
+		return err
+	}
+	if c.Path == "" {
+		return errors.New("coverage report path is empty")
+	}
+	if c.Threshold < 0 || c.Threshold > 100 {
+		return errors.New("coverage threshold must be between 0 and 100")
+	}
+	return nil
+}
+
+// GetCoverageReport returns the toolchain's coverage report settings,
+// or nil if the toolchain is not configured to collect code coverage
+func (tchn Toolchain) GetCoverageReport() *CoverageReport {
+	return tchn.coverageReport
+}
+
+// GetCoverageReportPath provides the absolute path to the coverage report file.
+// Returns an empty string if the toolchain is not configured to collect code coverage
+func (tchn Toolchain) GetCoverageReportPath() string {
+	if tchn.coverageReport == nil {
+		return ""
+	}
+	return filepath.Join(workDir, tchn.coverageReport.Path)
+}
+
+func (tchn Toolchain) checkCoverageReport() error {
+	if tchn.coverageReport == nil {
+		return nil
+	}
+	return tchn.coverageReport.check()
+}
+
+func (tchn Toolchain) parseCoverageReport() (*coverage.Report, error) {
+	if tchn.coverageReport == nil {
+		return nil, nil
+	}
+	return coverage.ParseFile(tchn.coverageReport.Format, tchn.GetCoverageReportPath())
+}
@@ -0,0 +1,87 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package toolchain
+
+import (
+	"github.com/murex/tcr/coverage"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+)
+
+func Test_check_coverage_report(t *testing.T) {
+	tests := []struct {
+		desc           string
+		coverageReport *CoverageReport
+		expectError    bool
+	}{
+		{"no coverage report", nil, false},
+		{"valid coverage report", &CoverageReport{Format: coverage.FormatLCOV, Path: "lcov.info", Threshold: 80}, false},
+		{"unknown format", &CoverageReport{Format: "unknown", Path: "lcov.info"}, true},
+		{"empty path", &CoverageReport{Format: coverage.FormatLCOV, Path: ""}, true},
+		{"negative threshold", &CoverageReport{Format: coverage.FormatLCOV, Path: "lcov.info", Threshold: -1}, true},
+		{"threshold above 100", &CoverageReport{Format: coverage.FormatLCOV, Path: "lcov.info", Threshold: 101}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			err := AToolchain(WithCoverageReport(test.coverageReport)).checkCoverageReport()
+			if test.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_get_coverage_report_path(t *testing.T) {
+	workDir, _ = filepath.Abs("/")
+	t.Run("no coverage report", func(t *testing.T) {
+		assert.Equal(t, "", AToolchain().GetCoverageReportPath())
+	})
+	t.Run("with coverage report", func(t *testing.T) {
+		tchn := AToolchain(WithCoverageReport(&CoverageReport{Format: coverage.FormatLCOV, Path: "some/lcov.info"}))
+		assert.Equal(t, filepath.Join(workDir, "some/lcov.info"), tchn.GetCoverageReportPath())
+	})
+}
+
+func Test_parse_coverage_report(t *testing.T) {
+	t.Run("no coverage report", func(t *testing.T) {
+		r, err := AToolchain().parseCoverageReport()
+		assert.NoError(t, err)
+		assert.Nil(t, r)
+	})
+	t.Run("with coverage report", func(t *testing.T) {
+		dir := t.TempDir()
+		workDir = dir
+		_ = afero.WriteFile(afero.NewOsFs(), filepath.Join(dir, "lcov.info"),
+			[]byte("SF:a.js\nDA:1,1\nDA:2,0\nend_of_record\n"), 0644)
+		tchn := AToolchain(WithCoverageReport(&CoverageReport{Format: coverage.FormatLCOV, Path: "lcov.info"}))
+		r, err := tchn.parseCoverageReport()
+		assert.NoError(t, err)
+		if assert.NotNil(t, r) {
+			assert.Equal(t, coverage.LineStats{Covered: 1, Total: 2}, r.LineStats())
+		}
+	})
+}
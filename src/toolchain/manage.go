@@ -0,0 +1,180 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package toolchain
+
+import (
+	"errors"
+	"fmt"
+	"github.com/murex/tcr/utils"
+	"github.com/spf13/afero"
+	"runtime"
+)
+
+// AddOptions contains the settings of a toolchain created through Add.
+// - Template is the name of an existing toolchain used as a starting point. Optional.
+// - BuildCommand and TestCommand replace the template's build and test commands on all platforms.
+// - BuildArgs and TestArgs replace the arguments of the corresponding commands.
+// - TestResultDir replaces the template's test result directory.
+// Settings left empty are taken from the template
+type AddOptions struct {
+	Template      string
+	BuildCommand  string
+	BuildArgs     []string
+	TestCommand   string
+	TestArgs      []string
+	TestResultDir string
+}
+
+// ShowList shows the list of available toolchains, together with where each one is defined
+func ShowList() {
+	utils.Trace("Available toolchains:")
+	for _, name := range Names() {
+		utils.TraceKeyValue(name, definitionOrigin(name))
+	}
+}
+
+const builtInOrigin = "built-in"
+
+// definitionOrigin tells where the toolchain with the provided name is defined
+func definitionOrigin(name string) string {
+	filename := utils.BuildYAMLFilePath(toolchainDirPath, name)
+	if exists, _ := afero.Exists(appFS, filename); !exists {
+		return builtInOrigin
+	}
+	if isBuiltIn(name) {
+		return builtInOrigin + ", customized in " + filename
+	}
+	return filename
+}
+
+// ShowConfig shows the configuration of the toolchain with the provided name
+func ShowConfig(name string) error {
+	tchn, err := Get(name)
+	if err != nil {
+		return err
+	}
+	origin := definitionOrigin(tchn.GetName())
+	utils.Trace("Toolchain ", tchn.GetName(), " (", origin, "):")
+	if origin != builtInOrigin {
+		if cfg, err := readConfig(utils.BuildYAMLFilename(tchn.GetName())); err == nil {
+			cfg.show()
+			return nil
+		}
+	}
+	asConfig(tchn).show()
+	return nil
+}
+
+// Add creates a new toolchain with the provided name and options, and saves its configuration
+// in toolchain configuration directory
+func Add(name string, opts AddOptions) error {
+	if name == "" {
+		return errors.New("toolchain name not provided")
+	}
+	if _, err := Get(name); err == nil {
+		return fmt.Errorf("toolchain already exists: %s", name)
+	}
+	var cfg configYAML
+	if opts.Template != "" {
+		tmpl, err := Get(opts.Template)
+		if err != nil {
+			return err
+		}
+		cfg = asConfig(tmpl)
+	}
+	cfg.Name = name
+	cfg.BuildCommand = overrideCommands(cfg.BuildCommand, opts.BuildCommand, opts.BuildArgs)
+	cfg.TestCommand = overrideCommands(cfg.TestCommand, opts.TestCommand, opts.TestArgs)
+	if opts.TestResultDir != "" {
+		cfg.TestResultDir = opts.TestResultDir
+	}
+	if err := Register(asToolchain(cfg)); err != nil {
+		return fmt.Errorf("invalid configuration for toolchain %s: %w", name, err)
+	}
+	createConfigDir()
+	filename := utils.BuildYAMLFilePath(toolchainDirPath, name)
+	utils.Trace("Creating toolchain configuration: ", filename)
+	utils.SaveToYAMLFile(appFS, cfg, filename)
+	return nil
+}
+
+// overrideCommands returns the provided commands with the provided command path and arguments.
+// When a command path is provided, it replaces the commands with a single command running on all platforms.
+// When only arguments are provided, they replace the arguments of each command
+func overrideCommands(commands []commandConfigYAML, path string, args []string) []commandConfigYAML {
+	if path != "" {
+		return []commandConfigYAML{{
+			Os:        asOsTableConfig(GetAllOsNames()),
+			Arch:      asArchTableConfig(GetAllArchNames()),
+			Command:   path,
+			Arguments: args,
+		}}
+	}
+	if args == nil {
+		return commands
+	}
+	res := make([]commandConfigYAML, len(commands))
+	for i, command := range commands {
+		res[i] = command
+		res[i].Arguments = args
+	}
+	return res
+}
+
+// Try runs the build and test commands of the toolchain with the provided name once, then
+// reports their outcome, and whether test results were found in the toolchain's test result directory.
+// It returns an error if any of these verifications fails
+func Try(name string) error {
+	tchn, err := Get(name)
+	if err != nil {
+		return err
+	}
+	if !tchn.runsOnPlatform(OsName(runtime.GOOS), ArchName(runtime.GOARCH)) {
+		return fmt.Errorf("toolchain %s does not run on %s/%s", tchn.GetName(), runtime.GOOS, runtime.GOARCH)
+	}
+
+	utils.Trace("Running ", tchn.GetName(), " build command: ", tchn.BuildCommandLine())
+	if result := tchn.RunBuild(); result.Failed() {
+		return fmt.Errorf("%s build failed", tchn.GetName())
+	}
+	utils.Trace("Build passed")
+
+	utils.Trace("Running ", tchn.GetName(), " test command: ", tchn.TestCommandLine())
+	result := tchn.RunTests()
+	if result.Failed() {
+		utils.Trace("Tests failed")
+	} else {
+		utils.Trace("Tests passed")
+	}
+
+	if result.Stats.TotalRun == 0 {
+		return fmt.Errorf("no test result found in %s", tchn.GetTestResultPath())
+	}
+	utils.Trace("Found ", result.Stats.TotalRun, " test results in ", tchn.GetTestResultPath(),
+		" (", result.Stats.Passed, " passed, ", result.Stats.Failed, " failed, ",
+		result.Stats.Skipped, " skipped, ", result.Stats.WithErrors, " with errors)")
+	if result.Failed() {
+		return fmt.Errorf("%s tests failed", tchn.GetName())
+	}
+	return nil
+}
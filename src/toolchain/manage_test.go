@@ -0,0 +1,171 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package toolchain
+
+import (
+	"bytes"
+	"github.com/murex/tcr/utils"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_show_toolchain_list(t *testing.T) {
+	setupToolchainConfigDir(t)
+	var output bytes.Buffer
+	utils.SetSimpleTrace(&output)
+	ShowList()
+	assert.Contains(t, output.String(), "Available toolchains:")
+	assert.Contains(t, output.String(), "- maven: built-in\n")
+}
+
+func Test_show_toolchain_list_with_customized_built_in(t *testing.T) {
+	setupToolchainConfigDir(t)
+	_ = SetConfigValue("maven", "test-result-dir", "results")
+	t.Cleanup(func() { Reset("maven") })
+	var output bytes.Buffer
+	utils.SetSimpleTrace(&output)
+	ShowList()
+	assert.Contains(t, output.String(), "- maven: built-in, customized in "+
+		utils.BuildYAMLFilePath(toolchainDirPath, "maven"))
+}
+
+func Test_show_single_toolchain_config(t *testing.T) {
+	setupToolchainConfigDir(t)
+	var output bytes.Buffer
+	utils.SetSimpleTrace(&output)
+	assert.NoError(t, ShowConfig("maven"))
+	assert.Contains(t, output.String(), "Toolchain maven (built-in):")
+	assert.Contains(t, output.String(), "- toolchain.maven.test-result-dir: target/surefire-reports")
+}
+
+func Test_show_unknown_toolchain_config(t *testing.T) {
+	setupToolchainConfigDir(t)
+	assert.Error(t, ShowConfig("unknown"))
+}
+
+func Test_add_toolchain_from_template(t *testing.T) {
+	setupToolchainConfigDir(t)
+	utils.SetSimpleTrace(io.Discard)
+	t.Cleanup(func() { Unregister("my-maven") })
+	assert.NoError(t, Add("my-maven", AddOptions{Template: "maven", BuildArgs: []string{"package"}}))
+
+	assertBuildCommandArgs(t, "my-maven", []string{"package"})
+	assertTestResultDir(t, "my-maven", "target/surefire-reports")
+	exists, _ := afero.Exists(appFS, utils.BuildYAMLFilePath(toolchainDirPath, "my-maven"))
+	assert.True(t, exists)
+}
+
+func Test_add_toolchain_from_options(t *testing.T) {
+	setupToolchainConfigDir(t)
+	utils.SetSimpleTrace(io.Discard)
+	t.Cleanup(func() { Unregister("my-make") })
+	assert.NoError(t, Add("my-make", AddOptions{
+		BuildCommand:  "make",
+		BuildArgs:     []string{"build"},
+		TestCommand:   "make",
+		TestArgs:      []string{"test"},
+		TestResultDir: "reports",
+	}))
+
+	assertBuildCommandPath(t, "my-make", "make")
+	assertBuildCommandArgs(t, "my-make", []string{"build"})
+	assertTestCommandPath(t, "my-make", "make")
+	assertTestCommandArgs(t, "my-make", []string{"test"})
+	assertTestResultDir(t, "my-make", "reports")
+}
+
+func Test_add_toolchain_errors(t *testing.T) {
+	testFlags := []struct {
+		desc string
+		name string
+		opts AddOptions
+	}{
+		{"no name", "", AddOptions{Template: "maven"}},
+		{"existing toolchain", "maven", AddOptions{Template: "gradle"}},
+		{"unknown template", "my-toolchain", AddOptions{Template: "unknown"}},
+		{"no test command", "my-toolchain", AddOptions{BuildCommand: "make"}},
+	}
+	for _, tt := range testFlags {
+		t.Run(tt.desc, func(t *testing.T) {
+			setupToolchainConfigDir(t)
+			assert.Error(t, Add(tt.name, tt.opts))
+			assert.False(t, isSupported("my-toolchain"))
+		})
+	}
+}
+
+func Test_try_toolchain(t *testing.T) {
+	const junitReport = `<testsuite name="suite" tests="2">
+  <testcase classname="Some" name="test1"/>
+  <testcase classname="Some" name="test2"/>
+</testsuite>`
+	testFlags := []struct {
+		desc          string
+		testResultDir string
+		reportFile    string
+		expectError   bool
+	}{
+		{"with test results", ".", "TEST-report.xml", false},
+		{"without test results", ".", "", true},
+		{"with stale test results", "reports", "TEST-report.xml", true},
+	}
+	for _, tt := range testFlags {
+		t.Run(tt.desc, func(t *testing.T) {
+			setupToolchainConfigDir(t)
+			utils.SetSimpleTrace(io.Discard)
+			previousWorkDir := workDir
+			assert.NoError(t, SetWorkDir(t.TempDir()))
+			t.Cleanup(func() {
+				Unregister("go-version")
+				workDir = previousWorkDir
+			})
+			reportPath := filepath.Join(GetWorkDir(), tt.testResultDir, tt.reportFile)
+			if tt.reportFile != "" {
+				assert.NoError(t, os.MkdirAll(filepath.Dir(reportPath), os.ModePerm))
+				assert.NoError(t, os.WriteFile(reportPath, []byte(junitReport), 0600))
+			}
+			_ = Add("go-version", AddOptions{
+				BuildCommand: "go", BuildArgs: []string{"version"},
+				TestCommand: "go", TestArgs: []string{"version"},
+				TestResultDir: tt.testResultDir,
+			})
+			err := Try("go-version")
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			if tt.testResultDir != "." {
+				assert.NoFileExists(t, reportPath)
+			}
+		})
+	}
+}
+
+func Test_try_unknown_toolchain(t *testing.T) {
+	assert.Error(t, Try("unknown"))
+}
@@ -45,6 +45,15 @@ func Register(tchn TchnInterface) error {
 	if err := tchn.checkTestCommand(); err != nil {
 		return err
 	}
+	if err := tchn.checkCoverageReport(); err != nil {
+		return err
+	}
+	if err := tchn.checkTestSelection(); err != nil {
+		return err
+	}
+	if err := tchn.checkSteps(); err != nil {
+		return err
+	}
 	registered[strings.ToLower(tchn.GetName())] = tchn
 	return nil
 }
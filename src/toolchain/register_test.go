@@ -84,6 +84,26 @@ func Test_cannot_register_a_toolchain_with_no_test_command(t *testing.T) {
 	assert.False(t, isSupported(name))
 }
 
+func Test_cannot_register_a_toolchain_with_invalid_coverage_report(t *testing.T) {
+	const name = "invalid-coverage-report"
+	assert.Error(t, Register(*AToolchain(WithName(name),
+		WithCoverageReport(&CoverageReport{Format: "unknown", Path: "coverage.out"}))))
+	assert.False(t, isSupported(name))
+}
+
+func Test_cannot_register_a_toolchain_with_invalid_test_selection(t *testing.T) {
+	const name = "invalid-test-selection"
+	assert.Error(t, Register(*AToolchain(WithName(name),
+		WithTestSelection(NewTestSelection("unknown", "", 0, []Command{*ACommand()})))))
+	assert.False(t, isSupported(name))
+}
+
+func Test_cannot_register_a_toolchain_with_invalid_step(t *testing.T) {
+	const name = "invalid-step"
+	assert.Error(t, Register(*AToolchain(WithName(name), WithBuildStep(NewStep("", true, nil)))))
+	assert.False(t, isSupported(name))
+}
+
 func Test_get_registered_toolchain_with_empty_name(t *testing.T) {
 	tchn, err := Get("")
 	assert.Zero(t, tchn)
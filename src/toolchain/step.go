@@ -0,0 +1,178 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package toolchain
+
+import (
+	"errors"
+	"fmt"
+	"github.com/murex/tcr/report"
+	"time"
+)
+
+type (
+	// Step is an additional step of a toolchain phase (build or test), such as a lint or format check
+	// before the build, or contract tests after the tests.
+	// - Name is the name of the step, it must be unique within a phase.
+	// - FailsCycle indicates if a failure of this step fails the TCR cycle. When false, a failure is only reported.
+	// - commands is a table of commands that can be called when running the step. The first one
+	// matching the current OS and configuration will be the one to be called.
+	Step struct {
+		Name       string
+		FailsCycle bool
+		commands   []Command
+	}
+
+	// StepResult contains the result from running a step
+	StepResult struct {
+		Name       string
+		Status     CommandStatus
+		Duration   time.Duration
+		FailsCycle bool
+	}
+)
+
+// Names of the steps corresponding to the main build and test commands
+const (
+	BuildStepName = "build"
+	TestStepName  = "test"
+)
+
+// NewStep creates a new Step instance
+func NewStep(name string, failsCycle bool, commands []Command) *Step {
+	return &Step{
+		Name:       name,
+		FailsCycle: failsCycle,
+		commands:   commands,
+	}
+}
+
+// GetCommands returns the commands that can be called when running the step
+func (s Step) GetCommands() []Command {
+	return s.commands
+}
+
+// CommandPath returns the path of the step command compatible with the local platform.
+// Returns an empty string if there is none
+func (s Step) CommandPath() string {
+	if command := findCompatibleCommand(s.commands); command != nil {
+		return command.Path
+	}
+	return ""
+}
+
+// CommandLine returns the step command line compatible with the local platform as a string.
+// Returns an empty string if there is none
+func (s Step) CommandLine() string {
+	if command := findCompatibleCommand(s.commands); command != nil {
+		return command.asCommandLine()
+	}
+	return ""
+}
+
+func (s Step) check() error {
+	if s.Name == "" {
+		return errors.New("step name is empty")
+	}
+	if s.commands == nil {
+		return fmt.Errorf("step %s has no command", s.Name)
+	}
+	for _, command := range s.commands {
+		if err := command.check(); err != nil {
+			return fmt.Errorf("step %s: %w", s.Name, err)
+		}
+	}
+	return nil
+}
+
+// Failed indicates if a step failed, either due to a failure or to an infrastructure error
+func (r StepResult) Failed() bool {
+	return r.Status == CommandStatusFail || r.Status == CommandStatusError
+}
+
+func checkSteps(phase string, steps []Step) error {
+	names := map[string]bool{phase: true}
+	for _, step := range steps {
+		if err := step.check(); err != nil {
+			return err
+		}
+		if names[step.Name] {
+			return fmt.Errorf("duplicate %s step name: %s", phase, step.Name)
+		}
+		names[step.Name] = true
+	}
+	return nil
+}
+
+// GetBuildSteps returns the toolchain's additional build steps. They run before the build command
+func (tchn Toolchain) GetBuildSteps() []Step {
+	return tchn.buildSteps
+}
+
+// GetTestSteps returns the toolchain's additional test steps. They run after the test command
+func (tchn Toolchain) GetTestSteps() []Step {
+	return tchn.testSteps
+}
+
+func (tchn Toolchain) checkSteps() error {
+	if err := checkSteps(BuildStepName, tchn.buildSteps); err != nil {
+		return err
+	}
+	return checkSteps(TestStepName, tchn.testSteps)
+}
+
+// runSteps runs the provided steps in order. It stops on the first failing step that fails the cycle.
+// Failures of other steps are only reported. Returns the status of the step that failed the cycle
+// if any (fail or error), or CommandStatusPass otherwise
+func (tchn Toolchain) runSteps(steps []Step) (results []StepResult, status CommandStatus) {
+	for _, step := range steps {
+		result := tchn.runStep(step)
+		results = append(results, result)
+		if result.Failed() {
+			if step.FailsCycle {
+				return results, result.Status
+			}
+			report.PostWarning("Step ", step.Name, " failed (not failing the cycle)")
+		}
+	}
+	return results, CommandStatusPass
+}
+
+func (tchn Toolchain) runStep(step Step) StepResult {
+	report.PostInfo("Running step ", step.Name)
+	start := time.Now()
+	// A step that cannot be run is a configuration issue rather than a failure
+	result := StepResult{Name: step.Name, Status: CommandStatusError, FailsCycle: step.FailsCycle}
+	command := findCompatibleCommand(step.commands)
+	if command == nil {
+		report.PostWarning("no ", step.Name, " step command found for local platform")
+		return result
+	}
+	expanded, err := tchn.expandCommand(*command, nil)
+	if err != nil {
+		report.PostWarning(templateErrorResult(err).Output)
+		return result
+	}
+	result.Status = expanded.run().Status
+	result.Duration = time.Since(start)
+	return result
+}
@@ -0,0 +1,256 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package toolchain
+
+import (
+	"github.com/murex/tcr/quarantine"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+)
+
+func passingStep(name string, failsCycle bool) *Step {
+	return NewStep(name, failsCycle, []Command{*ACommand(WithPath("go"), WithArgs([]string{"version"}))})
+}
+
+func failingStep(name string, failsCycle bool) *Step {
+	return NewStep(name, failsCycle, []Command{*ACommand(WithPath("go"), WithArgs([]string{"unknown-command"}))})
+}
+
+func erroringStep(name string, failsCycle bool) *Step {
+	return NewStep(name, failsCycle, []Command{*ACommand(WithPath("unknown-tcr-step-command"))})
+}
+
+func Test_step_result_failed(t *testing.T) {
+	tests := []struct {
+		status   CommandStatus
+		expected bool
+	}{
+		{CommandStatusPass, false},
+		{CommandStatusFail, true},
+		{CommandStatusError, true},
+	}
+	for _, test := range tests {
+		t.Run(string(test.status), func(t *testing.T) {
+			assert.Equal(t, test.expected, StepResult{Status: test.status}.Failed())
+		})
+	}
+}
+
+func Test_check_steps(t *testing.T) {
+	tests := []struct {
+		desc      string
+		tchn      *Toolchain
+		expectErr bool
+	}{
+		{"no step", AToolchain(), false},
+		{"valid steps", AToolchain(
+			WithBuildStep(passingStep("lint", true)), WithTestStep(passingStep("contract", false))), false},
+		{"step with no name", AToolchain(WithBuildStep(passingStep("", true))), true},
+		{"step with no command", AToolchain(WithTestStep(NewStep("contract", true, nil))), true},
+		{"step with invalid command", AToolchain(
+			WithBuildStep(NewStep("lint", true, []Command{*ACommand(WithPath(""))}))), true},
+		{"duplicate step names", AToolchain(
+			WithBuildStep(passingStep("lint", true)), WithBuildStep(passingStep("lint", false))), true},
+		{"step named after its phase", AToolchain(WithTestStep(passingStep(TestStepName, true))), true},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			err := test.tchn.checkSteps()
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_step_command_line(t *testing.T) {
+	step := passingStep("lint", true)
+	assert.Equal(t, "go", step.CommandPath())
+	assert.Equal(t, "go version", step.CommandLine())
+
+	noCommand := NewStep("lint", true, []Command{})
+	assert.Equal(t, "", noCommand.CommandPath())
+	assert.Equal(t, "", noCommand.CommandLine())
+}
+
+func Test_run_steps(t *testing.T) {
+	dir, _ := filepath.Abs(".")
+	_ = SetWorkDir(dir)
+	tests := []struct {
+		desc           string
+		steps          []Step
+		expectedNames  []string
+		expectedStatus []CommandStatus
+		expectedCycle  CommandStatus
+	}{
+		{"no step", nil, nil, nil, CommandStatusPass},
+		{
+			"all steps passing",
+			[]Step{*passingStep("s1", true), *passingStep("s2", true)},
+			[]string{"s1", "s2"},
+			[]CommandStatus{CommandStatusPass, CommandStatusPass},
+			CommandStatusPass,
+		},
+		{
+			"failing step not failing the cycle",
+			[]Step{*failingStep("s1", false), *passingStep("s2", true)},
+			[]string{"s1", "s2"},
+			[]CommandStatus{CommandStatusFail, CommandStatusPass},
+			CommandStatusPass,
+		},
+		{
+			"failing step failing the cycle",
+			[]Step{*failingStep("s1", true), *passingStep("s2", true)},
+			[]string{"s1"},
+			[]CommandStatus{CommandStatusFail},
+			CommandStatusFail,
+		},
+		{
+			"step that cannot be launched failing the cycle",
+			[]Step{*erroringStep("s1", true), *passingStep("s2", true)},
+			[]string{"s1"},
+			[]CommandStatus{CommandStatusError},
+			CommandStatusError,
+		},
+		{
+			"step with no compatible command",
+			[]Step{*NewStep("s1", true, []Command{})},
+			[]string{"s1"},
+			[]CommandStatus{CommandStatusError},
+			CommandStatusError,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			results, cycleStatus := AToolchain().runSteps(test.steps)
+			assert.Equal(t, test.expectedCycle, cycleStatus)
+			var names []string
+			var statuses []CommandStatus
+			for _, result := range results {
+				names = append(names, result.Name)
+				statuses = append(statuses, result.Status)
+			}
+			assert.Equal(t, test.expectedNames, names)
+			assert.Equal(t, test.expectedStatus, statuses)
+		})
+	}
+}
+
+func Test_run_build_with_steps(t *testing.T) {
+	dir, _ := filepath.Abs(".")
+	_ = SetWorkDir(dir)
+	buildCmd := ACommand(WithPath("go"), WithArgs([]string{"version"}))
+
+	t.Run("build runs after passing steps", func(t *testing.T) {
+		tchn := AToolchain(WithNoBuildCommand(), WithBuildCommand(buildCmd),
+			WithBuildStep(passingStep("lint", true)))
+		result := tchn.RunBuild()
+		assert.True(t, result.Passed())
+		assert.Equal(t, 2, len(result.Steps))
+		assert.Equal(t, BuildStepName, result.Steps[1].Name)
+	})
+
+	t.Run("build does not run after a step failing the cycle", func(t *testing.T) {
+		tchn := AToolchain(WithNoBuildCommand(), WithBuildCommand(buildCmd),
+			WithBuildStep(failingStep("lint", true)))
+		result := tchn.RunBuild()
+		assert.Equal(t, CommandStatusFail, result.Status)
+		assert.Equal(t, 1, len(result.Steps))
+	})
+
+	t.Run("a step that cannot be launched is an infrastructure error", func(t *testing.T) {
+		tchn := AToolchain(WithNoBuildCommand(), WithBuildCommand(buildCmd),
+			WithBuildStep(erroringStep("lint", true)))
+		assert.Equal(t, CommandStatusError, tchn.RunBuild().Status)
+	})
+
+	t.Run("no step result when there is no step", func(t *testing.T) {
+		tchn := AToolchain(WithNoBuildCommand(), WithBuildCommand(buildCmd))
+		assert.Nil(t, tchn.RunBuild().Steps)
+	})
+}
+
+func Test_run_tests_with_steps(t *testing.T) {
+	dir, _ := filepath.Abs(".")
+	_ = SetWorkDir(dir)
+	testCmd := ACommand(WithPath("go"), WithArgs([]string{"version"}))
+
+	t.Run("steps run after passing tests", func(t *testing.T) {
+		tchn := AToolchain(WithNoTestCommand(), WithTestCommand(testCmd),
+			WithTestStep(passingStep("contract", true)))
+		result := tchn.RunTests()
+		assert.True(t, result.Passed())
+		assert.Equal(t, 2, len(result.Steps))
+		assert.Equal(t, TestStepName, result.Steps[0].Name)
+	})
+
+	t.Run("a failing step fails the tests", func(t *testing.T) {
+		tchn := AToolchain(WithNoTestCommand(), WithTestCommand(testCmd),
+			WithTestStep(failingStep("contract", true)))
+		assert.Equal(t, CommandStatusFail, tchn.RunTests().Status)
+	})
+
+	t.Run("a step that cannot be launched is an infrastructure error", func(t *testing.T) {
+		tchn := AToolchain(WithNoTestCommand(), WithTestCommand(testCmd),
+			WithTestStep(erroringStep("contract", true)))
+		assert.Equal(t, CommandStatusError, tchn.RunTests().Status)
+	})
+
+	t.Run("a failing step not failing the cycle does not fail the tests", func(t *testing.T) {
+		tchn := AToolchain(WithNoTestCommand(), WithTestCommand(testCmd),
+			WithTestStep(failingStep("contract", false)))
+		assert.True(t, tchn.RunTests().Passed())
+	})
+}
+
+func Test_run_tests_with_steps_and_quarantined_failures(t *testing.T) {
+	program, _ := filepath.Abs(filepath.Join(testDataRootDir, "xunit", "failing_tests.go"))
+	previousWorkDir := GetWorkDir()
+	_ = SetWorkDir(t.TempDir())
+	t.Cleanup(func() { _ = SetWorkDir(previousWorkDir) })
+	quarantine.Add("quarantined-1")
+	t.Cleanup(func() { _ = quarantine.Remove("quarantined-1") })
+	testCmdFailingOn := func(testNames ...string) *Command {
+		return ACommand(WithPath("go"), WithArgs(append([]string{"run", program, "results"}, testNames...)))
+	}
+
+	t.Run("steps run when only quarantined tests fail", func(t *testing.T) {
+		tchn := AToolchain(WithNoTestCommand(), WithTestCommand(testCmdFailingOn("quarantined-1")),
+			WithTestResultDir("results"), WithTestStep(passingStep("contract", true)))
+		result := tchn.RunTests()
+		assert.True(t, result.Passed())
+		assert.Equal(t, 2, len(result.Steps))
+		assert.Equal(t, CommandStatusPass, result.Steps[0].Status)
+	})
+
+	t.Run("steps do not run when other tests fail", func(t *testing.T) {
+		tchn := AToolchain(WithNoTestCommand(), WithTestCommand(testCmdFailingOn("quarantined-1", "test-a")),
+			WithTestResultDir("results"), WithTestStep(passingStep("contract", true)))
+		result := tchn.RunTests()
+		assert.Equal(t, CommandStatusFail, result.Status)
+		assert.Equal(t, 1, len(result.Steps))
+	})
+}
@@ -0,0 +1,229 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package toolchain
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+type (
+	// fileList is a list of files (or test targets) available for command templating.
+	// It is rendered as a space-separated list when embedded in a larger argument
+	fileList []string
+
+	// commandData contains the data available for toolchain command path and arguments templating.
+	// - BaseDir is the base directory watched by TCR.
+	// - WorkDir is the directory from which toolchain commands are launched.
+	// - TestResultDir is the absolute path to the toolchain's test result directory.
+	// - ChangedSrcFiles and ChangedTestFiles are the source and test files changed since last commit.
+	// - TestTargets is the list of selected test targets (only set when running selected tests).
+	// - Env contains the environment variables.
+	commandData struct {
+		BaseDir          string
+		WorkDir          string
+		TestResultDir    string
+		ChangedSrcFiles  fileList
+		ChangedTestFiles fileList
+		TestTargets      fileList
+		Env              map[string]string
+	}
+
+	// changedFilesState keeps track of the files changed since last commit
+	changedFilesState struct {
+		mutex sync.Mutex
+		src   []string
+		test  []string
+	}
+)
+
+// List of placeholders that expand to multiple arguments when an argument is only made of them
+const (
+	// ChangedSrcFilesPlaceholder is the placeholder for source files changed since last commit
+	ChangedSrcFilesPlaceholder = "{{.ChangedSrcFiles}}"
+	// ChangedTestFilesPlaceholder is the placeholder for test files changed since last commit
+	ChangedTestFilesPlaceholder = "{{.ChangedTestFiles}}"
+)
+
+var baseDir string
+
+var changedFiles changedFilesState
+
+// SetBaseDir sets the base directory made available to toolchain command templates
+func SetBaseDir(dir string) (err error) {
+	baseDir, err = dirAbsPath(dir)
+	return err
+}
+
+// GetBaseDir returns the base directory made available to toolchain command templates
+func GetBaseDir() string {
+	return baseDir
+}
+
+// SetChangedFiles sets the source and test files changed since last commit,
+// made available to toolchain command templates
+func SetChangedFiles(srcFiles []string, testFiles []string) {
+	changedFiles.mutex.Lock()
+	defer changedFiles.mutex.Unlock()
+	changedFiles.src = srcFiles
+	changedFiles.test = testFiles
+}
+
+// String returns the files as a space-separated list
+func (f fileList) String() string {
+	return strings.Join(f, " ")
+}
+
+func (tchn Toolchain) commandData(targets []string) commandData {
+	changedFiles.mutex.Lock()
+	defer changedFiles.mutex.Unlock()
+	return commandData{
+		BaseDir:          baseDir,
+		WorkDir:          workDir,
+		TestResultDir:    tchn.GetTestResultPath(),
+		ChangedSrcFiles:  changedFiles.src,
+		ChangedTestFiles: changedFiles.test,
+		TestTargets:      targets,
+		Env:              environment(),
+	}
+}
+
+func environment() map[string]string {
+	env := make(map[string]string)
+	for _, entry := range os.Environ() {
+		if key, value, found := strings.Cut(entry, "="); found {
+			env[key] = value
+		}
+	}
+	return env
+}
+
+// expandCommand returns a copy of the provided command with its path, arguments,
+// work directory and environment variables templates expanded
+func (tchn Toolchain) expandCommand(command Command, targets []string) (Command, error) {
+	data := tchn.commandData(targets)
+	path, err := expandTemplate(command.Path, data)
+	if err != nil {
+		return command, err
+	}
+	args, err := expandArguments(command.Arguments, data)
+	if err != nil {
+		return command, err
+	}
+	dir, err := expandTemplate(command.WorkDir, data)
+	if err != nil {
+		return command, err
+	}
+	var env map[string]string
+	for key, value := range command.Env {
+		if env == nil {
+			env = make(map[string]string)
+		}
+		if env[key], err = expandTemplate(value, data); err != nil {
+			return command, err
+		}
+	}
+	command.Path = path
+	command.Arguments = args
+	command.WorkDir = dir
+	command.Env = env
+	return command, nil
+}
+
+// CheckCommandTemplates verifies that the path and arguments templates of all
+// toolchain commands can be expanded. Returns the first error found
+func (tchn Toolchain) CheckCommandTemplates() error {
+	all := [][]Command{
+		tchn.buildCommands,
+		tchn.testCommands,
+		tchn.slowTestCommands,
+		tchn.testSelectionCommands(),
+	}
+	for _, steps := range [][]Step{tchn.buildSteps, tchn.testSteps} {
+		for _, step := range steps {
+			all = append(all, step.commands)
+		}
+	}
+	for _, commands := range all {
+		for _, command := range commands {
+			if _, err := tchn.expandCommand(command, nil); err != nil {
+				return fmt.Errorf("%s: %w", command.asCommandLine(), err)
+			}
+		}
+	}
+	return nil
+}
+
+func (tchn Toolchain) testSelectionCommands() []Command {
+	if tchn.testSelection == nil {
+		return nil
+	}
+	return tchn.testSelection.testCommands
+}
+
+// expandArguments applies templating on the provided command arguments. An argument
+// only made of a file list placeholder is replaced with one argument per file
+func expandArguments(args []string, data commandData) (expanded []string, err error) {
+	lists := map[string]fileList{
+		TestTargetsPlaceholder:      data.TestTargets,
+		ChangedSrcFilesPlaceholder:  data.ChangedSrcFiles,
+		ChangedTestFilesPlaceholder: data.ChangedTestFiles,
+	}
+	for _, arg := range args {
+		if files, isList := lists[strings.TrimSpace(arg)]; isList {
+			expanded = append(expanded, files...)
+			continue
+		}
+		value, err := expandTemplate(arg, data)
+		if err != nil {
+			return nil, err
+		}
+		expanded = append(expanded, value)
+	}
+	return expanded, nil
+}
+
+func expandTemplate(text string, data commandData) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+	tmpl, err := template.New("command").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var b bytes.Buffer
+	if err = tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// templateErrorResult reports an invalid command template as an infrastructure error,
+// so that it does not trigger a revert of the user's changes
+func templateErrorResult(err error) CommandResult {
+	return CommandResult{Status: CommandStatusError, Output: "invalid command template: " + err.Error()}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package toolchain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+)
+
+func Test_expand_command_arguments(t *testing.T) {
+	data := commandData{
+		BaseDir:          "/base",
+		WorkDir:          "/work",
+		ChangedSrcFiles:  fileList{"src1", "src2"},
+		ChangedTestFiles: fileList{"test1"},
+		TestTargets:      fileList{"t1", "t2"},
+		Env:              map[string]string{"SOME_VAR": "some-value"},
+	}
+	tests := []struct {
+		desc      string
+		args      []string
+		expected  []string
+		expectErr bool
+	}{
+		{"no argument", nil, nil, false},
+		{"no placeholder", []string{"arg1", "arg2"}, []string{"arg1", "arg2"}, false},
+		{"standalone test targets placeholder", []string{"arg1", TestTargetsPlaceholder},
+			[]string{"arg1", "t1", "t2"}, false},
+		{"embedded test targets placeholder", []string{"--tests=" + TestTargetsPlaceholder},
+			[]string{"--tests=t1 t2"}, false},
+		{"standalone changed source files placeholder", []string{ChangedSrcFilesPlaceholder},
+			[]string{"src1", "src2"}, false},
+		{"standalone changed test files placeholder", []string{ChangedTestFilesPlaceholder},
+			[]string{"test1"}, false},
+		{"directories", []string{"{{.BaseDir}}/src", "--dir={{.WorkDir}}"},
+			[]string{"/base/src", "--dir=/work"}, false},
+		{"environment variable", []string{"{{.Env.SOME_VAR}}"}, []string{"some-value"}, false},
+		{"unknown environment variable", []string{"{{.Env.UNKNOWN_VAR}}"}, nil, true},
+		{"unknown placeholder", []string{"{{.Unknown}}"}, nil, true},
+		{"invalid template", []string{"{{.TestTargets"}, nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			expanded, err := expandArguments(test.args, data)
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, expanded)
+			}
+		})
+	}
+}
+
+func Test_expand_command_path_and_arguments(t *testing.T) {
+	t.Setenv("TCR_TEMPLATE_TEST_VAR", "some-value")
+	dir, _ := filepath.Abs(".")
+	_ = SetWorkDir(dir)
+	_ = SetBaseDir(dir)
+	SetChangedFiles([]string{"src1"}, []string{"test1"})
+	defer SetChangedFiles(nil, nil)
+
+	cmd := ACommand(
+		WithPath("{{.WorkDir}}/gradlew"),
+		WithArgs([]string{"{{.Env.TCR_TEMPLATE_TEST_VAR}}", "--results={{.TestResultDir}}",
+			ChangedSrcFilesPlaceholder, ChangedTestFilesPlaceholder}))
+	tchn := AToolchain(WithTestResultDir("build"))
+	expanded, err := tchn.expandCommand(*cmd, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, dir+"/gradlew", expanded.Path)
+	assert.Equal(t, []string{"some-value", "--results=" + filepath.Join(dir, "build"), "src1", "test1"},
+		expanded.Arguments)
+}
+
+func Test_expand_command_work_dir_and_env(t *testing.T) {
+	dir, _ := filepath.Abs(".")
+	_ = SetBaseDir(dir)
+	cmd := ACommand(WithWorkDir("{{.BaseDir}}/sub"), WithEnv("SOME_VAR", "{{.BaseDir}}"))
+	expanded, err := AToolchain().expandCommand(*cmd, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, dir+"/sub", expanded.WorkDir)
+	assert.Equal(t, map[string]string{"SOME_VAR": dir}, expanded.Env)
+	assert.Equal(t, "{{.BaseDir}}", cmd.Env["SOME_VAR"])
+}
+
+func Test_check_command_templates(t *testing.T) {
+	tests := []struct {
+		desc      string
+		tchn      *Toolchain
+		expectErr bool
+	}{
+		{"no template", AToolchain(), false},
+		{"valid template", AToolchain(WithBuildCommand(
+			ACommand(WithArgs([]string{"{{.BaseDir}}"})))), false},
+		{"invalid build template", AToolchain(WithBuildCommand(
+			ACommand(WithArgs([]string{"{{.Unknown}}"})))), true},
+		{"invalid slow test template", AToolchain(WithSlowTestCommand(
+			ACommand(WithPath("{{.Unknown}}")))), true},
+		{"invalid test selection template", AToolchain(WithTestSelection(NewTestSelection(
+			NamingMapping, "", 0, []Command{*ACommand(WithArgs([]string{"{{.Unknown"}))}))), true},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			err := test.tchn.CheckCommandTemplates()
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_invalid_command_template_is_an_infrastructure_error(t *testing.T) {
+	tchn := AToolchain(WithNoBuildCommand(), WithBuildCommand(ACommand(WithArgs([]string{"{{.Unknown}}"}))))
+	result := tchn.RunBuild()
+	assert.Equal(t, CommandStatusError, result.Status)
+	assert.Contains(t, result.Output, "invalid command template")
+}
@@ -0,0 +1,143 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package toolchain
+
+import (
+	"errors"
+	"fmt"
+)
+
+type (
+	// TestMapping is the strategy used for mapping changed files to test targets
+	TestMapping string
+
+	// TestSelection describes how a toolchain can run only the tests affected by changes.
+	// - Mapping is the strategy used for mapping changed files to test targets.
+	// - Script is the path to the mapping script, used only with ScriptMapping.
+	// - FullRunEvery is the period (in number of TCR cycles) for running all tests. 0 means never.
+	// - testCommands is a table of commands that can be called when running selected tests. Their arguments
+	// can refer to selected test targets through TestTargetsPlaceholder.
+	TestSelection struct {
+		Mapping      TestMapping
+		Script       string
+		FullRunEvery int
+		testCommands []Command
+	}
+)
+
+// List of supported test mapping strategies
+const (
+	// NamingMapping maps changed source files to test files following naming conventions
+	NamingMapping TestMapping = "naming"
+	// PackageMapping maps changed files to the directory (package) containing them
+	PackageMapping TestMapping = "package"
+	// ScriptMapping delegates mapping of changed files to a user-supplied script
+	ScriptMapping TestMapping = "script"
+)
+
+// TestTargetsPlaceholder is the placeholder for selected test targets in test selection command arguments.
+// When an argument is only made of this placeholder, it is replaced with one argument per test target
+const TestTargetsPlaceholder = "{{.TestTargets}}"
+
+// GetAllTestMappings returns the list of all supported test mapping strategies
+func GetAllTestMappings() []TestMapping {
+	return []TestMapping{NamingMapping, PackageMapping, ScriptMapping}
+}
+
+// NewTestSelection creates a new TestSelection instance
+func NewTestSelection(mapping TestMapping, script string, fullRunEvery int, testCommands []Command) *TestSelection {
+	return &TestSelection{
+		Mapping:      mapping,
+		Script:       script,
+		FullRunEvery: fullRunEvery,
+		testCommands: testCommands,
+	}
+}
+
+// GetTestCommands returns the commands used for running selected tests
+func (s TestSelection) GetTestCommands() []Command {
+	return s.testCommands
+}
+
+func (s TestSelection) check() error {
+	if err := checkTestMapping(s.Mapping); err != nil {
+		return err
+	}
+	if s.Mapping == ScriptMapping && s.Script == "" {
+		return errors.New("test selection script is not set")
+	}
+	if s.FullRunEvery < 0 {
+		return errors.New("test selection full run period cannot be negative")
+	}
+	if s.testCommands == nil {
+		return errors.New("test selection has no test command")
+	}
+	return nil
+}
+
+func checkTestMapping(mapping TestMapping) error {
+	for _, m := range GetAllTestMappings() {
+		if m == mapping {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported test mapping: %q", mapping)
+}
+
+// GetTestSelection returns the toolchain's test selection settings,
+// or nil if the toolchain always runs all tests
+func (tchn Toolchain) GetTestSelection() *TestSelection {
+	return tchn.testSelection
+}
+
+func (tchn Toolchain) checkTestSelection() error {
+	if tchn.testSelection == nil {
+		return nil
+	}
+	return tchn.testSelection.check()
+}
+
+// RunSelectedTests runs the provided test targets with this toolchain
+func (tchn Toolchain) RunSelectedTests(targets []string) TestCommandResult {
+	command := findCompatibleCommand(tchn.testSelection.testCommands)
+	if command == nil {
+		return TestCommandResult{
+			CommandResult: CommandResult{
+				Status: CommandStatusError,
+				Output: "no test selection command found for local platform",
+			},
+		}
+	}
+	return tchn.runTestCommand(*command, targets)
+}
+
+// SelectedTestCommandLine returns the toolchain's test selection command line as a string,
+// with test targets left as a placeholder
+func (tchn Toolchain) SelectedTestCommandLine() string {
+	return compatibleCommandLine(tchn.testSelection.testCommands)
+}
+
+// SelectedTestCommandPath returns the toolchain's test selection command path
+func (tchn Toolchain) SelectedTestCommandPath() string {
+	return compatibleCommandPath(tchn.testSelection.testCommands)
+}
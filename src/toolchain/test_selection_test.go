@@ -0,0 +1,68 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package toolchain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_check_test_selection(t *testing.T) {
+	testCommands := []Command{*ACommand()}
+	tests := []struct {
+		desc      string
+		selection *TestSelection
+		expectErr bool
+	}{
+		{"no test selection", nil, false},
+		{"naming mapping", NewTestSelection(NamingMapping, "", 0, testCommands), false},
+		{"package mapping", NewTestSelection(PackageMapping, "", 10, testCommands), false},
+		{"script mapping", NewTestSelection(ScriptMapping, "./select.sh", 0, testCommands), false},
+		{"script mapping with no script", NewTestSelection(ScriptMapping, "", 0, testCommands), true},
+		{"unknown mapping", NewTestSelection("unknown", "", 0, testCommands), true},
+		{"negative full run period", NewTestSelection(NamingMapping, "", -1, testCommands), true},
+		{"no test command", NewTestSelection(NamingMapping, "", 0, nil), true},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			err := AToolchain(WithTestSelection(test.selection)).checkTestSelection()
+			if test.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_selected_test_command_line(t *testing.T) {
+	cmd := ACommand(WithPath("test-cmd"), WithArgs([]string{"-run", TestTargetsPlaceholder}))
+	tchn := AToolchain(WithTestSelection(NewTestSelection(NamingMapping, "", 0, []Command{*cmd})))
+	assert.Equal(t, "test-cmd", tchn.SelectedTestCommandPath())
+	assert.Equal(t, "test-cmd -run {{.TestTargets}}", tchn.SelectedTestCommandLine())
+}
+
+func Test_run_selected_tests_with_no_compatible_command(t *testing.T) {
+	tchn := AToolchain(WithTestSelection(NewTestSelection(NamingMapping, "", 0, []Command{})))
+	assert.True(t, tchn.RunSelectedTests([]string{"target"}).InfraError())
+}
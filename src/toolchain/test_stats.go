@@ -25,14 +25,17 @@ package toolchain
 import "time"
 
 type (
-	// TestStats is the structure containing information of the test run
+	// TestStats is the structure containing information of the test run.
+	// FailedTests and PassedTests contain the identifiers of failed and passed test cases
 	TestStats struct {
-		TotalRun   int
-		Passed     int
-		Failed     int
-		Skipped    int
-		WithErrors int
-		Duration   time.Duration
+		TotalRun    int
+		Passed      int
+		Failed      int
+		Skipped     int
+		WithErrors  int
+		Duration    time.Duration
+		FailedTests []string
+		PassedTests []string
 	}
 )
 
@@ -24,10 +24,17 @@ package toolchain
 
 import (
 	"errors"
+	"fmt"
+	"github.com/murex/tcr/coverage"
+	"github.com/murex/tcr/quarantine"
 	"github.com/murex/tcr/report"
+	"github.com/murex/tcr/utils"
 	"github.com/murex/tcr/xunit"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 )
 
 type (
@@ -37,17 +44,33 @@ type (
 	// matching the current OS and configuration will be the one to be called.
 	// - testCommands is a table of commands that can be called when running the tests. The first one
 	// matching the current OS and configuration will be the one to be called.
+	// - slowTestCommands is optional. When set, these commands run the slow part of the test suite,
+	// which is run after committing changes rather than for deciding between commit and revert.
+	// - coverageReport is optional. When set, code coverage is retrieved after running the tests.
+	// - testSelection is optional. When set, only the tests affected by changes are run.
+	// - buildSteps is optional. It contains additional steps that run before the build command.
+	// - testSteps is optional. It contains additional steps that run after the test command.
+	// - detectInfraErrors indicates if a failing test run with no test report or with no test run
+	// should be considered as an infrastructure error rather than as a test failure.
 	Toolchain struct {
-		name          string
-		buildCommands []Command
-		testCommands  []Command
-		testResultDir string
+		name              string
+		buildCommands     []Command
+		testCommands      []Command
+		slowTestCommands  []Command
+		testResultDir     string
+		coverageReport    *CoverageReport
+		testSelection     *TestSelection
+		buildSteps        []Step
+		testSteps         []Step
+		detectInfraErrors bool
 	}
 
-	// TestCommandResult is a CommandResult enriched with test Stats
+	// TestCommandResult is a CommandResult enriched with test Stats and code coverage data.
+	// Coverage is nil when the toolchain does not collect code coverage
 	TestCommandResult struct {
 		CommandResult
-		Stats TestStats
+		Stats    TestStats
+		Coverage *coverage.Report
 	}
 
 	// TchnInterface provides the interface for interacting with a toolchain
@@ -55,10 +78,21 @@ type (
 		GetName() string
 		GetBuildCommands() []Command
 		GetTestCommands() []Command
+		GetSlowTestCommands() []Command
+		GetBuildSteps() []Step
+		GetTestSteps() []Step
+		DetectsInfraErrors() bool
 		GetTestResultDir() string
 		GetTestResultPath() string
+		GetCoverageReport() *CoverageReport
+		GetCoverageReportPath() string
 		RunBuild() CommandResult
 		RunTests() TestCommandResult
+		RunSlowTests() CommandResult
+		GetTestSelection() *TestSelection
+		RunSelectedTests(targets []string) TestCommandResult
+		SelectedTestCommandPath() string
+		SelectedTestCommandLine() string
 		checkName() error
 		BuildCommandLine() string
 		BuildCommandPath() string
@@ -68,6 +102,12 @@ type (
 		TestCommandPath() string
 		TestCommandArgs() []string
 		checkTestCommand() error
+		SlowTestCommandPath() string
+		SlowTestCommandLine() string
+		checkCoverageReport() error
+		checkTestSelection() error
+		checkSteps() error
+		CheckCommandTemplates() error
 		runsOnPlatform(osName OsName, archName ArchName) bool
 		CheckCommandAccess(cmdPath string) (string, error)
 	}
@@ -123,16 +163,31 @@ func (tchn Toolchain) checkBuildCommand() error {
 	if tchn.buildCommands == nil {
 		return errors.New("toolchain has no build command")
 	}
-	return nil
+	return checkExitCodes(tchn.buildCommands)
 }
 
 func (tchn Toolchain) checkTestCommand() error {
 	if tchn.testCommands == nil {
 		return errors.New("toolchain has no test command")
 	}
+	return checkExitCodes(tchn.testCommands)
+}
+
+func checkExitCodes(commands []Command) error {
+	for _, command := range commands {
+		if err := command.ExitCodes.check(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// DetectsInfraErrors indicates if the toolchain considers a failing test run with no test report
+// or with no test run as an infrastructure error
+func (tchn Toolchain) DetectsInfraErrors() bool {
+	return tchn.detectInfraErrors
+}
+
 // GetName provides the name of the toolchain
 func (tchn Toolchain) GetName() string {
 	return tchn.name
@@ -148,46 +203,158 @@ func (tchn Toolchain) GetTestCommands() []Command {
 	return tchn.testCommands
 }
 
+// GetSlowTestCommands returns the toolchain's slow test commands
+func (tchn Toolchain) GetSlowTestCommands() []Command {
+	return tchn.slowTestCommands
+}
+
 // RunBuild runs the build with this toolchain
 func (tchn Toolchain) RunBuild() CommandResult {
-	return findCompatibleCommand(tchn.buildCommands).run()
+	stepResults, stepStatus := tchn.runSteps(tchn.buildSteps)
+	if stepStatus != CommandStatusPass {
+		return CommandResult{Status: stepStatus, Output: "build step failed", Steps: stepResults}
+	}
+	start := time.Now()
+	result := tchn.runBuildCommand()
+	if len(tchn.buildSteps) > 0 {
+		result.Steps = append(stepResults, StepResult{
+			Name: BuildStepName, Status: result.Status, Duration: time.Since(start), FailsCycle: true,
+		})
+	}
+	return result
+}
+
+func (tchn Toolchain) runBuildCommand() CommandResult {
+	compatible := findCompatibleCommand(tchn.buildCommands)
+	if compatible == nil {
+		return CommandResult{Status: CommandStatusError, Output: "no build command found for local platform"}
+	}
+	command, err := tchn.expandCommand(*compatible, nil)
+	if err != nil {
+		return templateErrorResult(err)
+	}
+	return command.run()
 }
 
 // RunTests runs the tests with this toolchain
 func (tchn Toolchain) RunTests() TestCommandResult {
-	result := findCompatibleCommand(tchn.testCommands).run()
-	testStats, _ := tchn.parseTestReport()
-	return TestCommandResult{result, testStats}
+	command := findCompatibleCommand(tchn.testCommands)
+	if command == nil {
+		return TestCommandResult{
+			CommandResult: CommandResult{Status: CommandStatusError, Output: "no test command found for local platform"},
+		}
+	}
+	return tchn.runTestCommand(*command, nil)
+}
+
+// runTestCommand runs the provided test command, followed by the toolchain's additional test steps
+// when the test command passes
+func (tchn Toolchain) runTestCommand(command Command, targets []string) TestCommandResult {
+	start := time.Now()
+	result := ignoreQuarantinedFailures(tchn.runTestCommandOnly(command, targets))
+	if len(tchn.testSteps) == 0 {
+		return result
+	}
+	result.Steps = []StepResult{{
+		Name: TestStepName, Status: result.Status, Duration: time.Since(start), FailsCycle: true,
+	}}
+	if result.Failed() {
+		return result
+	}
+	stepResults, stepStatus := tchn.runSteps(tchn.testSteps)
+	result.Steps = append(result.Steps, stepResults...)
+	if stepStatus != CommandStatusPass {
+		result.Status = stepStatus
+	}
+	return result
+}
+
+// ignoreQuarantinedFailures considers failing tests as passing when all failed tests
+// are in quarantine, so that test steps are run as they would be with passing tests
+func ignoreQuarantinedFailures(result TestCommandResult) TestCommandResult {
+	if result.Status != CommandStatusFail || len(result.Stats.FailedTests) == 0 {
+		return result
+	}
+	if _, remaining := quarantine.Split(result.Stats.FailedTests); len(remaining) == 0 {
+		result.Status = CommandStatusPass
+	}
+	return result
+}
+
+func (tchn Toolchain) runTestCommandOnly(command Command, targets []string) TestCommandResult {
+	command, err := tchn.expandCommand(command, targets)
+	if err != nil {
+		return TestCommandResult{CommandResult: templateErrorResult(err)}
+	}
+	// Test results left by a previous run must not be mistaken for the results of this one
+	if err = clearTestResults(tchn.GetTestResultPath()); err != nil {
+		return TestCommandResult{CommandResult: CommandResult{Status: CommandStatusError, Output: err.Error()}}
+	}
+	result := command.run()
+	testStats, reportErr := tchn.parseTestReport()
+	if tchn.detectInfraErrors && result.Status == CommandStatusFail && (reportErr != nil || testStats.TotalRun == 0) {
+		report.PostWarning("No test report or no test run: considering test failure as an infrastructure error")
+		result.Status = CommandStatusError
+	}
+	coverageReport, err := tchn.parseCoverageReport()
+	if err != nil {
+		report.PostWarning("failed to retrieve code coverage: ", err)
+	}
+	return TestCommandResult{CommandResult: result, Stats: testStats, Coverage: coverageReport}
+}
+
+// RunSlowTests runs the slow tests with this toolchain. Their command line and output
+// are not reported while running: the output is returned in the command result
+func (tchn Toolchain) RunSlowTests() CommandResult {
+	command := findCompatibleCommand(tchn.slowTestCommands)
+	if command == nil {
+		return CommandResult{Status: CommandStatusError, Output: "no slow test command found for local platform"}
+	}
+	expanded, err := tchn.expandCommand(*command, nil)
+	if err != nil {
+		return templateErrorResult(err)
+	}
+	return expanded.runQuietly()
 }
 
 // BuildCommandPath returns the build command path for this toolchain
 func (tchn Toolchain) BuildCommandPath() string {
-	return findCompatibleCommand(tchn.buildCommands).Path
+	return compatibleCommandPath(tchn.buildCommands)
 }
 
 // BuildCommandArgs returns a table with the list of build command arguments for this toolchain
 func (tchn Toolchain) BuildCommandArgs() []string {
-	return findCompatibleCommand(tchn.buildCommands).Arguments
+	return compatibleCommandArgs(tchn.buildCommands)
 }
 
 // BuildCommandLine returns the toolchain's build command line as a string
 func (tchn Toolchain) BuildCommandLine() string {
-	return findCompatibleCommand(tchn.buildCommands).asCommandLine()
+	return compatibleCommandLine(tchn.buildCommands)
 }
 
 // TestCommandPath returns the test command path for this toolchain
 func (tchn Toolchain) TestCommandPath() string {
-	return findCompatibleCommand(tchn.testCommands).Path
+	return compatibleCommandPath(tchn.testCommands)
 }
 
 // TestCommandArgs returns a table with the list of test command arguments for this toolchain
 func (tchn Toolchain) TestCommandArgs() []string {
-	return findCompatibleCommand(tchn.testCommands).Arguments
+	return compatibleCommandArgs(tchn.testCommands)
 }
 
 // TestCommandLine returns the toolchain's test command line as a string
 func (tchn Toolchain) TestCommandLine() string {
-	return findCompatibleCommand(tchn.testCommands).asCommandLine()
+	return compatibleCommandLine(tchn.testCommands)
+}
+
+// SlowTestCommandPath returns the slow test command path for this toolchain
+func (tchn Toolchain) SlowTestCommandPath() string {
+	return compatibleCommandPath(tchn.slowTestCommands)
+}
+
+// SlowTestCommandLine returns the toolchain's slow test command line as a string
+func (tchn Toolchain) SlowTestCommandLine() string {
+	return compatibleCommandLine(tchn.slowTestCommands)
 }
 
 func (tchn Toolchain) runsOnPlatform(osName OsName, archName ArchName) bool {
@@ -215,14 +382,40 @@ func (tchn Toolchain) parseTestReport() (TestStats, error) {
 		report.PostWarning(err)
 		return TestStats{}, err
 	}
-	return NewTestStats(
+	stats := NewTestStats(
 		parser.Stats.Run,
 		parser.Stats.Passed,
 		parser.Stats.Failed,
 		parser.Stats.Skipped,
 		parser.Stats.InError,
 		parser.Stats.Duration,
-	), nil
+	)
+	stats.FailedTests = parser.Stats.FailedTests
+	stats.PassedTests = parser.Stats.PassedTests
+	return stats, nil
+}
+
+// clearTestResults removes test result files left in the test result directory by a previous run,
+// so that they are not mistaken for the results of the next run. Nothing is removed when the test
+// result directory contains the work directory or the base directory, as other project files may
+// then look like test result files
+func clearTestResults(dir string) error {
+	if utils.IsSubPathOf(workDir, dir) || (baseDir != "" && utils.IsSubPathOf(baseDir, dir)) {
+		return nil
+	}
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.Type().IsRegular() && strings.HasSuffix(entry.Name(), ".xml") {
+			return os.Remove(path)
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("cannot clear test result directory %s: %w", dir, err)
+	}
+	return nil
 }
 
 // GetTestResultPath provides the absolute path to the test result directory
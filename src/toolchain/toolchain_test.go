@@ -104,6 +104,90 @@ func Test_test_command_line(t *testing.T) {
 	assert.Equal(t, "test-cmd arg1 arg2", tchn.TestCommandLine())
 }
 
+func Test_slow_test_command_line(t *testing.T) {
+	cmd := ACommand(WithPath("slow-test-cmd"), WithArgs([]string{"arg1", "arg2"}))
+	tchn := AToolchain(WithSlowTestCommand(cmd))
+	assert.Equal(t, "slow-test-cmd", tchn.SlowTestCommandPath())
+	assert.Equal(t, "slow-test-cmd arg1 arg2", tchn.SlowTestCommandLine())
+}
+
+func Test_run_slow_tests_with_no_compatible_command(t *testing.T) {
+	tchn := AToolchain()
+	assert.True(t, tchn.RunSlowTests().InfraError())
+}
+
+func Test_toolchain_with_no_compatible_command(t *testing.T) {
+	cmd := ACommand(WithNoOs(), WithPath("cmd"), WithArgs([]string{"arg"}))
+	tchn := AToolchain(WithNoBuildCommand(), WithBuildCommand(cmd),
+		WithNoTestCommand(), WithTestCommand(cmd), WithSlowTestCommand(cmd),
+		WithTestSelection(NewTestSelection(PackageMapping, "", 0, []Command{*cmd})))
+
+	t.Run("running build is an infrastructure error", func(t *testing.T) {
+		assert.True(t, tchn.RunBuild().InfraError())
+	})
+	t.Run("running tests is an infrastructure error", func(t *testing.T) {
+		assert.True(t, tchn.RunTests().InfraError())
+	})
+	t.Run("command details are empty", func(t *testing.T) {
+		assert.Zero(t, tchn.BuildCommandPath())
+		assert.Nil(t, tchn.BuildCommandArgs())
+		assert.Zero(t, tchn.BuildCommandLine())
+		assert.Zero(t, tchn.TestCommandPath())
+		assert.Nil(t, tchn.TestCommandArgs())
+		assert.Zero(t, tchn.TestCommandLine())
+		assert.Zero(t, tchn.SlowTestCommandPath())
+		assert.Zero(t, tchn.SlowTestCommandLine())
+		assert.Zero(t, tchn.SelectedTestCommandPath())
+		assert.Zero(t, tchn.SelectedTestCommandLine())
+	})
+}
+
+func Test_run_tests_with_infra_error_detection(t *testing.T) {
+	dir, _ := filepath.Abs(".")
+	_ = SetWorkDir(dir)
+	failingCmd := ACommand(WithPath("go"), WithArgs([]string{"unknown-command"}))
+	tests := []struct {
+		desc     string
+		tchn     *Toolchain
+		expected CommandStatus
+	}{
+		{"detection off", AToolchain(WithNoTestCommand(), WithTestCommand(failingCmd),
+			WithTestResultDir("no-such-dir")), CommandStatusFail},
+		{"detection on with no test report", AToolchain(WithNoTestCommand(), WithTestCommand(failingCmd),
+			WithTestResultDir("no-such-dir"), WithInfraErrorDetection()), CommandStatusError},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.tchn.RunTests().Status)
+		})
+	}
+}
+
+func Test_run_tests_ignores_test_results_from_previous_run(t *testing.T) {
+	previousWorkDir := GetWorkDir()
+	_ = SetWorkDir(t.TempDir())
+	t.Cleanup(func() { _ = SetWorkDir(previousWorkDir) })
+	resultDir := filepath.Join(GetWorkDir(), "results")
+	_ = os.MkdirAll(resultDir, 0750)
+	_ = os.WriteFile(filepath.Join(resultDir, "previous.xml"),
+		[]byte(`<testsuite name="previous" tests="1"><testcase name="test-a"/></testsuite>`), 0600)
+
+	failingCmd := ACommand(WithPath("go"), WithArgs([]string{"unknown-command"}))
+	tchn := AToolchain(WithNoTestCommand(), WithTestCommand(failingCmd),
+		WithTestResultDir("results"), WithInfraErrorDetection())
+	result := tchn.RunTests()
+	assert.Equal(t, CommandStatusError, result.Status)
+	assert.Zero(t, result.Stats.TotalRun)
+	assert.NoFileExists(t, filepath.Join(resultDir, "previous.xml"))
+}
+
+func Test_cannot_register_a_toolchain_with_invalid_exit_codes(t *testing.T) {
+	const name = "invalid-exit-codes"
+	assert.Error(t, Register(*AToolchain(WithName(name), WithNoTestCommand(),
+		WithTestCommand(ACommand(WithExitCodes(ExitCodeMapping{Pass: []int{1}, TestFailure: []int{1}}))))))
+	assert.False(t, isSupported(name))
+}
+
 func Test_check_command_access_for_valid_command(t *testing.T) {
 	tchn := AToolchain()
 	path, err := tchn.CheckCommandAccess("go")
@@ -63,7 +63,39 @@ func WithTestCommand(command *Command) func(tchn *Toolchain) {
 	}
 }
 
+// WithSlowTestCommand adds the provided command as a slow test command
+func WithSlowTestCommand(command *Command) func(tchn *Toolchain) {
+	return func(tchn *Toolchain) {
+		tchn.slowTestCommands = append(tchn.slowTestCommands, *command)
+	}
+}
+
 // WithTestResultDir sets the test result directory of the created toolchain to dir
 func WithTestResultDir(dir string) func(tchn *Toolchain) {
 	return func(tchn *Toolchain) { tchn.testResultDir = dir }
 }
+
+// WithCoverageReport sets the coverage report settings of the created toolchain
+func WithCoverageReport(coverageReport *CoverageReport) func(tchn *Toolchain) {
+	return func(tchn *Toolchain) { tchn.coverageReport = coverageReport }
+}
+
+// WithTestSelection sets the test selection settings of the created toolchain
+func WithTestSelection(selection *TestSelection) func(tchn *Toolchain) {
+	return func(tchn *Toolchain) { tchn.testSelection = selection }
+}
+
+// WithBuildStep adds the provided step to the build steps of the created toolchain
+func WithBuildStep(step *Step) func(tchn *Toolchain) {
+	return func(tchn *Toolchain) { tchn.buildSteps = append(tchn.buildSteps, *step) }
+}
+
+// WithTestStep adds the provided step to the test steps of the created toolchain
+func WithTestStep(step *Step) func(tchn *Toolchain) {
+	return func(tchn *Toolchain) { tchn.testSteps = append(tchn.testSteps, *step) }
+}
+
+// WithInfraErrorDetection turns on infrastructure error detection for the created toolchain
+func WithInfraErrorDetection() func(tchn *Toolchain) {
+	return func(tchn *Toolchain) { tchn.detectInfraErrors = true }
+}
@@ -24,6 +24,8 @@ SOFTWARE.
 
 package toolchain
 
+import "github.com/murex/tcr/coverage"
+
 type commandFunc func() string
 type checkCommandFunc func() (string, error)
 
@@ -36,9 +38,10 @@ type (
 
 // List of supported toolchain operations
 const (
-	BuildOperation Operation = "build"
-	TestOperation  Operation = "test"
-	Never          Operation = ""
+	BuildOperation    Operation = "build"
+	TestOperation     Operation = "test"
+	SlowTestOperation Operation = "slow-test"
+	Never             Operation = ""
 )
 
 func (operations Operations) contains(operation Operation) bool {
@@ -56,6 +59,10 @@ type FakeToolchain struct {
 	Toolchain
 	failingOperations  Operations
 	testStats          TestStats
+	coverage           *coverage.Report
+	selectedTargets    []string
+	stepResults        map[Operation][]StepResult
+	infraErrors        Operations
 	buildCommandPath   commandFunc
 	testCommandPath    commandFunc
 	buildCommandLine   commandFunc
@@ -153,14 +160,69 @@ func (ft *FakeToolchain) RunBuild() CommandResult {
 // RunTests returns an error if test is part of failingOperations, nil otherwise.
 // This method does not call any real command
 func (ft *FakeToolchain) RunTests() TestCommandResult {
-	return TestCommandResult{ft.fakeOperation(TestOperation), ft.testStats}
+	return TestCommandResult{ft.fakeOperation(TestOperation), ft.testStats, ft.coverage}
+}
+
+// WithCoverage sets the coverage report settings and the coverage data returned by RunTests() method
+func (ft *FakeToolchain) WithCoverage(coverageReport *CoverageReport, coverageData *coverage.Report) *FakeToolchain {
+	ft.coverageReport = coverageReport
+	ft.coverage = coverageData
+	return ft
+}
+
+// RunSlowTests returns an error if slow-test is part of failingOperations, nil otherwise.
+// This method does not call any real command
+func (ft *FakeToolchain) RunSlowTests() CommandResult {
+	return ft.fakeOperation(SlowTestOperation)
+}
+
+// WithSlowTests adds a slow test command to the fake toolchain
+func (ft *FakeToolchain) WithSlowTests() *FakeToolchain {
+	ft.slowTestCommands = []Command{*ACommand()}
+	return ft
+}
+
+// RunSelectedTests behaves as RunTests() and records the provided test targets.
+// This method does not call any real command
+func (ft *FakeToolchain) RunSelectedTests(targets []string) TestCommandResult {
+	ft.selectedTargets = targets
+	return ft.RunTests()
+}
+
+// GetSelectedTargets returns the test targets provided on last call to RunSelectedTests()
+func (ft *FakeToolchain) GetSelectedTargets() []string {
+	return ft.selectedTargets
+}
+
+// WithTestSelection sets the test selection settings of the fake toolchain
+func (ft *FakeToolchain) WithTestSelection(selection *TestSelection) *FakeToolchain {
+	ft.testSelection = selection
+	return ft
 }
 
 func (ft *FakeToolchain) fakeOperation(operation Operation) (result CommandResult) {
-	if ft.failingOperations.contains(operation) {
+	if ft.infraErrors.contains(operation) {
+		result = CommandResult{Status: CommandStatusError, Output: "toolchain " + string(operation) + " fake infra error"}
+	} else if ft.failingOperations.contains(operation) {
 		result = CommandResult{Status: CommandStatusFail, Output: "toolchain " + string(operation) + " fake error"}
 	} else {
 		result = CommandResult{Status: CommandStatusPass, Output: ""}
 	}
+	result.Steps = ft.stepResults[operation]
 	return
 }
+
+// WithStepResults sets the step results returned when running the provided operation
+func (ft *FakeToolchain) WithStepResults(operation Operation, results ...StepResult) *FakeToolchain {
+	if ft.stepResults == nil {
+		ft.stepResults = make(map[Operation][]StepResult)
+	}
+	ft.stepResults[operation] = results
+	return ft
+}
+
+// WithInfraErrors makes the provided operations fail with an infrastructure error
+func (ft *FakeToolchain) WithInfraErrors(operations ...Operation) *FakeToolchain {
+	ft.infraErrors = operations
+	return ft
+}
@@ -80,7 +80,7 @@ func assertErrorWhenBuildFails(t *testing.T, toolchainName string, workDir strin
 	toolchain, _ := Get(toolchainName)
 	runFromDir(t, workDir,
 		func(t *testing.T) {
-			assert.Equal(t, toolchain.RunBuild().Status, CommandStatusFail)
+			assert.Equal(t, toolchain.RunBuild().Status, CommandStatusError)
 		})
 }
 
@@ -98,7 +98,7 @@ func assertErrorWhenTestFails(t *testing.T, toolchainName string, workDir string
 	toolchain, _ := Get(toolchainName)
 	runFromDir(t, workDir,
 		func(t *testing.T) {
-			assert.Equal(t, toolchain.RunTests().Status, CommandStatusFail)
+			assert.Equal(t, toolchain.RunTests().Status, CommandStatusError)
 		})
 }
 
@@ -0,0 +1,94 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package toolchain
+
+import (
+	"github.com/murex/tcr/schema"
+	"github.com/murex/tcr/utils"
+	"github.com/spf13/afero"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ValidateConfigFiles checks all toolchain configuration files, and returns the issues found
+func ValidateConfigFiles() (issues schema.Issues) {
+	for _, entry := range GetConfigFileList() {
+		issues = append(issues, ValidateConfigFile(entry)...)
+	}
+	return issues
+}
+
+// ValidateConfigFile checks the provided toolchain configuration file against the toolchain
+// configuration schema, then verifies that the resulting toolchain definition is consistent
+func ValidateConfigFile(yamlFilename string) schema.Issues {
+	filePath := filepath.Join(toolchainDirPath, yamlFilename)
+	data, err := afero.ReadFile(appFS, filePath)
+	if err != nil {
+		return schema.Issues{{File: filePath, Message: err.Error()}}
+	}
+	doc, issues := schema.ValidateData(schema.Toolchain, filePath, data)
+	if len(issues) > 0 {
+		return issues
+	}
+	cfg, err := readConfig(yamlFilename)
+	if err != nil {
+		return schema.Issues{doc.IssueAt("extends", err.Error(), "built-in toolchains: "+strings.Join(builtInNames(), ", "))}
+	}
+	return checkConfig(doc, asToolchain(*cfg))
+}
+
+// checkConfig performs the checks that cannot be expressed through the toolchain configuration schema
+func checkConfig(doc *schema.Document, tchn *Toolchain) (issues schema.Issues) {
+	for _, c := range []struct {
+		key   string
+		check func() error
+		hint  string
+	}{
+		{"build", tchn.checkBuildCommand, "define at least one build command"},
+		{"test", tchn.checkTestCommand, "define at least one test command"},
+		{"coverage-report", tchn.checkCoverageReport, ""},
+		{"test-selection", tchn.checkTestSelection, ""},
+		{"", tchn.checkSteps, ""},
+	} {
+		if err := c.check(); err != nil {
+			issues = append(issues, doc.IssueAt(c.key, err.Error(), c.hint))
+		}
+	}
+	return issues
+}
+
+func builtInNames() []string {
+	names := make([]string, 0, len(builtIn))
+	for name := range builtIn {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func traceIssues(issues schema.Issues) {
+	for _, issue := range issues {
+		utils.Trace(issue.String())
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package toolchain
+
+import (
+	"github.com/murex/tcr/schema"
+	"github.com/stretchr/testify/assert"
+	"path"
+	"testing"
+)
+
+func Test_built_in_toolchains_match_toolchain_schema(t *testing.T) {
+	entries, _ := builtInFS.ReadDir(builtInDir)
+	for _, entry := range entries {
+		t.Run(entry.Name(), func(t *testing.T) {
+			data, err := builtInFS.ReadFile(path.Join(builtInDir, entry.Name()))
+			assert.NoError(t, err)
+			_, issues := schema.ValidateData(schema.Toolchain, entry.Name(), data)
+			assert.Empty(t, issues)
+		})
+	}
+}
+
+func Test_validate_toolchain_config_file(t *testing.T) {
+	testFlags := []struct {
+		desc        string
+		data        string
+		expectedKey string
+		expectedLn  int
+	}{
+		{
+			"unknown OS name",
+			"extends: make\nbuild:\n  - os: [ linux, macos ]\n    command: make\n",
+			"build.0.os.1", 3,
+		},
+		{
+			"unknown architecture name",
+			"extends: make\ntest:\n  - arch: [ arm ]\n    command: make\n",
+			"test.0.arch.0", 3,
+		},
+		{
+			"wrong type",
+			"extends: make\ndetect-infra-errors: sometimes\n",
+			"detect-infra-errors", 2,
+		},
+		{
+			"no test command",
+			"build:\n  - command: make\ntest-result-dir: results\n",
+			"test", 1,
+		},
+		{
+			"exit code out of range",
+			"extends: make\ntest:\n  - command: make\n    exit-codes:\n      infra-error: [ 2147483648 ]\n",
+			"test.0.exit-codes.infra-error.0", 5,
+		},
+		{
+			"unknown extended toolchain",
+			"extends: unknown\n",
+			"extends", 1,
+		},
+	}
+	for _, tt := range testFlags {
+		t.Run(tt.desc, func(t *testing.T) {
+			setupToolchainConfigFile(t, "my-toolchain", tt.data)
+			issues := ValidateConfigFile("my-toolchain.yml")
+			if assert.Len(t, issues, 1) {
+				assert.Equal(t, tt.expectedKey, issues[0].Key)
+				assert.Equal(t, tt.expectedLn, issues[0].Line)
+			}
+		})
+	}
+}
+
+func Test_validate_toolchain_config_file_with_negative_exit_codes(t *testing.T) {
+	// Windows reports some process failures with negative exit codes (ex: 0xC0000005 access violation)
+	setupToolchainConfigFile(t, "my-toolchain",
+		"extends: make\ntest:\n  - command: make\n    exit-codes:\n      infra-error: [ -1073741819 ]\n")
+	assert.Empty(t, ValidateConfigFile("my-toolchain.yml"))
+}
+
+func Test_validate_valid_toolchain_config_file(t *testing.T) {
+	setupToolchainConfigFile(t, "make", "extends: make\ndetect-infra-errors: true\n")
+	assert.Empty(t, ValidateConfigFiles())
+}
@@ -39,4 +39,5 @@ type UserInterface interface {
 	StartReporting()
 	StopReporting()
 	MuteDesktopNotifications(muted bool)
+	NotifyAlert(a ...any)
 }
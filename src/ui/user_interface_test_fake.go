@@ -67,3 +67,6 @@ func (ui FakeUI) StopReporting() {}
 
 // MuteDesktopNotifications does nothing in FakeUI
 func (ui FakeUI) MuteDesktopNotifications(_ bool) {}
+
+// NotifyAlert does nothing in FakeUI
+func (ui FakeUI) NotifyAlert(_ ...any) {}
@@ -49,3 +49,8 @@ func Trace(a ...any) {
 func TraceKeyValue(key string, value any) {
 	Trace("- ", key, ": ", value)
 }
+
+// TraceKeyValueWithOrigin writes simple trace messages for a key/value pair, followed by the value's origin
+func TraceKeyValueWithOrigin(key string, value any, origin string) {
+	Trace("- ", key, ": ", value, " (from ", origin, ")")
+}
@@ -45,10 +45,23 @@ func LoadFromYAMLFile(filesystem fs.FS, filename string, out any) error {
 	// Cf. https://anil.io/blog/symfony/yaml/using-variables-in-yaml-files/
 	// Cf. https://pkg.go.dev/os#Expand
 
+	data, err := readYAMLFile(filesystem, filename)
+	if err != nil {
+		return err
+	}
+	if errUnmarshal := yaml.Unmarshal(data, out); errUnmarshal != nil {
+		Trace("Error while unmarshalling data: ", errUnmarshal)
+		return errUnmarshal
+	}
+	return nil
+}
+
+// readYAMLFile reads the raw contents of a YAML file
+func readYAMLFile(filesystem fs.FS, filename string) ([]byte, error) {
 	f, errOpen := filesystem.Open(filename)
 	if errOpen != nil {
 		Trace("Error while opening file: ", errOpen)
-		return errOpen
+		return nil, errOpen
 	}
 	defer func() {
 		_ = f.Close()
@@ -58,7 +71,7 @@ func LoadFromYAMLFile(filesystem fs.FS, filename string, out any) error {
 	info, errStat := f.Stat()
 	if errStat != nil {
 		Trace("Error while retrieving file info: ", errStat)
-		return errStat
+		return nil, errStat
 	}
 	size := info.Size() + 1 // one byte for final read at EOF
 
@@ -66,13 +79,9 @@ func LoadFromYAMLFile(filesystem fs.FS, filename string, out any) error {
 	l, errRead := f.Read(data)
 	if errRead != nil {
 		Trace("Error while reading file: ", errRead)
-		return errRead
-	}
-	if errUnmarshal := yaml.Unmarshal(data[:l], out); errUnmarshal != nil {
-		Trace("Error while unmarshalling data: ", errUnmarshal)
-		return errUnmarshal
+		return nil, errRead
 	}
-	return nil
+	return data[:l], nil
 }
 
 // SaveToYAMLFile saves a structure configuration into a YAML file
@@ -0,0 +1,218 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ErrYAMLEntryNotFound is returned when a YAML entry cannot be found at the provided path
+var ErrYAMLEntryNotFound = errors.New("entry not found")
+
+// LoadYAMLEntries loads the contents of a YAML file as a generic mapping.
+// An empty mapping is returned when the file does not exist
+func LoadYAMLEntries(filesystem afero.Fs, filename string) (map[string]any, error) {
+	entries := make(map[string]any)
+	data, err := afero.ReadFile(filesystem, filename)
+	if os.IsNotExist(err) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = yaml.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+	if entries == nil {
+		entries = make(map[string]any)
+	}
+	return entries, nil
+}
+
+// AsYAMLEntries converts a structure into a generic mapping, following its YAML tags
+func AsYAMLEntries(in any) (map[string]any, error) {
+	data, err := yaml.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+	entries := make(map[string]any)
+	return entries, yaml.Unmarshal(data, &entries)
+}
+
+// ParseYAMLValue converts a value provided as a string into a YAML value.
+// For instance "true" is converted into a boolean, and "[a, b]" into a list
+func ParseYAMLValue(value string) (any, error) {
+	var parsed any
+	if err := yaml.Unmarshal([]byte(value), &parsed); err != nil {
+		return nil, fmt.Errorf("invalid value %q: %w", value, err)
+	}
+	if parsed == nil {
+		return value, nil
+	}
+	return parsed, nil
+}
+
+// CheckYAMLEntries returns an error if the provided mapping contains keys or values that do not match
+// the structure of out. The "extends" key used by layered YAML files is ignored
+func CheckYAMLEntries(entries map[string]any, out any) error {
+	layer := MergeYAMLMaps(entries, nil)
+	delete(layer, extendsKey)
+	data, err := yaml.Marshal(layer)
+	if err != nil {
+		return err
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err = decoder.Decode(out); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
+
+// GetYAMLEntry returns the value found at the provided dotted path (ex: "test-files.directories").
+// Numeric path elements can be used for accessing list items (ex: "build.0.command")
+func GetYAMLEntry(entries map[string]any, path string) (any, error) {
+	var current any = entries
+	for _, key := range splitYAMLPath(path) {
+		next, found := yamlChild(current, key)
+		if !found {
+			return nil, fmt.Errorf("%s: %w", path, ErrYAMLEntryNotFound)
+		}
+		current = next
+	}
+	return current, nil
+}
+
+// SetYAMLEntry sets the value at the provided dotted path, creating intermediate mappings when needed.
+// List items can be replaced but not created
+func SetYAMLEntry(entries map[string]any, path string, value any) error {
+	keys := splitYAMLPath(path)
+	parent, err := yamlParent(entries, keys, true)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	last := keys[len(keys)-1]
+	switch p := parent.(type) {
+	case map[string]any:
+		p[last] = value
+	case []any:
+		index, ok := yamlIndex(p, last)
+		if !ok {
+			return fmt.Errorf("%s: %w", path, ErrYAMLEntryNotFound)
+		}
+		p[index] = value
+	default:
+		return fmt.Errorf("%s: cannot set a value under a scalar", path)
+	}
+	return nil
+}
+
+// InheritYAMLList copies from base the first list found along the provided path, when this list is missing
+// from entries. As lists cannot be merged, this allows changing a single item of a base definition's list
+func InheritYAMLList(entries map[string]any, base map[string]any, path string) error {
+	keys := splitYAMLPath(path)
+	for i := 1; i < len(keys); i++ {
+		prefix := strings.Join(keys[:i], ".")
+		if _, err := GetYAMLEntry(entries, prefix); err == nil {
+			continue
+		}
+		value, err := GetYAMLEntry(base, prefix)
+		if err != nil {
+			return nil
+		}
+		if list, isList := value.([]any); isList {
+			return SetYAMLEntry(entries, prefix, list)
+		}
+	}
+	return nil
+}
+
+// UnsetYAMLEntry removes the value at the provided dotted path. Mappings left empty are removed as well
+func UnsetYAMLEntry(entries map[string]any, path string) error {
+	keys := splitYAMLPath(path)
+	parent, err := yamlParent(entries, keys, false)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	p, isMap := parent.(map[string]any)
+	if !isMap {
+		return fmt.Errorf("%s: only mapping entries can be removed", path)
+	}
+	if _, found := p[keys[len(keys)-1]]; !found {
+		return fmt.Errorf("%s: %w", path, ErrYAMLEntryNotFound)
+	}
+	delete(p, keys[len(keys)-1])
+	if len(p) == 0 && len(keys) > 1 {
+		_ = UnsetYAMLEntry(entries, strings.Join(keys[:len(keys)-1], "."))
+	}
+	return nil
+}
+
+func splitYAMLPath(path string) []string {
+	return strings.Split(path, ".")
+}
+
+// yamlParent returns the container holding the last element of keys. When create is set,
+// missing intermediate mappings are created on the fly
+func yamlParent(entries map[string]any, keys []string, create bool) (any, error) {
+	var current any = entries
+	for _, key := range keys[:len(keys)-1] {
+		next, found := yamlChild(current, key)
+		if !found {
+			m, isMap := current.(map[string]any)
+			if !create || !isMap {
+				return nil, ErrYAMLEntryNotFound
+			}
+			next = make(map[string]any)
+			m[key] = next
+		}
+		current = next
+	}
+	return current, nil
+}
+
+func yamlChild(node any, key string) (any, bool) {
+	switch n := node.(type) {
+	case map[string]any:
+		child, found := n[key]
+		return child, found
+	case []any:
+		if index, ok := yamlIndex(n, key); ok {
+			return n[index], true
+		}
+	}
+	return nil, false
+}
+
+func yamlIndex(list []any, key string) (int, bool) {
+	index, err := strconv.Atoi(key)
+	return index, err == nil && index >= 0 && index < len(list)
+}
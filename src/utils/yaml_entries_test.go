@@ -0,0 +1,166 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package utils
+
+import (
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func sampleYAMLEntries() map[string]any {
+	return map[string]any{
+		"name": "sample",
+		"files": map[string]any{
+			"directories": []any{"src", "lib"},
+		},
+		"commands": []any{
+			map[string]any{"command": "make", "arguments": []any{"all"}},
+		},
+	}
+}
+
+func Test_get_yaml_entry(t *testing.T) {
+	testFlags := []struct {
+		path     string
+		expected any
+	}{
+		{"name", "sample"},
+		{"files.directories", []any{"src", "lib"}},
+		{"files.directories.1", "lib"},
+		{"commands.0.command", "make"},
+	}
+	for _, tt := range testFlags {
+		t.Run(tt.path, func(t *testing.T) {
+			value, err := GetYAMLEntry(sampleYAMLEntries(), tt.path)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func Test_get_unknown_yaml_entry(t *testing.T) {
+	for _, path := range []string{"unknown", "files.unknown", "files.directories.2", "name.sub"} {
+		t.Run(path, func(t *testing.T) {
+			_, err := GetYAMLEntry(sampleYAMLEntries(), path)
+			assert.ErrorIs(t, err, ErrYAMLEntryNotFound)
+		})
+	}
+}
+
+func Test_set_yaml_entry(t *testing.T) {
+	entries := sampleYAMLEntries()
+	assert.NoError(t, SetYAMLEntry(entries, "files.patterns", []any{"*.c"}))
+	assert.NoError(t, SetYAMLEntry(entries, "new.sub.key", true))
+	assert.NoError(t, SetYAMLEntry(entries, "commands.0.command", "cmake"))
+
+	assert.Equal(t, []any{"*.c"}, entries["files"].(map[string]any)["patterns"])
+	assert.Equal(t, map[string]any{"sub": map[string]any{"key": true}}, entries["new"])
+	value, _ := GetYAMLEntry(entries, "commands.0.command")
+	assert.Equal(t, "cmake", value)
+}
+
+func Test_set_yaml_entry_cannot_create_list_items(t *testing.T) {
+	assert.ErrorIs(t, SetYAMLEntry(sampleYAMLEntries(), "commands.1", "x"), ErrYAMLEntryNotFound)
+}
+
+func Test_unset_yaml_entry_removes_empty_parent_mappings(t *testing.T) {
+	entries := sampleYAMLEntries()
+	assert.NoError(t, UnsetYAMLEntry(entries, "files.directories"))
+	_, found := entries["files"]
+	assert.False(t, found)
+}
+
+func Test_unset_unknown_yaml_entry(t *testing.T) {
+	assert.ErrorIs(t, UnsetYAMLEntry(sampleYAMLEntries(), "files.unknown"), ErrYAMLEntryNotFound)
+}
+
+func Test_inherit_yaml_list_from_base(t *testing.T) {
+	entries := map[string]any{"extends": "sample"}
+	assert.NoError(t, InheritYAMLList(entries, sampleYAMLEntries(), "commands.0.command"))
+	assert.Equal(t, sampleYAMLEntries()["commands"], entries["commands"])
+	_, found := entries["files"]
+	assert.False(t, found)
+}
+
+func Test_parse_yaml_value(t *testing.T) {
+	testFlags := []struct {
+		value    string
+		expected any
+	}{
+		{"", ""},
+		{"text", "text"},
+		{"true", true},
+		{"12", 12},
+		{"[a, b]", []any{"a", "b"}},
+	}
+	for _, tt := range testFlags {
+		t.Run(tt.value, func(t *testing.T) {
+			parsed, err := ParseYAMLValue(tt.value)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, parsed)
+		})
+	}
+}
+
+func Test_check_yaml_entries(t *testing.T) {
+	type sample struct {
+		Name  string   `yaml:"name"`
+		Items []string `yaml:"items"`
+	}
+	testFlags := []struct {
+		desc      string
+		entries   map[string]any
+		expectErr bool
+	}{
+		{"valid entries", map[string]any{"name": "x", "items": []any{"a"}}, false},
+		{"extends key is ignored", map[string]any{"extends": "base", "name": "x"}, false},
+		{"unknown key", map[string]any{"unknown": "x"}, true},
+		{"wrong type", map[string]any{"items": "a"}, true},
+		{"no entry", map[string]any{}, false},
+	}
+	for _, tt := range testFlags {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := CheckYAMLEntries(tt.entries, &sample{})
+			if tt.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_load_yaml_entries_from_missing_file(t *testing.T) {
+	entries, err := LoadYAMLEntries(afero.NewMemMapFs(), "missing.yml")
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func Test_load_yaml_entries_from_file(t *testing.T) {
+	filesystem := afero.NewMemMapFs()
+	_ = afero.WriteFile(filesystem, "sample.yml", []byte("name: sample\nfiles:\n  directories: [src]\n"), 0600)
+	entries, err := LoadYAMLEntries(filesystem, "sample.yml")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"name": "sample", "files": map[string]any{"directories": []any{"src"}}}, entries)
+}
@@ -0,0 +1,218 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io/fs"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// extendsKey is the key used in a YAML configuration file for extending a base definition
+const extendsKey = "extends"
+
+type (
+	// YAMLOrigins provides the origin of each value of a layered YAML configuration.
+	// Keys use a dotted notation (ex: "test-files.directories")
+	YAMLOrigins map[string]string
+
+	// YAMLBaseLoader returns the raw YAML data and the origin of the base definition with the provided name
+	YAMLBaseLoader func(name string) (data []byte, origin string, err error)
+)
+
+// LoadFromLayeredYAMLFile loads a structure configuration from a YAML file that may extend a base definition.
+// When the YAML file contains an "extends" key, the base definition with this name is retrieved through
+// the provided loader, and the YAML file only needs to provide the keys overriding the base definition.
+// Mappings are merged key by key, while any other value (including lists) replaces the base definition's value.
+// Returns the name of the extended definition and the origin of each value, or an empty name and nil origins
+// when the YAML file does not extend any definition
+func LoadFromLayeredYAMLFile(
+	filesystem fs.FS, filename string, origin string, base YAMLBaseLoader, out any,
+) (extends string, origins YAMLOrigins, err error) {
+	data, err := readYAMLFile(filesystem, filename)
+	if err != nil {
+		return "", nil, err
+	}
+	var layer map[string]any
+	if err = yaml.Unmarshal(data, &layer); err != nil {
+		Trace("Error while unmarshalling data: ", err)
+		return "", nil, err
+	}
+	value, found := layer[extendsKey]
+	if !found {
+		return "", nil, yaml.Unmarshal(data, out)
+	}
+	extends, ok := value.(string)
+	if !ok || extends == "" {
+		return "", nil, errors.New("\"" + extendsKey + "\" value must be the name of the definition to extend")
+	}
+	delete(layer, extendsKey)
+
+	baseData, baseOrigin, err := base(extends)
+	if err != nil {
+		return "", nil, err
+	}
+	var baseLayer map[string]any
+	if err = yaml.Unmarshal(baseData, &baseLayer); err != nil {
+		return "", nil, fmt.Errorf("cannot load %s: %w", baseOrigin, err)
+	}
+
+	merged := MergeYAMLMaps(baseLayer, layer)
+	mergedData, err := yaml.Marshal(merged)
+	if err != nil {
+		return "", nil, err
+	}
+	if err = yaml.Unmarshal(mergedData, out); err != nil {
+		return "", nil, err
+	}
+
+	overridden := make(map[string]bool)
+	for _, key := range FlattenYAMLKeys("", layer) {
+		overridden[key] = true
+	}
+	origins = make(YAMLOrigins)
+	for _, key := range FlattenYAMLKeys("", merged) {
+		if overridden[key] {
+			origins[key] = origin
+		} else {
+			origins[key] = baseOrigin
+		}
+	}
+	return extends, origins, nil
+}
+
+// BuildYAMLLayer returns the YAML entries of data that differ from the base definition with the provided name,
+// together with the "extends" key, so that loading them with LoadFromLayeredYAMLFile gives back data.
+// data must be a structure value. The base definition is loaded into a structure of the same type
+// before being compared with data
+func BuildYAMLLayer(data any, extends string, base YAMLBaseLoader) (map[string]any, error) {
+	baseData, baseOrigin, err := base(extends)
+	if err != nil {
+		return nil, err
+	}
+	baseOut := reflect.New(reflect.TypeOf(data))
+	if err = yaml.Unmarshal(baseData, baseOut.Interface()); err != nil {
+		return nil, fmt.Errorf("cannot load %s: %w", baseOrigin, err)
+	}
+	baseLayer, err := toYAMLMap(baseOut.Elem().Interface())
+	if err != nil {
+		return nil, err
+	}
+	layer, err := toYAMLMap(data)
+	if err != nil {
+		return nil, err
+	}
+	diff := DiffYAMLMaps(baseLayer, layer)
+	diff[extendsKey] = extends
+	return diff, nil
+}
+
+func toYAMLMap(in any) (m map[string]any, err error) {
+	data, err := yaml.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+	err = yaml.Unmarshal(data, &m)
+	if m == nil {
+		m = make(map[string]any)
+	}
+	return m, err
+}
+
+// DiffYAMLMaps returns the values of the overriding map that differ from the base map.
+// Mappings are compared key by key, while any other value (including lists) is compared as a whole.
+// This is the reverse operation of MergeYAMLMaps
+func DiffYAMLMaps(base map[string]any, override map[string]any) map[string]any {
+	diff := make(map[string]any)
+	for key, value := range override {
+		baseMap, baseIsMap := base[key].(map[string]any)
+		overrideMap, overrideIsMap := value.(map[string]any)
+		if baseIsMap && overrideIsMap {
+			if sub := DiffYAMLMaps(baseMap, overrideMap); len(sub) > 0 {
+				diff[key] = sub
+			}
+		} else if baseValue, found := base[key]; !found || !reflect.DeepEqual(baseValue, value) {
+			diff[key] = value
+		}
+	}
+	return diff
+}
+
+// MergeYAMLMaps returns a new map with the values of the overriding map applied on top of the base map
+func MergeYAMLMaps(base map[string]any, override map[string]any) map[string]any {
+	merged := make(map[string]any, len(base)+len(override))
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range override {
+		baseMap, baseIsMap := merged[key].(map[string]any)
+		overrideMap, overrideIsMap := value.(map[string]any)
+		if baseIsMap && overrideIsMap {
+			merged[key] = MergeYAMLMaps(baseMap, overrideMap)
+		} else {
+			merged[key] = value
+		}
+	}
+	return merged
+}
+
+// FlattenYAMLKeys returns the sorted list of keys leading to a value that is not a mapping
+func FlattenYAMLKeys(prefix string, m map[string]any) (keys []string) {
+	for key, value := range m {
+		if sub, isMap := value.(map[string]any); isMap && len(sub) > 0 {
+			keys = append(keys, FlattenYAMLKeys(prefix+key+".", sub)...)
+		} else {
+			keys = append(keys, prefix+key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Get returns the origin of the value for the provided key. When the key is not found, the origin
+// of the closest parent key is returned
+func (o YAMLOrigins) Get(key string) string {
+	for k := key; k != ""; {
+		if origin, found := o[k]; found {
+			return origin
+		}
+		i := strings.LastIndex(k, ".")
+		if i < 0 {
+			break
+		}
+		k = k[:i]
+	}
+	return ""
+}
+
+// Tracer returns a key/value trace function that also traces the origin of each value.
+// Traced keys are expected to start with the provided prefix, which is ignored when looking up origins
+func (o YAMLOrigins) Tracer(prefix string) func(key string, value any) {
+	return func(key string, value any) {
+		TraceKeyValueWithOrigin(key, value, o.Get(strings.TrimPrefix(key, prefix)))
+	}
+}
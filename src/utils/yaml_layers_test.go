@@ -0,0 +1,131 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package utils
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"testing/fstest"
+)
+
+type layeredStructYAML struct {
+	Name  string            `yaml:"name"`
+	Items []string          `yaml:"items"`
+	Sub   map[string]string `yaml:"sub"`
+}
+
+const baseYAMLData = "name: base\nitems: [ a, b ]\nsub:\n  key1: value1\n  key2: value2\n"
+
+func loadBaseYAML(name string) ([]byte, string, error) {
+	if name != "base" {
+		return nil, "", errors.New("unknown definition " + name)
+	}
+	return []byte(baseYAMLData), "built-in base", nil
+}
+
+func loadLayeredYAML(data string) (string, YAMLOrigins, layeredStructYAML, error) {
+	var out layeredStructYAML
+	filesystem := fstest.MapFS{"layer.yml": &fstest.MapFile{Data: []byte(data)}}
+	extends, origins, err := LoadFromLayeredYAMLFile(filesystem, "layer.yml", "layer.yml", loadBaseYAML, &out)
+	return extends, origins, out, err
+}
+
+func Test_load_from_layered_yaml_file_with_no_base_definition(t *testing.T) {
+	extends, origins, out, err := loadLayeredYAML("name: standalone\nitems: [ c ]\n")
+	assert.NoError(t, err)
+	assert.Equal(t, "", extends)
+	assert.Nil(t, origins)
+	assert.Equal(t, layeredStructYAML{Name: "standalone", Items: []string{"c"}}, out)
+}
+
+func Test_load_from_layered_yaml_file_extending_a_base_definition(t *testing.T) {
+	extends, origins, out, err := loadLayeredYAML("extends: base\nitems: [ c ]\nsub:\n  key2: other\n")
+	assert.NoError(t, err)
+	assert.Equal(t, "base", extends)
+	assert.Equal(t, layeredStructYAML{
+		Name:  "base",
+		Items: []string{"c"},
+		Sub:   map[string]string{"key1": "value1", "key2": "other"},
+	}, out)
+	assert.Equal(t, YAMLOrigins{
+		"name":     "built-in base",
+		"items":    "layer.yml",
+		"sub.key1": "built-in base",
+		"sub.key2": "layer.yml",
+	}, origins)
+}
+
+func Test_build_yaml_layer_keeps_only_values_differing_from_base_definition(t *testing.T) {
+	data := layeredStructYAML{
+		Name:  "base",
+		Items: []string{"c"},
+		Sub:   map[string]string{"key1": "value1", "key2": "other"},
+	}
+	layer, err := BuildYAMLLayer(data, "base", loadBaseYAML)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"extends": "base",
+		"items":   []any{"c"},
+		"sub":     map[string]any{"key2": "other"},
+	}, layer)
+}
+
+func Test_build_yaml_layer_extending_an_unknown_definition(t *testing.T) {
+	_, err := BuildYAMLLayer(layeredStructYAML{}, "unknown", loadBaseYAML)
+	assert.Error(t, err)
+}
+
+func Test_diff_yaml_maps(t *testing.T) {
+	base := map[string]any{"a": 1, "b": []any{"x"}, "c": map[string]any{"d": 2, "e": 3}}
+	override := map[string]any{"a": 1, "b": []any{"x", "y"}, "c": map[string]any{"d": 2, "e": 4}, "f": 5}
+	diff := DiffYAMLMaps(base, override)
+	assert.Equal(t, map[string]any{"b": []any{"x", "y"}, "c": map[string]any{"e": 4}, "f": 5}, diff)
+	assert.Equal(t, override, MergeYAMLMaps(base, diff))
+}
+
+func Test_load_from_layered_yaml_file_extending_an_unknown_definition(t *testing.T) {
+	_, _, _, err := loadLayeredYAML("extends: unknown\n")
+	assert.Error(t, err)
+}
+
+func Test_load_from_layered_yaml_file_with_invalid_extends_value(t *testing.T) {
+	_, _, _, err := loadLayeredYAML("extends: [ base ]\n")
+	assert.Error(t, err)
+}
+
+func Test_yaml_origins_fall_back_on_parent_keys(t *testing.T) {
+	origins := YAMLOrigins{"build": "layer.yml", "sub.key1": "built-in base"}
+	assert.Equal(t, "layer.yml", origins.Get("build.command"))
+	assert.Equal(t, "built-in base", origins.Get("sub.key1"))
+	assert.Equal(t, "", origins.Get("sub.key2"))
+	assert.Equal(t, "", origins.Get("unknown"))
+}
+
+func Test_trace_key_value_with_origin(t *testing.T) {
+	AssertSimpleTrace(t, []string{"- prefix.sub.key1: value1 (from built-in base)"},
+		func() {
+			YAMLOrigins{"sub.key1": "built-in base"}.Tracer("prefix.")("prefix.sub.key1", "value1")
+		},
+	)
+}
@@ -23,11 +23,20 @@ SOFTWARE.
 package vcs
 
 type (
-	// FileDiff is a structure containing diff information for a file
+	// FileDiff is a structure containing diff information for a file.
+	// ChangedRanges contains the ranges of added or modified lines in the new version
+	// of the file. It is nil when the VCS does not provide this information
 	FileDiff struct {
-		Path         string
-		AddedLines   int
-		RemovedLines int
+		Path          string
+		AddedLines    int
+		RemovedLines  int
+		ChangedRanges []LineRange
+	}
+
+	// LineRange is a range of consecutive line numbers, from Start to End (both included)
+	LineRange struct {
+		Start int
+		End   int
 	}
 
 	// FileDiffs contains a set of FileDiff data in a slice
@@ -39,6 +48,26 @@ func NewFileDiff(filename string, added int, removed int) FileDiff {
 	return FileDiff{Path: filename, AddedLines: added, RemovedLines: removed}
 }
 
+// WithChangedRanges returns a copy of the file diff with the provided ranges of changed lines
+func (fd FileDiff) WithChangedRanges(ranges ...LineRange) FileDiff {
+	fd.ChangedRanges = append([]LineRange{}, ranges...)
+	return fd
+}
+
+// IsChangedLine indicates if the provided line number was added or modified in the new version
+// of the file. When changed line ranges are not known, all lines are considered as changed
+func (fd FileDiff) IsChangedLine(line int) bool {
+	if fd.ChangedRanges == nil {
+		return true
+	}
+	for _, r := range fd.ChangedRanges {
+		if line >= r.Start && line <= r.End {
+			return true
+		}
+	}
+	return false
+}
+
 // ChangedLines returns the number of changed lines for this file
 func (fd FileDiff) ChangedLines() int {
 	return fd.AddedLines + fd.RemovedLines
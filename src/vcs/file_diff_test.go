@@ -79,3 +79,26 @@ func Test_total_number_of_changed_lines(t *testing.T) {
 		})
 	}
 }
+
+func Test_is_changed_line(t *testing.T) {
+	tests := []struct {
+		desc     string
+		diff     FileDiff
+		line     int
+		expected bool
+	}{
+		{"unknown ranges", NewFileDiff("", 1, 0), 12, true},
+		{"no range", NewFileDiff("", 0, 1).WithChangedRanges(), 1, false},
+		{"line before range", NewFileDiff("", 2, 0).WithChangedRanges(LineRange{Start: 3, End: 4}), 2, false},
+		{"first line of range", NewFileDiff("", 2, 0).WithChangedRanges(LineRange{Start: 3, End: 4}), 3, true},
+		{"last line of range", NewFileDiff("", 2, 0).WithChangedRanges(LineRange{Start: 3, End: 4}), 4, true},
+		{"line after range", NewFileDiff("", 2, 0).WithChangedRanges(LineRange{Start: 3, End: 4}), 5, false},
+		{"line in second range", NewFileDiff("", 2, 0).WithChangedRanges(
+			LineRange{Start: 1, End: 1}, LineRange{Start: 8, End: 8}), 8, true},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.diff.IsChangedLine(test.line))
+		})
+	}
+}
@@ -180,6 +180,33 @@ func retrieveWorkingBranch(repository *git.Repository) (string, error) {
 	return head.Target().Short(), nil
 }
 
+// WorkingBranch returns the current working branch of the git repository containing dir
+func WorkingBranch(dir string) (string, error) {
+	repo, _, err := plainOpen(dir)
+	if err != nil {
+		return "", err
+	}
+	return retrieveWorkingBranch(repo)
+}
+
+// CreateBranch creates a new branch starting from the current commit in the git repository
+// containing dir, and makes it the working branch. Local changes are kept
+func CreateBranch(dir string, name string) error {
+	repo, _, err := plainOpen(dir)
+	if err != nil {
+		return err
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return err
+	}
+	return worktree.Checkout(&git.CheckoutOptions{
+		Branch: plumbing.NewBranchReferenceName(name),
+		Create: true,
+		Keep:   true,
+	})
+}
+
 // GetRootDir returns the root directory path
 func (g *gitImpl) GetRootDir() string {
 	return g.rootDir
@@ -311,29 +338,79 @@ func (g *gitImpl) UnStash(keep bool) error {
 	return g.traceGit("stash", stashAction, "--quiet")
 }
 
-// Diff returns the list of files modified since last commit with diff info for each file
+// Diff returns the list of files modified since last commit with diff info for each file,
+// including the ranges of changed lines in each file.
 // Current implementation uses a direct call to git
 func (g *gitImpl) Diff() (diffs vcs.FileDiffs, err error) {
 	var gitOutput []byte
-	gitOutput, err = g.runGit("diff", "--numstat", "--ignore-cr-at-eol",
-		"--ignore-all-space", "--ignore-blank-lines", "HEAD")
+	gitOutput, err = g.runGit("diff", "--numstat", "--patch", "--unified=0",
+		"--no-color", "--no-ext-diff", "--src-prefix=a/", "--dst-prefix=b/",
+		"--ignore-cr-at-eol", "--ignore-all-space", "--ignore-blank-lines", "HEAD")
 	if err != nil {
 		return nil, err
 	}
 
+	changedRanges := make(map[string][]vcs.LineRange)
+	inPatch, inFileHeader := false, false
+	var patchedFile string
 	scanner := bufio.NewScanner(bytes.NewReader(gitOutput))
 	for scanner.Scan() {
-		fields := strings.Split(scanner.Text(), "\t")
-		if len(fields) == 3 { //nolint:revive
-			added, _ := strconv.Atoi(fields[0])
-			removed, _ := strconv.Atoi(fields[1])
-			filename := filepath.Join(g.rootDir, fields[2])
-			diffs = append(diffs, vcs.NewFileDiff(filename, added, removed))
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			// numstat lines come first, then one patch per file
+			inPatch, inFileHeader, patchedFile = true, true, ""
+		case !inPatch:
+			fields := strings.Split(line, "\t")
+			if len(fields) == 3 { //nolint:revive
+				added, _ := strconv.Atoi(fields[0])
+				removed, _ := strconv.Atoi(fields[1])
+				filename := filepath.Join(g.rootDir, fields[2])
+				diffs = append(diffs, vcs.NewFileDiff(filename, added, removed))
+			}
+		case inFileHeader && strings.HasPrefix(line, "+++ b/"):
+			// git appends a tab to file names containing spaces
+			patchedFile = filepath.Join(g.rootDir, strings.TrimSuffix(strings.TrimPrefix(line, "+++ b/"), "\t"))
+			changedRanges[patchedFile] = []vcs.LineRange{}
+		case strings.HasPrefix(line, "@@ "):
+			inFileHeader = false
+			if r, ok := parseHunkHeader(line); ok && patchedFile != "" {
+				changedRanges[patchedFile] = append(changedRanges[patchedFile], r)
+			}
+		}
+	}
+	for i := range diffs {
+		if ranges, found := changedRanges[diffs[i].Path]; found {
+			diffs[i] = diffs[i].WithChangedRanges(ranges...)
 		}
 	}
 	return diffs, nil
 }
 
+// parseHunkHeader returns the range of lines changed in the new version of the file from
+// a hunk header (ex: "@@ -5,0 +6,2 @@"). ok is false when the hunk only removes lines
+func parseHunkHeader(header string) (r vcs.LineRange, ok bool) {
+	fields := strings.Fields(header)
+	if len(fields) < 3 || !strings.HasPrefix(fields[2], "+") { //nolint:revive
+		return r, false
+	}
+	start, count, found := strings.Cut(strings.TrimPrefix(fields[2], "+"), ",")
+	first, err := strconv.Atoi(start)
+	if err != nil {
+		return r, false
+	}
+	lines := 1
+	if found {
+		if lines, err = strconv.Atoi(count); err != nil {
+			return r, false
+		}
+	}
+	if lines == 0 {
+		return r, false
+	}
+	return vcs.LineRange{Start: first, End: first + lines - 1}, true
+}
+
 // Log returns the list of git log items compliant with the provided msgFilter.
 // When no msgFilter is provided, returns all git log items unfiltered.
 // Current implementation uses go-git's Log() function
@@ -90,6 +90,38 @@ func Test_can_retrieve_working_branch_on_current_repo(t *testing.T) {
 	assert.NotEmpty(t, g.GetWorkingBranch())
 }
 
+func Test_working_branch_on_current_repo(t *testing.T) {
+	branch, err := WorkingBranch(".")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, branch)
+}
+
+func Test_working_branch_outside_a_git_repo(t *testing.T) {
+	branch, err := WorkingBranch("/")
+	assert.Error(t, err)
+	assert.Empty(t, branch)
+}
+
+func Test_create_branch(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	assert.NoError(t, err)
+	worktree, _ := repo.Worktree()
+	_, err = worktree.Commit("initial commit", &git.CommitOptions{
+		AllowEmptyCommits: true,
+		Author:            &object.Signature{Name: "test", Email: "test@example.com"},
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, CreateBranch(dir, "tcr/session"))
+	branch, _ := WorkingBranch(dir)
+	assert.Equal(t, "tcr/session", branch)
+}
+
+func Test_create_branch_outside_a_git_repo(t *testing.T) {
+	assert.Error(t, CreateBranch(t.TempDir(), "tcr/session"))
+}
+
 func Test_check_remote_access_on_in_memory_repo(t *testing.T) {
 	g, _ := newGitImpl(inMemoryRepoInit, "")
 	assert.False(t, g.CheckRemoteAccess())
@@ -109,7 +141,9 @@ func Test_git_diff(t *testing.T) {
 			nil,
 			false,
 			[]string{
-				"diff", "--numstat", "--ignore-cr-at-eol", "--ignore-all-space", "--ignore-blank-lines", "HEAD"},
+				"diff", "--numstat", "--patch", "--unified=0",
+				"--no-color", "--no-ext-diff", "--src-prefix=a/", "--dst-prefix=b/",
+				"--ignore-cr-at-eol", "--ignore-all-space", "--ignore-blank-lines", "HEAD"},
 			nil,
 		},
 		{"git diff command call fails",
@@ -172,6 +206,32 @@ func Test_git_diff(t *testing.T) {
 				{Path: filepath.Join("/", "some-file.txt"), AddedLines: 0, RemovedLines: 7},
 			},
 		},
+		{"changed line ranges",
+			"3\t1\tfile1.txt\n0\t1\tsome dir/file2.txt\n\n" +
+				"diff --git a/file1.txt b/file1.txt\n" +
+				"index 9405325..c1f6ebf 100644\n" +
+				"--- a/file1.txt\n" +
+				"+++ b/file1.txt\n" +
+				"@@ -2 +2 @@ a\n" +
+				"-b\n" +
+				"+B\n" +
+				"@@ -5,0 +6,2 @@ e\n" +
+				"+f\n" +
+				"++++ b/not-a-header.txt\n" +
+				"diff --git a/some dir/file2.txt b/some dir/file2.txt\n" +
+				"--- a/some dir/file2.txt\t\n" +
+				"+++ b/some dir/file2.txt\t\n" +
+				"@@ -1 +0,0 @@\n" +
+				"-x\n",
+			nil,
+			false,
+			nil,
+			vcs.FileDiffs{
+				vcs.NewFileDiff(filepath.Join("/", "file1.txt"), 3, 1).WithChangedRanges(
+					vcs.LineRange{Start: 2, End: 2}, vcs.LineRange{Start: 6, End: 7}),
+				vcs.NewFileDiff(filepath.Join("/", "some dir", "file2.txt"), 0, 1).WithChangedRanges(),
+			},
+		},
 		{"noise in output trace",
 			"warning: LF will be replaced by CRLF in some-file.txt.\n" +
 				"The file will have its original line endings in your working directory\n" +
@@ -0,0 +1,97 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package wizard
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/murex/tcr/cli"
+	"io"
+	"strings"
+)
+
+// Prompter is the interface used by the initialization wizard to interact with the user
+type Prompter interface {
+	Confirm(message string, defaultAnswer bool) bool
+	Ask(message string, defaultAnswer string) string
+}
+
+// TextPrompter is a line-based Prompter reading answers from an input stream.
+// When acceptDefaults is set, questions are printed out together with their
+// default answer, and nothing is read from the input stream
+type TextPrompter struct {
+	in             *bufio.Reader
+	out            io.Writer
+	acceptDefaults bool
+}
+
+// NewTextPrompter creates a new TextPrompter instance
+func NewTextPrompter(in io.Reader, out io.Writer, acceptDefaults bool) *TextPrompter {
+	return &TextPrompter{in: bufio.NewReader(in), out: out, acceptDefaults: acceptDefaults}
+}
+
+// Confirm asks a yes/no question to the user. An empty answer selects the default answer.
+// The question is asked again until a valid answer is provided
+func (tp *TextPrompter) Confirm(message string, defaultAnswer bool) bool {
+	for {
+		input := tp.readAnswer(message+" "+cli.YesOrNoAdvice(defaultAnswer)+" ", yesOrNo(defaultAnswer))
+		if answer, valid := cli.ParseYesOrNo(input, defaultAnswer); valid {
+			return answer
+		}
+	}
+}
+
+// Ask asks a question to the user. An empty answer selects the default answer
+func (tp *TextPrompter) Ask(message string, defaultAnswer string) string {
+	prompt := message + ": "
+	if defaultAnswer != "" {
+		prompt = message + " [" + defaultAnswer + "]: "
+	}
+	answer := tp.readAnswer(prompt, defaultAnswer)
+	if answer == "" {
+		return defaultAnswer
+	}
+	return answer
+}
+
+// readAnswer prints out the prompt and returns the trimmed answer. The answer is empty
+// when defaults are accepted or when no more answer can be read from the input stream
+func (tp *TextPrompter) readAnswer(prompt string, defaultAnswer string) string {
+	_, _ = fmt.Fprint(tp.out, prompt)
+	if tp.acceptDefaults {
+		_, _ = fmt.Fprintln(tp.out, defaultAnswer)
+		return ""
+	}
+	line, err := tp.in.ReadString('\n')
+	if err != nil && line == "" {
+		_, _ = fmt.Fprintln(tp.out)
+	}
+	return strings.TrimSpace(line)
+}
+
+func yesOrNo(answer bool) string {
+	if answer {
+		return "y"
+	}
+	return "n"
+}
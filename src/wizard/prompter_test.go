@@ -0,0 +1,88 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package wizard
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func Test_text_prompter_confirm(t *testing.T) {
+	testFlags := []struct {
+		desc          string
+		input         string
+		defaultAnswer bool
+		expected      bool
+	}{
+		{"yes", "y\n", false, true},
+		{"YES", "YES\n", false, true},
+		{"no", "n\n", true, false},
+		{"empty answer with default yes", "\n", true, true},
+		{"empty answer with default no", "\n", false, false},
+		{"end of input", "", true, true},
+		{"invalid answer followed by yes", "maybe\ny\n", false, true},
+	}
+	for _, tt := range testFlags {
+		t.Run(tt.desc, func(t *testing.T) {
+			tp := NewTextPrompter(strings.NewReader(tt.input), &bytes.Buffer{}, false)
+			assert.Equal(t, tt.expected, tp.Confirm("Proceed?", tt.defaultAnswer))
+		})
+	}
+}
+
+func Test_text_prompter_confirm_prints_question_with_default_answer(t *testing.T) {
+	var out bytes.Buffer
+	tp := NewTextPrompter(strings.NewReader("\n"), &out, false)
+	tp.Confirm("Proceed?", true)
+	assert.Equal(t, "Proceed? [Y/n] ", out.String())
+}
+
+func Test_text_prompter_ask(t *testing.T) {
+	testFlags := []struct {
+		desc          string
+		input         string
+		defaultAnswer string
+		expected      string
+	}{
+		{"answer provided", "java\n", "go", "java"},
+		{"answer with spaces", "  java  \n", "go", "java"},
+		{"empty answer", "\n", "go", "go"},
+		{"end of input", "", "go", "go"},
+	}
+	for _, tt := range testFlags {
+		t.Run(tt.desc, func(t *testing.T) {
+			tp := NewTextPrompter(strings.NewReader(tt.input), &bytes.Buffer{}, false)
+			assert.Equal(t, tt.expected, tp.Ask("Language", tt.defaultAnswer))
+		})
+	}
+}
+
+func Test_text_prompter_accepting_defaults(t *testing.T) {
+	var out bytes.Buffer
+	tp := NewTextPrompter(strings.NewReader("n\nrust\n"), &out, true)
+	assert.True(t, tp.Confirm("Proceed?", true))
+	assert.Equal(t, "go", tp.Ask("Language", "go"))
+	assert.Equal(t, "Proceed? [Y/n] y\nLanguage [go]: go\n", out.String())
+}
@@ -0,0 +1,150 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package wizard
+
+import (
+	"fmt"
+	"github.com/murex/tcr/language"
+	"github.com/murex/tcr/params"
+	"github.com/murex/tcr/vcs/git"
+	"github.com/murex/tcr/vcs/p4"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Run walks the user through TCR configuration of the project located in p.BaseDir,
+// and returns the resulting parameters. Values already set in p (from command line
+// or from configuration files) are proposed as default answers
+func Run(p params.Params, prompter Prompter) (params.Params, error) {
+	steps := []func(*params.Params, Prompter) error{
+		chooseLanguage,
+		chooseToolchain,
+		chooseVCS,
+		chooseAutoPush,
+		chooseCommitFailures,
+		chooseMobTimerDuration,
+	}
+	for _, step := range steps {
+		if err := step(&p, prompter); err != nil {
+			return p, err
+		}
+	}
+	return p, nil
+}
+
+func chooseLanguage(p *params.Params, prompter Prompter) error {
+	proposal, reason := p.Language, "current configuration"
+	if proposal == "" {
+		// When a language is detected, the first detection reason relates to the language
+		if d := language.Detect(p.BaseDir); d.Language != "" {
+			proposal, reason = d.Language, d.Reasons[0]
+		}
+	}
+	if proposal != "" && prompter.Confirm(fmt.Sprintf("Use %s language (%s)?", proposal, reason), true) {
+		p.Language = proposal
+		return nil
+	}
+	name, err := choose(prompter, "Language", proposal, language.Names())
+	if err != nil {
+		return err
+	}
+	p.Language = name
+	return nil
+}
+
+func chooseToolchain(p *params.Params, prompter Prompter) error {
+	lang, err := language.Get(p.Language)
+	if err != nil {
+		return err
+	}
+	proposal, reason := p.Toolchain, "current configuration"
+	if proposal == "" || !slices.Contains(lang.GetToolchains().Compatible, proposal) {
+		proposal, reason = lang.GetToolchains().Default, p.Language+" default toolchain"
+		if d := language.Detect(p.BaseDir); d.Language == p.Language && d.Toolchain != "" {
+			// When a toolchain is detected, the last detection reason relates to the toolchain
+			proposal, reason = d.Toolchain, d.Reasons[len(d.Reasons)-1]
+		}
+	}
+	if proposal != "" && prompter.Confirm(fmt.Sprintf("Use %s toolchain (%s)?", proposal, reason), true) {
+		p.Toolchain = proposal
+		return nil
+	}
+	name, err := choose(prompter, "Toolchain", proposal, lang.GetToolchains().Compatible)
+	if err != nil {
+		return err
+	}
+	p.Toolchain = name
+	return nil
+}
+
+func chooseVCS(p *params.Params, prompter Prompter) error {
+	proposal := p.VCS
+	if proposal == "" {
+		proposal = git.Name
+	}
+	name, err := choose(prompter, "VCS", proposal, []string{git.Name, p4.Name})
+	if err != nil {
+		return err
+	}
+	p.VCS = name
+	return nil
+}
+
+func chooseAutoPush(p *params.Params, prompter Prompter) error {
+	if p.VCS != git.Name {
+		p.AutoPush = false
+		return nil
+	}
+	p.AutoPush = prompter.Confirm("Enable git auto-push after each commit?", p.AutoPush)
+	return nil
+}
+
+func chooseCommitFailures(p *params.Params, prompter Prompter) error {
+	p.CommitFailures = prompter.Confirm("Commit failing tests instead of reverting them?", p.CommitFailures)
+	return nil
+}
+
+func chooseMobTimerDuration(p *params.Params, prompter Prompter) error {
+	answer := prompter.Ask("Mob timer duration (0 to disable)", p.MobTurnDuration.String())
+	d, err := time.ParseDuration(answer)
+	if err != nil {
+		return fmt.Errorf("invalid mob timer duration: %s", answer)
+	}
+	p.MobTurnDuration = d
+	return nil
+}
+
+// choose asks the user to pick one of the provided options
+func choose(prompter Prompter, message string, proposal string, options []string) (string, error) {
+	answer := prompter.Ask(fmt.Sprintf("%s (%s)", message, strings.Join(options, ", ")), proposal)
+	for _, option := range options {
+		if strings.EqualFold(answer, option) {
+			return option, nil
+		}
+	}
+	if answer == "" {
+		return "", fmt.Errorf("no %s selected", strings.ToLower(message))
+	}
+	return "", fmt.Errorf("%s not supported: %s", strings.ToLower(message), answer)
+}
@@ -0,0 +1,182 @@
+/*
+Copyright (c) 2023 Murex
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package wizard
+
+import (
+	"github.com/murex/tcr/params"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// scriptedPrompter is a Prompter returning predefined answers, in order.
+// An empty answer selects the default answer
+type scriptedPrompter struct {
+	answers   []string
+	questions []string
+}
+
+func (sp *scriptedPrompter) next(question string) string {
+	sp.questions = append(sp.questions, question)
+	if len(sp.answers) == 0 {
+		return ""
+	}
+	answer := sp.answers[0]
+	sp.answers = sp.answers[1:]
+	return answer
+}
+
+func (sp *scriptedPrompter) Confirm(message string, defaultAnswer bool) bool {
+	switch sp.next(message) {
+	case "y":
+		return true
+	case "n":
+		return false
+	default:
+		return defaultAnswer
+	}
+}
+
+func (sp *scriptedPrompter) Ask(message string, defaultAnswer string) string {
+	if answer := sp.next(message); answer != "" {
+		return answer
+	}
+	return defaultAnswer
+}
+
+func projectDirWith(t *testing.T, files ...string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, file := range files {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte{}, 0600))
+	}
+	return dir
+}
+
+func Test_run_wizard_accepting_detected_values(t *testing.T) {
+	p := params.Params{
+		BaseDir:         projectDirWith(t, "pom.xml"),
+		VCS:             "git",
+		MobTurnDuration: 5 * time.Minute,
+	}
+	result, err := Run(p, &scriptedPrompter{})
+	assert.NoError(t, err)
+	assert.Equal(t, "java", result.Language)
+	assert.Equal(t, "maven", result.Toolchain)
+	assert.Equal(t, "git", result.VCS)
+	assert.False(t, result.AutoPush)
+	assert.False(t, result.CommitFailures)
+	assert.Equal(t, 5*time.Minute, result.MobTurnDuration)
+}
+
+func Test_run_wizard_with_user_answers(t *testing.T) {
+	p := params.Params{
+		BaseDir:         projectDirWith(t, "pom.xml"),
+		VCS:             "git",
+		MobTurnDuration: 5 * time.Minute,
+	}
+	prompter := &scriptedPrompter{answers: []string{
+		"n", "kotlin", // language
+		"n", "gradle", // toolchain
+		"",    // vcs
+		"y",   // auto-push
+		"y",   // commit-failures
+		"10m", // mob timer
+	}}
+	result, err := Run(p, prompter)
+	assert.NoError(t, err)
+	assert.Equal(t, "kotlin", result.Language)
+	assert.Equal(t, "gradle", result.Toolchain)
+	assert.Equal(t, "git", result.VCS)
+	assert.True(t, result.AutoPush)
+	assert.True(t, result.CommitFailures)
+	assert.Equal(t, 10*time.Minute, result.MobTurnDuration)
+}
+
+func Test_run_wizard_proposes_current_configuration_values(t *testing.T) {
+	p := params.Params{
+		BaseDir:         projectDirWith(t, "pom.xml"),
+		Language:        "java",
+		Toolchain:       "gradle",
+		VCS:             "git",
+		AutoPush:        true,
+		MobTurnDuration: 3 * time.Minute,
+	}
+	prompter := &scriptedPrompter{}
+	result, err := Run(p, prompter)
+	assert.NoError(t, err)
+	assert.Equal(t, "java", result.Language)
+	assert.Equal(t, "gradle", result.Toolchain)
+	assert.True(t, result.AutoPush)
+	assert.Equal(t, 3*time.Minute, result.MobTurnDuration)
+	assert.Equal(t, "Use java language (current configuration)?", prompter.questions[0])
+	assert.Equal(t, "Use gradle toolchain (current configuration)?", prompter.questions[1])
+}
+
+func Test_run_wizard_does_not_ask_for_auto_push_with_p4(t *testing.T) {
+	p := params.Params{
+		BaseDir:         projectDirWith(t, "go.mod"),
+		VCS:             "p4",
+		AutoPush:        true,
+		MobTurnDuration: 5 * time.Minute,
+	}
+	prompter := &scriptedPrompter{}
+	result, err := Run(p, prompter)
+	assert.NoError(t, err)
+	assert.Equal(t, "p4", result.VCS)
+	assert.False(t, result.AutoPush)
+	assert.Len(t, prompter.questions, 5)
+}
+
+func Test_run_wizard_errors(t *testing.T) {
+	testFlags := []struct {
+		desc    string
+		files   []string
+		answers []string
+		errMsg  string
+	}{
+		{"no language detected", nil, nil, "no language selected"},
+		{"unsupported language", nil, []string{"cobol"}, "language not supported: cobol"},
+		{"incompatible toolchain", []string{"go.mod"}, []string{"", "n", "maven"}, "toolchain not supported: maven"},
+		{"unsupported vcs", []string{"go.mod"}, []string{"", "", "svn"}, "vcs not supported: svn"},
+		{"invalid mob timer duration", []string{"go.mod"}, []string{"", "", "", "", "", "5 minutes"},
+			"invalid mob timer duration: 5 minutes"},
+	}
+	for _, tt := range testFlags {
+		t.Run(tt.desc, func(t *testing.T) {
+			p := params.Params{BaseDir: projectDirWith(t, tt.files...), VCS: "git"}
+			_, err := Run(p, &scriptedPrompter{answers: tt.answers})
+			assert.EqualError(t, err, tt.errMsg)
+		})
+	}
+}
+
+func Test_run_wizard_explains_detected_values(t *testing.T) {
+	p := params.Params{BaseDir: projectDirWith(t, "pom.xml"), VCS: "git"}
+	prompter := &scriptedPrompter{}
+	_, _ = Run(p, prompter)
+	assert.Equal(t, "Use java language (found pom.xml: java language)?", prompter.questions[0])
+	assert.Equal(t, "Use maven toolchain (found pom.xml: maven toolchain)?", prompter.questions[1])
+}
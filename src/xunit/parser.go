@@ -27,15 +27,19 @@ import (
 	"time"
 )
 
-// TestStats is the structure containing test Stats extracted from xUnit files
+// TestStats is the structure containing test Stats extracted from xUnit files.
+// FailedTests and PassedTests contain the identifiers of the corresponding test cases,
+// tests in error being considered as failed
 type TestStats struct {
-	Total    int
-	Passed   int
-	Failed   int
-	Skipped  int
-	InError  int
-	Run      int
-	Duration time.Duration
+	Total       int
+	Passed      int
+	Failed      int
+	Skipped     int
+	InError     int
+	Run         int
+	Duration    time.Duration
+	FailedTests []string
+	PassedTests []string
 }
 
 // Parser encapsulates XUnit files parsing
@@ -109,5 +113,29 @@ func (p *Parser) extractData(suites []junit.Suite) {
 		p.Stats.InError += suite.Totals.Error
 		p.Stats.Duration += suite.Totals.Duration
 		p.Stats.Run += suite.Totals.Passed + suite.Totals.Failed + suite.Totals.Error
+		p.extractTestIDs(suite)
 	}
 }
+
+func (p *Parser) extractTestIDs(suite junit.Suite) {
+	for _, test := range suite.Tests {
+		switch test.Status {
+		case junit.StatusFailed, junit.StatusError:
+			p.Stats.FailedTests = append(p.Stats.FailedTests, TestID(test))
+		case junit.StatusPassed:
+			p.Stats.PassedTests = append(p.Stats.PassedTests, TestID(test))
+		}
+	}
+	for _, nested := range suite.Suites {
+		p.extractTestIDs(nested)
+	}
+}
+
+// TestID returns the identifier of a test case. The identifier is built from
+// the test case's class name and name, separated by a dot
+func TestID(test junit.Test) string {
+	if test.Classname == "" {
+		return test.Name
+	}
+	return test.Classname + "." + test.Name
+}
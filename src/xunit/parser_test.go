@@ -23,6 +23,7 @@ SOFTWARE.
 package xunit
 
 import (
+	"github.com/mengdaming/go-junit"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"os"
@@ -150,3 +151,36 @@ func Test_parse_dir_with_error(t *testing.T) {
 	assert.Equal(t, 0, parser.getTotalTestsRun())
 	assert.Equal(t, time.Duration(0), parser.getTotalTestDuration())
 }
+
+func Test_retrieve_xunit_failed_test_ids(t *testing.T) {
+	parser := NewParser()
+	_ = parser.parse(xunitSample)
+	assert.Equal(t, []string{
+		"JUnitXmlReporter.constructor.should default path to an empty string",
+	}, parser.Stats.FailedTests)
+}
+
+func Test_retrieve_xunit_passed_test_ids(t *testing.T) {
+	parser := NewParser()
+	_ = parser.parse(xunitSample)
+	assert.Equal(t, []string{
+		"JUnitXmlReporter.constructor.should default useDotNotation to true",
+	}, parser.Stats.PassedTests)
+}
+
+func Test_test_id(t *testing.T) {
+	testFlags := []struct {
+		desc      string
+		classname string
+		name      string
+		expected  string
+	}{
+		{"with class name", "SomeClass", "someTest", "SomeClass.someTest"},
+		{"without class name", "", "someTest", "someTest"},
+	}
+	for _, tt := range testFlags {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, TestID(junit.Test{Classname: tt.classname, Name: tt.name}))
+		})
+	}
+}